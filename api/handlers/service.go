@@ -20,7 +20,17 @@ import (
 
 type serviceCreateEventData struct {
 	keptnevents.ServiceCreateEventData `json:",inline"`
-	EventContext                       models.EventContext `json:"eventContext"`
+	EventContext                       models.EventContext    `json:"eventContext"`
+	ChartRef                           *models.ChartReference `json:"chartRef,omitempty"`
+}
+
+// internalServiceDeleteEventType is a CloudEvent type for deleting a service in all stages
+const internalServiceDeleteEventType = "sh.keptn.internal.event.service.delete"
+
+type serviceDeleteEventData struct {
+	Project      string              `json:"project"`
+	Service      string              `json:"service"`
+	EventContext models.EventContext `json:"eventContext"`
 }
 
 // PostServiceHandlerFunc creates a new service
@@ -52,7 +62,8 @@ func PostServiceHandlerFunc(params service.PostProjectProjectNameServiceParams,
 		HelmChart:            params.Service.HelmChart,
 		DeploymentStrategies: deploymentStrategies,
 	}
-	forwardData := serviceCreateEventData{ServiceCreateEventData: serviceData, EventContext: eventContext}
+	forwardData := serviceCreateEventData{ServiceCreateEventData: serviceData, EventContext: eventContext,
+		ChartRef: params.Service.ChartRef}
 
 	contentType := "application/json"
 	event := cloudevents.Event{
@@ -76,6 +87,51 @@ func PostServiceHandlerFunc(params service.PostProjectProjectNameServiceParams,
 	return service.NewPostProjectProjectNameServiceOK().WithPayload(&eventContext)
 }
 
+// DeleteServiceHandlerFunc deletes a service in all stages
+func DeleteServiceHandlerFunc(params service.DeleteProjectProjectNameServiceServiceNameParams, principal *models.Principal) middleware.Responder {
+
+	keptnContext := uuid.New().String()
+	l := keptnutils.NewLogger(keptnContext, "", "api")
+	l.Info("API received delete for service")
+
+	token, err := ws.CreateChannelInfo(keptnContext)
+	if err != nil {
+		l.Error(fmt.Sprintf("Error creating channel info %s", err.Error()))
+		return getServiceDeleteInternalError(err)
+	}
+
+	eventContext := models.EventContext{KeptnContext: &keptnContext, Token: &token}
+
+	source, _ := url.Parse("https://github.com/keptn/keptn/api")
+
+	forwardData := serviceDeleteEventData{
+		Project:      params.ProjectName,
+		Service:      params.ServiceName,
+		EventContext: eventContext,
+	}
+
+	contentType := "application/json"
+	event := cloudevents.Event{
+		Context: cloudevents.EventContextV02{
+			ID:          uuid.New().String(),
+			Time:        &types.Timestamp{Time: time.Now()},
+			Type:        internalServiceDeleteEventType,
+			Source:      types.URLRef{URL: *source},
+			ContentType: &contentType,
+			Extensions:  map[string]interface{}{"shkeptncontext": keptnContext},
+		}.AsV02(),
+		Data: forwardData,
+	}
+
+	_, err = utils.PostToEventBroker(event)
+	if err != nil {
+		l.Error(fmt.Sprintf("Error sending CloudEvent %s", err.Error()))
+		return getServiceDeleteInternalError(err)
+	}
+
+	return service.NewDeleteProjectProjectNameServiceServiceNameOK().WithPayload(&eventContext)
+}
+
 func mapDeploymentStrategies(deploymentStrategies map[string]string) (map[string]keptnevents.DeploymentStrategy, error) {
 
 	deplStrategies := make(map[string]keptnevents.DeploymentStrategy)
@@ -92,3 +148,7 @@ func mapDeploymentStrategies(deploymentStrategies map[string]string) (map[string
 func getServiceInternalError(err error) *service.PostProjectProjectNameServiceDefault {
 	return service.NewPostProjectProjectNameServiceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
 }
+
+func getServiceDeleteInternalError(err error) *service.DeleteProjectProjectNameServiceServiceNameDefault {
+	return service.NewDeleteProjectProjectNameServiceServiceNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	"github.com/cloudevents/sdk-go/pkg/cloudevents/types"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	keptnutils "github.com/keptn/go-utils/pkg/lib"
+	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/restapi/operations/stage"
+	"github.com/keptn/keptn/api/utils"
+	"github.com/keptn/keptn/api/ws"
+)
+
+// internalStageUpdateEventType is a CloudEvent type for renaming a stage
+const internalStageUpdateEventType = "sh.keptn.internal.event.stage.update"
+
+// internalStageDeleteEventType is a CloudEvent type for deleting a stage
+const internalStageDeleteEventType = "sh.keptn.internal.event.stage.delete"
+
+type stageUpdateEventData struct {
+	Project      string              `json:"project"`
+	Stage        string              `json:"stage"`
+	NewStageName string              `json:"newStageName"`
+	EventContext models.EventContext `json:"eventContext"`
+}
+
+type stageDeleteEventData struct {
+	Project      string              `json:"project"`
+	Stage        string              `json:"stage"`
+	EventContext models.EventContext `json:"eventContext"`
+}
+
+// PutStageHandlerFunc renames a stage
+func PutStageHandlerFunc(params stage.PutProjectProjectNameStageStageNameParams, principal *models.Principal) middleware.Responder {
+
+	keptnContext := uuid.New().String()
+	l := keptnutils.NewLogger(keptnContext, "", "api")
+	l.Info("API received update for stage")
+
+	if params.Stage == nil || params.Stage.StageName == nil || *params.Stage.StageName == "" {
+		return stage.NewPutProjectProjectNameStageStageNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("No stage name provided")})
+	}
+
+	token, err := ws.CreateChannelInfo(keptnContext)
+	if err != nil {
+		l.Error(fmt.Sprintf("Error creating channel info %s", err.Error()))
+		return getStageUpdateInternalError(err)
+	}
+
+	eventContext := models.EventContext{KeptnContext: &keptnContext, Token: &token}
+
+	forwardData := stageUpdateEventData{
+		Project:      params.ProjectName,
+		Stage:        params.StageName,
+		NewStageName: *params.Stage.StageName,
+		EventContext: eventContext,
+	}
+
+	_, err = utils.PostToEventBroker(getInternalStageEvent(keptnContext, internalStageUpdateEventType, forwardData))
+	if err != nil {
+		l.Error(fmt.Sprintf("Error sending CloudEvent %s", err.Error()))
+		return getStageUpdateInternalError(err)
+	}
+
+	return stage.NewPutProjectProjectNameStageStageNameOK().WithPayload(&eventContext)
+}
+
+// DeleteStageHandlerFunc deletes a stage
+func DeleteStageHandlerFunc(params stage.DeleteProjectProjectNameStageStageNameParams, principal *models.Principal) middleware.Responder {
+
+	keptnContext := uuid.New().String()
+	l := keptnutils.NewLogger(keptnContext, "", "api")
+	l.Info("API received delete for stage")
+
+	token, err := ws.CreateChannelInfo(keptnContext)
+	if err != nil {
+		l.Error(fmt.Sprintf("Error creating channel info %s", err.Error()))
+		return getStageDeleteInternalError(err)
+	}
+
+	eventContext := models.EventContext{KeptnContext: &keptnContext, Token: &token}
+
+	forwardData := stageDeleteEventData{
+		Project:      params.ProjectName,
+		Stage:        params.StageName,
+		EventContext: eventContext,
+	}
+
+	_, err = utils.PostToEventBroker(getInternalStageEvent(keptnContext, internalStageDeleteEventType, forwardData))
+	if err != nil {
+		l.Error(fmt.Sprintf("Error sending CloudEvent %s", err.Error()))
+		return getStageDeleteInternalError(err)
+	}
+
+	return stage.NewDeleteProjectProjectNameStageStageNameOK().WithPayload(&eventContext)
+}
+
+func getInternalStageEvent(keptnContext string, eventType string, data interface{}) cloudevents.Event {
+	source, _ := url.Parse("https://github.com/keptn/keptn/api")
+	contentType := "application/json"
+	return cloudevents.Event{
+		Context: cloudevents.EventContextV02{
+			ID:          uuid.New().String(),
+			Time:        &types.Timestamp{Time: time.Now()},
+			Type:        eventType,
+			Source:      types.URLRef{URL: *source},
+			ContentType: &contentType,
+			Extensions:  map[string]interface{}{"shkeptncontext": keptnContext},
+		}.AsV02(),
+		Data: data,
+	}
+}
+
+func getStageUpdateInternalError(err error) *stage.PutProjectProjectNameStageStageNameDefault {
+	return stage.NewPutProjectProjectNameStageStageNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
+}
+
+func getStageDeleteInternalError(err error) *stage.DeleteProjectProjectNameStageStageNameDefault {
+	return stage.NewDeleteProjectProjectNameStageStageNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
+}
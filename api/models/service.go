@@ -17,6 +17,9 @@ import (
 // swagger:model service
 type Service struct {
 
+	// chart ref
+	ChartRef *ChartReference `json:"chartRef,omitempty"`
+
 	// deployment strategies
 	DeploymentStrategies map[string]string `json:"deploymentStrategies,omitempty"`
 
@@ -32,6 +35,10 @@ type Service struct {
 func (m *Service) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateChartRef(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateServiceName(formats); err != nil {
 		res = append(res, err)
 	}
@@ -42,6 +49,24 @@ func (m *Service) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Service) validateChartRef(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ChartRef) { // not required
+		return nil
+	}
+
+	if m.ChartRef != nil {
+		if err := m.ChartRef.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("chartRef")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Service) validateServiceName(formats strfmt.Registry) error {
 
 	if err := validate.Required("serviceName", "body", m.ServiceName); err != nil {
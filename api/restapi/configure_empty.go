@@ -26,6 +26,7 @@ import (
 	"github.com/keptn/keptn/api/restapi/operations/metadata"
 	"github.com/keptn/keptn/api/restapi/operations/project"
 	"github.com/keptn/keptn/api/restapi/operations/service"
+	"github.com/keptn/keptn/api/restapi/operations/stage"
 )
 
 //go:generate swagger generate server --target ../../api --name  --spec ../swagger.yaml --principal models.Principal
@@ -84,6 +85,11 @@ func configureAPI(api *operations.API) http.Handler {
 
 	// Service endpoints
 	api.ServicePostProjectProjectNameServiceHandler = service.PostProjectProjectNameServiceHandlerFunc(handlers.PostServiceHandlerFunc)
+	api.ServiceDeleteProjectProjectNameServiceServiceNameHandler = service.DeleteProjectProjectNameServiceServiceNameHandlerFunc(handlers.DeleteServiceHandlerFunc)
+
+	// Stage endpoints
+	api.StagePutProjectProjectNameStageStageNameHandler = stage.PutProjectProjectNameStageStageNameHandlerFunc(handlers.PutStageHandlerFunc)
+	api.StageDeleteProjectProjectNameStageStageNameHandler = stage.DeleteProjectProjectNameStageStageNameHandlerFunc(handlers.DeleteStageHandlerFunc)
 
 	api.ServerShutdown = func() {}
 
@@ -302,6 +302,109 @@ func init() {
           "$ref": "#/parameters/projectName"
         }
       ]
+    },
+    "/project/{projectName}/service/{serviceName}": {
+      "delete": {
+        "tags": [
+          "Service"
+        ],
+        "summary": "Deletes the specified service in all stages",
+        "responses": {
+          "200": {
+            "description": "Deleting of service triggered",
+            "schema": {
+              "$ref": "response_model.yaml#/definitions/eventContext"
+            }
+          },
+          "400": {
+            "description": "Failed. Service could not be deleted",
+            "schema": {
+              "$ref": "response_model.yaml#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "response_model.yaml#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/serviceName"
+        }
+      ]
+    },
+    "/project/{projectName}/stage/{stageName}": {
+      "delete": {
+        "tags": [
+          "Stage"
+        ],
+        "summary": "Deletes the specified stage",
+        "responses": {
+          "200": {
+            "description": "Deleting of stage triggered",
+            "schema": {
+              "$ref": "response_model.yaml#/definitions/eventContext"
+            }
+          },
+          "400": {
+            "description": "Failed. Stage could not be deleted",
+            "schema": {
+              "$ref": "response_model.yaml#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "response_model.yaml#/definitions/error"
+            }
+          }
+        }
+      },
+      "put": {
+        "tags": [
+          "Stage"
+        ],
+        "summary": "Updates the specified stage",
+        "parameters": [
+          {
+            "$ref": "#/parameters/stage"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Updating of stage triggered",
+            "schema": {
+              "$ref": "response_model.yaml#/definitions/eventContext"
+            }
+          },
+          "400": {
+            "description": "Failed. Stage could not be updated",
+            "schema": {
+              "$ref": "response_model.yaml#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "response_model.yaml#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/stageName"
+        }
+      ]
     }
   },
   "parameters": {
@@ -336,6 +439,21 @@ func init() {
         "$ref": "service_model.yaml#/definitions/service"
       }
     },
+    "serviceName": {
+      "type": "string",
+      "description": "Name of the service",
+      "name": "serviceName",
+      "in": "path",
+      "required": true
+    },
+    "stage": {
+      "description": "Stage entity",
+      "name": "stage",
+      "in": "body",
+      "schema": {
+        "$ref": "stage_model.yaml#/definitions/stage"
+      }
+    },
     "stageName": {
       "type": "string",
       "description": "Name of the stage",
@@ -665,9 +783,151 @@ func init() {
           "required": true
         }
       ]
+    },
+    "/project/{projectName}/service/{serviceName}": {
+      "delete": {
+        "tags": [
+          "Service"
+        ],
+        "summary": "Deletes the specified service in all stages",
+        "responses": {
+          "200": {
+            "description": "Deleting of service triggered",
+            "schema": {
+              "$ref": "#/definitions/eventContext"
+            }
+          },
+          "400": {
+            "description": "Failed. Service could not be deleted",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the service",
+          "name": "serviceName",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/project/{projectName}/stage/{stageName}": {
+      "delete": {
+        "tags": [
+          "Stage"
+        ],
+        "summary": "Deletes the specified stage",
+        "responses": {
+          "200": {
+            "description": "Deleting of stage triggered",
+            "schema": {
+              "$ref": "#/definitions/eventContext"
+            }
+          },
+          "400": {
+            "description": "Failed. Stage could not be deleted",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "put": {
+        "tags": [
+          "Stage"
+        ],
+        "summary": "Updates the specified stage",
+        "parameters": [
+          {
+            "description": "Stage entity",
+            "name": "stage",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/stage"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Updating of stage triggered",
+            "schema": {
+              "$ref": "#/definitions/eventContext"
+            }
+          },
+          "400": {
+            "description": "Failed. Stage could not be updated",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the stage",
+          "name": "stageName",
+          "in": "path",
+          "required": true
+        }
+      ]
     }
   },
   "definitions": {
+    "chartReference": {
+      "type": "object",
+      "required": [
+        "repoURL",
+        "name"
+      ],
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "repoURL": {
+          "type": "string"
+        },
+        "version": {
+          "type": "string"
+        }
+      }
+    },
     "configureBridge": {
       "type": "object",
       "required": [
@@ -814,6 +1074,9 @@ func init() {
         "serviceName"
       ],
       "properties": {
+        "chartRef": {
+          "$ref": "#/definitions/chartReference"
+        },
         "deploymentStrategies": {
           "type": "object",
           "additionalProperties": {
@@ -827,6 +1090,17 @@ func init() {
           "type": "string"
         }
       }
+    },
+    "stage": {
+      "type": "object",
+      "required": [
+        "stageName"
+      ],
+      "properties": {
+        "stageName": {
+          "type": "string"
+        }
+      }
     }
   },
   "parameters": {
@@ -861,6 +1135,21 @@ func init() {
         "$ref": "#/definitions/service"
       }
     },
+    "serviceName": {
+      "type": "string",
+      "description": "Name of the service",
+      "name": "serviceName",
+      "in": "path",
+      "required": true
+    },
+    "stage": {
+      "description": "Stage entity",
+      "name": "stage",
+      "in": "body",
+      "schema": {
+        "$ref": "#/definitions/stage"
+      }
+    },
     "stageName": {
       "type": "string",
       "description": "Name of the stage",
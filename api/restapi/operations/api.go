@@ -25,6 +25,7 @@ import (
 	"github.com/keptn/keptn/api/restapi/operations/metadata"
 	"github.com/keptn/keptn/api/restapi/operations/project"
 	"github.com/keptn/keptn/api/restapi/operations/service"
+	"github.com/keptn/keptn/api/restapi/operations/stage"
 
 	models "github.com/keptn/keptn/api/models"
 )
@@ -49,6 +50,12 @@ func NewAPI(spec *loads.Document) *API {
 		ProjectDeleteProjectProjectNameHandler: project.DeleteProjectProjectNameHandlerFunc(func(params project.DeleteProjectProjectNameParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation ProjectDeleteProjectProjectName has not yet been implemented")
 		}),
+		ServiceDeleteProjectProjectNameServiceServiceNameHandler: service.DeleteProjectProjectNameServiceServiceNameHandlerFunc(func(params service.DeleteProjectProjectNameServiceServiceNameParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation ServiceDeleteProjectProjectNameServiceServiceName has not yet been implemented")
+		}),
+		StageDeleteProjectProjectNameStageStageNameHandler: stage.DeleteProjectProjectNameStageStageNameHandlerFunc(func(params stage.DeleteProjectProjectNameStageStageNameParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation StageDeleteProjectProjectNameStageStageName has not yet been implemented")
+		}),
 		ConfigurationGetConfigBridgeHandler: configuration.GetConfigBridgeHandlerFunc(func(params configuration.GetConfigBridgeParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation ConfigurationGetConfigBridge has not yet been implemented")
 		}),
@@ -67,6 +74,9 @@ func NewAPI(spec *loads.Document) *API {
 		ServicePostProjectProjectNameServiceHandler: service.PostProjectProjectNameServiceHandlerFunc(func(params service.PostProjectProjectNameServiceParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation ServicePostProjectProjectNameService has not yet been implemented")
 		}),
+		StagePutProjectProjectNameStageStageNameHandler: stage.PutProjectProjectNameStageStageNameHandlerFunc(func(params stage.PutProjectProjectNameStageStageNameParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation StagePutProjectProjectNameStageStageName has not yet been implemented")
+		}),
 		AuthAuthHandler: auth.AuthHandlerFunc(func(params auth.AuthParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation AuthAuth has not yet been implemented")
 		}),
@@ -121,6 +131,10 @@ type API struct {
 
 	// ProjectDeleteProjectProjectNameHandler sets the operation handler for the delete project project name operation
 	ProjectDeleteProjectProjectNameHandler project.DeleteProjectProjectNameHandler
+	// ServiceDeleteProjectProjectNameServiceServiceNameHandler sets the operation handler for the delete project project name service service name operation
+	ServiceDeleteProjectProjectNameServiceServiceNameHandler service.DeleteProjectProjectNameServiceServiceNameHandler
+	// StageDeleteProjectProjectNameStageStageNameHandler sets the operation handler for the delete project project name stage stage name operation
+	StageDeleteProjectProjectNameStageStageNameHandler stage.DeleteProjectProjectNameStageStageNameHandler
 	// ConfigurationGetConfigBridgeHandler sets the operation handler for the get config bridge operation
 	ConfigurationGetConfigBridgeHandler configuration.GetConfigBridgeHandler
 	// EventGetEventHandler sets the operation handler for the get event operation
@@ -133,6 +147,8 @@ type API struct {
 	ProjectPostProjectHandler project.PostProjectHandler
 	// ServicePostProjectProjectNameServiceHandler sets the operation handler for the post project project name service operation
 	ServicePostProjectProjectNameServiceHandler service.PostProjectProjectNameServiceHandler
+	// StagePutProjectProjectNameStageStageNameHandler sets the operation handler for the put project project name stage stage name operation
+	StagePutProjectProjectNameStageStageNameHandler stage.PutProjectProjectNameStageStageNameHandler
 	// AuthAuthHandler sets the operation handler for the auth operation
 	AuthAuthHandler auth.AuthHandler
 	// MetadataMetadataHandler sets the operation handler for the metadata operation
@@ -208,6 +224,14 @@ func (o *API) Validate() error {
 		unregistered = append(unregistered, "project.DeleteProjectProjectNameHandler")
 	}
 
+	if o.ServiceDeleteProjectProjectNameServiceServiceNameHandler == nil {
+		unregistered = append(unregistered, "service.DeleteProjectProjectNameServiceServiceNameHandler")
+	}
+
+	if o.StageDeleteProjectProjectNameStageStageNameHandler == nil {
+		unregistered = append(unregistered, "stage.DeleteProjectProjectNameStageStageNameHandler")
+	}
+
 	if o.ConfigurationGetConfigBridgeHandler == nil {
 		unregistered = append(unregistered, "configuration.GetConfigBridgeHandler")
 	}
@@ -232,6 +256,10 @@ func (o *API) Validate() error {
 		unregistered = append(unregistered, "service.PostProjectProjectNameServiceHandler")
 	}
 
+	if o.StagePutProjectProjectNameStageStageNameHandler == nil {
+		unregistered = append(unregistered, "stage.PutProjectProjectNameStageStageNameHandler")
+	}
+
 	if o.AuthAuthHandler == nil {
 		unregistered = append(unregistered, "auth.AuthHandler")
 	}
@@ -359,6 +387,16 @@ func (o *API) initHandlerCache() {
 	}
 	o.handlers["DELETE"]["/project/{projectName}"] = project.NewDeleteProjectProjectName(o.context, o.ProjectDeleteProjectProjectNameHandler)
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/project/{projectName}/service/{serviceName}"] = service.NewDeleteProjectProjectNameServiceServiceName(o.context, o.ServiceDeleteProjectProjectNameServiceServiceNameHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/project/{projectName}/stage/{stageName}"] = stage.NewDeleteProjectProjectNameStageStageName(o.context, o.StageDeleteProjectProjectNameStageStageNameHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -389,6 +427,11 @@ func (o *API) initHandlerCache() {
 	}
 	o.handlers["POST"]["/project/{projectName}/service"] = service.NewPostProjectProjectNameService(o.context, o.ServicePostProjectProjectNameServiceHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/project/{projectName}/stage/{stageName}"] = stage.NewPutProjectProjectNameStageStageName(o.context, o.StagePutProjectProjectNameStageStageNameHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
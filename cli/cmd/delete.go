@@ -6,7 +6,7 @@ import (
 
 // deleteCmd represents the delete command
 var deleteCmd = &cobra.Command{
-	Use:   "delete [project]",
+	Use:   "delete [project | service | stage]",
 	Short: "delete is the parent command of \"delete project\"",
 	Long:  `delete is the parent command of \"delete project\". \"delete\" without subcommand cannot be used.`,
 }
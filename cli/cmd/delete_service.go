@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/keptn/keptn/cli/pkg/websockethelper"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+type deleteServiceCmdParams struct {
+	Project *string
+}
+
+var deleteServiceParams *deleteServiceCmdParams
+
+// delServiceCmd represents the delete service command
+var delServiceCmd = &cobra.Command{
+	Use:   "service SERVICENAME --project=PROJECTNAME",
+	Short: "Deletes a service identified by service name in all stages of a project",
+	Long: `Deletes a service identified by service name in all stages of a project.
+
+The Helm releases of the service are uninstalled, the service is removed from the umbrella chart
+of each stage, and the service together with its resources is deleted in the configuration of each stage.
+`,
+	Example:      `keptn delete service carts --project=sockshop`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		_, _, err := credentialmanager.NewCredentialManager().GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		if len(args) != 1 {
+			cmd.SilenceUsage = false
+			return errors.New("required argument SERVICENAME not set")
+		}
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager().GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+		logging.PrintLog("Starting to delete service", logging.InfoLevel)
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if !mocking {
+			eventContext, err := sendEventContextRequest("DELETE",
+				endPoint.String()+"/v1/project/"+*deleteServiceParams.Project+"/service/"+args[0], apiToken, nil)
+			if err != nil {
+				fmt.Println("Delete service was unsuccessful")
+				return fmt.Errorf("Delete service was unsuccessful. %s", err.Error())
+			}
+
+			// if eventContext is available, open WebSocket communication
+			if eventContext != nil && !SuppressWSCommunication {
+				return websockethelper.PrintWSContentEventContext(eventContext, endPoint)
+			}
+
+			return nil
+		}
+
+		fmt.Println("Skipping delete service due to mocking flag set to true")
+		return nil
+	},
+}
+
+func init() {
+	deleteCmd.AddCommand(delServiceCmd)
+	deleteServiceParams = &deleteServiceCmdParams{}
+	deleteServiceParams.Project = delServiceCmd.Flags().StringP("project", "p", "", "The name of the project")
+	delServiceCmd.MarkFlagRequired("project")
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+func TestDeleteServiceCmd(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("delete service %s --project=%s --mock", "carts", "sockshop")
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/keptn/keptn/cli/pkg/websockethelper"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+type deleteStageCmdParams struct {
+	Project *string
+}
+
+var deleteStageParams *deleteStageCmdParams
+
+// delStageCmd represents the delete stage command
+var delStageCmd = &cobra.Command{
+	Use:   "stage STAGENAME --project=PROJECTNAME",
+	Short: "Deletes a stage identified by stage name in a project",
+	Long: `Deletes a stage identified by stage name in a project.
+
+The Git branch of the stage is deleted in the configuration of the project and in its Git upstream, if one is configured.
+Besides, the stage is removed from the shipyard file of the project.
+A namespace of the stage is not deleted.
+`,
+	Example:      `keptn delete stage hardening --project=sockshop`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		_, _, err := credentialmanager.NewCredentialManager().GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		if len(args) != 1 {
+			cmd.SilenceUsage = false
+			return errors.New("required argument STAGENAME not set")
+		}
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager().GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+		logging.PrintLog("Starting to delete stage", logging.InfoLevel)
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if !mocking {
+			eventContext, err := sendEventContextRequest("DELETE",
+				endPoint.String()+"/v1/project/"+*deleteStageParams.Project+"/stage/"+args[0], apiToken, nil)
+			if err != nil {
+				fmt.Println("Delete stage was unsuccessful")
+				return fmt.Errorf("Delete stage was unsuccessful. %s", err.Error())
+			}
+
+			// if eventContext is available, open WebSocket communication
+			if eventContext != nil && !SuppressWSCommunication {
+				return websockethelper.PrintWSContentEventContext(eventContext, endPoint)
+			}
+
+			return nil
+		}
+
+		fmt.Println("Skipping delete stage due to mocking flag set to true")
+		return nil
+	},
+}
+
+func init() {
+	deleteCmd.AddCommand(delStageCmd)
+	deleteStageParams = &deleteStageCmdParams{}
+	deleteStageParams.Project = delStageCmd.Flags().StringP("project", "p", "", "The name of the project")
+	delStageCmd.MarkFlagRequired("project")
+}
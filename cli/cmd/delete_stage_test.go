@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+func TestDeleteStageCmd(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("delete stage %s --project=%s --mock", "hardening", "sockshop")
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
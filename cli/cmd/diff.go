@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"text/tabwriter"
+
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+type diffCmdParams struct {
+	Project   *string
+	FromStage *string
+	ToStage   *string
+	Service   *string
+	Resource  *string
+}
+
+// fileDiff is a file that differs between two stages
+type fileDiff struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	Diff   string `json:"diff"`
+}
+
+// stageDiff contains the files that differ between two stages
+type stageDiff struct {
+	FromStage string      `json:"fromStage"`
+	ToStage   string      `json:"toStage"`
+	Files     []*fileDiff `json:"files"`
+}
+
+var diffParams *diffCmdParams
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff --project=PROJECTNAME --from-stage=STAGENAME --to-stage=STAGENAME",
+	Short: "Shows the differences of the configuration between two stages",
+	Long: `Shows the differences of the configuration between two stages of a project.
+
+For each file that was added, removed, or changed in the stage given by --to-stage compared to the stage given by --from-stage, a unified diff is printed.
+The comparison can be limited to the resources of a service (--service) or to a single resource path (--resource).
+`,
+	Example: `keptn diff --project=sockshop --from-stage=staging --to-stage=production
+keptn diff --project=sockshop --from-stage=staging --to-stage=production --service=carts
+keptn diff --project=sockshop --from-stage=staging --to-stage=production --service=carts --resource=slo.yaml`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		_, _, err := credentialmanager.NewCredentialManager().GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+		if *diffParams.FromStage == *diffParams.ToStage {
+			return errors.New("--from-stage and --to-stage have to be different stages")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager().GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		query := url.Values{}
+		query.Set("fromStage", *diffParams.FromStage)
+		query.Set("toStage", *diffParams.ToStage)
+		if *diffParams.Service != "" {
+			query.Set("serviceName", *diffParams.Service)
+		}
+		if *diffParams.Resource != "" {
+			query.Set("resourcePath", *diffParams.Resource)
+		}
+
+		if !mocking {
+			diff, err := getStageDiff(endPoint.Scheme+"://"+endPoint.Host+"/api/configuration-service/v1/project/"+
+				*diffParams.Project+"/diff?"+query.Encode(), apiToken)
+			if err != nil {
+				return fmt.Errorf("Stages could not be compared. %s", err.Error())
+			}
+			printStageDiff(os.Stdout, diff)
+			return nil
+		}
+
+		fmt.Println("Skipping diff due to mocking flag set to true")
+		return nil
+	},
+}
+
+func getStageDiff(endpoint string, apiToken string) (*stageDiff, error) {
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			DialContext:     apiutils.ResolveXipIoWithContext,
+		},
+	}
+
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("x-token", apiToken)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, errors.New("Received not successful response: " + string(body))
+	}
+	diff := &stageDiff{}
+	if err := json.Unmarshal(body, diff); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// printStageDiff prints a summary of the differing files followed by their unified diffs
+func printStageDiff(out io.Writer, diff *stageDiff) {
+	if len(diff.Files) == 0 {
+		fmt.Fprintf(out, "No differences between stage %s and stage %s\n", diff.FromStage, diff.ToStage)
+		return
+	}
+
+	fmt.Fprintf(out, "Differences between stage %s and stage %s:\n", diff.FromStage, diff.ToStage)
+	w := new(tabwriter.Writer)
+	w.Init(out, 10, 8, 2, ' ', 0)
+	for _, file := range diff.Files {
+		fmt.Fprintln(w, "  "+file.Status+"\t"+file.Path)
+	}
+	w.Flush()
+
+	for _, file := range diff.Files {
+		fmt.Fprintln(out)
+		fmt.Fprint(out, file.Diff)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffParams = &diffCmdParams{}
+	diffParams.Project = diffCmd.Flags().StringP("project", "p", "", "The name of the project")
+	diffCmd.MarkFlagRequired("project")
+	diffParams.FromStage = diffCmd.Flags().StringP("from-stage", "", "", "The name of the stage to compare from")
+	diffCmd.MarkFlagRequired("from-stage")
+	diffParams.ToStage = diffCmd.Flags().StringP("to-stage", "", "", "The name of the stage to compare to")
+	diffCmd.MarkFlagRequired("to-stage")
+	diffParams.Service = diffCmd.Flags().StringP("service", "", "", "The name of the service to limit the comparison to")
+	diffParams.Resource = diffCmd.Flags().StringP("resource", "", "", "The resource path to limit the comparison to (relative to the service, if a service is given)")
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+func TestDiffCmd(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("diff --project=%s --from-stage=%s --to-stage=%s --service=%s --mock", "sockshop", "staging", "production", "carts")
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+func TestPrintStageDiff(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printStageDiff(buf, &stageDiff{FromStage: "staging", ToStage: "production"})
+	if buf.String() != "No differences between stage staging and stage production\n" {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	buf.Reset()
+	printStageDiff(buf, &stageDiff{
+		FromStage: "staging",
+		ToStage:   "production",
+		Files: []*fileDiff{
+			{Path: "carts/slo.yaml", Status: "added", Diff: "--- /dev/null\n+++ b/carts/slo.yaml\n"},
+			{Path: "carts/helm/carts/values.yaml", Status: "changed", Diff: "--- a/carts/helm/carts/values.yaml\n+++ b/carts/helm/carts/values.yaml\n"},
+		},
+	})
+	for _, expected := range []string{"added    carts/slo.yaml", "changed  carts/helm/carts/values.yaml", "+++ b/carts/slo.yaml\n"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("output does not contain %q: %s", expected, buf.String())
+		}
+	}
+}
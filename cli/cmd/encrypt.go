@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// encryptCmd represents the encrypt command
+var encryptCmd = &cobra.Command{
+	Use:   "encrypt [values]",
+	Short: "Encrypts files before they are added to the configuration of a project",
+}
+
+func init() {
+	rootCmd.AddCommand(encryptCmd)
+}
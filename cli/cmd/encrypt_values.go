@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/keptn/keptn/helm-service/pkg/secretvalues"
+	keptnutils "github.com/keptn/kubernetes-utils/pkg"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type encryptValuesCmdParams struct {
+	Project        *string
+	Output         *string
+	KeptnNamespace *string
+}
+
+var encryptValuesParams *encryptValuesCmdParams
+
+// encryptValuesCmd represents the encrypt values command
+var encryptValuesCmd = &cobra.Command{
+	Use:   "values VALUESFILE --project=PROJECTNAME",
+	Short: "Encrypts a Helm values file with the key of a project",
+	Long: `Encrypts a Helm values file with the key of a project, which allows storing credentials in the configuration repository.
+
+The key of the project is stored in the Kubernetes secret *keptn-values-key-PROJECTNAME* in the namespace of Keptn. 
+If the secret does not exist, a new key is generated. The encrypted file can be added as values overlay of a service, 
+e.g., with the resource URI *helm/values/secrets.yaml*. The helm-service decrypts the values in memory when upgrading the chart.
+`,
+	Example: `keptn encrypt values secrets.yaml --project=sockshop
+keptn add-resource --project=sockshop --stage=production --service=carts --resource=secrets.enc.yaml --resourceUri=helm/values/secrets.yaml`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			cmd.SilenceUsage = false
+			return errors.New("required argument VALUESFILE not set")
+		}
+		return nil
+	},
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		valuesFile := keptnutils.ExpandTilde(args[0])
+		if !fileExists(valuesFile) {
+			return errors.New("File " + valuesFile + " not found on local file system")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		valuesFile := keptnutils.ExpandTilde(args[0])
+		values, err := ioutil.ReadFile(valuesFile)
+		if err != nil {
+			return errors.New("File " + valuesFile + " could not be read")
+		}
+		if err := yaml.Unmarshal(values, &map[string]interface{}{}); err != nil {
+			return fmt.Errorf("File %s does not contain Helm values: %v", valuesFile, err)
+		}
+
+		output := *encryptValuesParams.Output
+		if output == "" {
+			output = strings.TrimSuffix(valuesFile, filepath.Ext(valuesFile)) + ".enc.yaml"
+		}
+
+		if !mocking {
+			key, err := getValuesKey(*encryptValuesParams.Project, *encryptValuesParams.KeptnNamespace)
+			if err != nil {
+				return err
+			}
+			encrypted, err := secretvalues.Encrypt(values, key, *encryptValuesParams.Project)
+			if err != nil {
+				return fmt.Errorf("Values could not be encrypted: %v", err)
+			}
+			if err := ioutil.WriteFile(output, encrypted, 0644); err != nil {
+				return fmt.Errorf("Encrypted values could not be written to %s: %v", output, err)
+			}
+			logging.PrintLog("Encrypted values have been written to "+output, logging.InfoLevel)
+			return nil
+		}
+
+		fmt.Println("Skipping encrypt values due to mocking flag set to true")
+		return nil
+	},
+}
+
+// getValuesKey returns the key of the project and generates a new key if the project does not have one
+func getValuesKey(project string, namespace string) ([]byte, error) {
+
+	ops := options{"get",
+		"secret",
+		secretvalues.GetKeySecretName(project),
+		"-n",
+		namespace,
+		"--ignore-not-found",
+		"-ojsonpath={.data.key}"}
+	ops.appendIfNotEmpty(kubectlOptions)
+	out, err := keptnutils.ExecuteCommand("kubectl", ops)
+	if err != nil {
+		return nil, fmt.Errorf("Key of project %s could not be read: %v", project, err)
+	}
+
+	if strings.TrimSpace(out) == "" {
+		return createValuesKey(project, namespace)
+	}
+
+	// the secret contains the base64 encoded key
+	encodedKey, err := base64.StdEncoding.DecodeString(strings.TrimSpace(out))
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(string(encodedKey))
+}
+
+func createValuesKey(project string, namespace string) ([]byte, error) {
+
+	key, err := secretvalues.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// the key is passed as file, hence it does not appear in the arguments of kubectl
+	keyFile, err := ioutil.TempFile("", "keptn-values-key")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(keyFile.Name())
+	if _, err := keyFile.WriteString(base64.StdEncoding.EncodeToString(key)); err != nil {
+		keyFile.Close()
+		return nil, err
+	}
+	if err := keyFile.Close(); err != nil {
+		return nil, err
+	}
+
+	ops := options{"create",
+		"secret",
+		"generic",
+		secretvalues.GetKeySecretName(project),
+		"-n",
+		namespace,
+		"--from-file=key=" + keyFile.Name()}
+	ops.appendIfNotEmpty(kubectlOptions)
+	if _, err := keptnutils.ExecuteCommand("kubectl", ops); err != nil {
+		return nil, fmt.Errorf("Key of project %s could not be created: %v", project, err)
+	}
+	logging.PrintLog(fmt.Sprintf("Created key of project %s in secret %s", project, secretvalues.GetKeySecretName(project)), logging.InfoLevel)
+	return key, nil
+}
+
+func init() {
+	encryptCmd.AddCommand(encryptValuesCmd)
+	encryptValuesParams = &encryptValuesCmdParams{}
+	encryptValuesParams.Project = encryptValuesCmd.Flags().StringP("project", "p", "", "The name of the project")
+	encryptValuesCmd.MarkFlagRequired("project")
+	encryptValuesParams.Output = encryptValuesCmd.Flags().StringP("output", "", "", "The path of the encrypted values file. "+
+		"By default, the file is written next to the values file with the extension .enc.yaml")
+	encryptValuesParams.KeptnNamespace = encryptValuesCmd.Flags().StringP("keptn-namespace", "", "keptn", "The namespace of Keptn containing the key of the project")
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestEncryptValuesCmd(t *testing.T) {
+
+	valuesFile, err := ioutil.TempFile("", "values")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(valuesFile.Name())
+	valuesFile.WriteString("db:\n  password: secret\n")
+	valuesFile.Close()
+
+	cmd := fmt.Sprintf("encrypt values %s --project=%s --mock", valuesFile.Name(), "sockshop")
+	_, err = executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+func TestEncryptValuesCmdWithMissingFile(t *testing.T) {
+
+	cmd := fmt.Sprintf("encrypt values %s --project=%s --mock", "./missing-values.yaml", "sockshop")
+	_, err := executeActionCommandC(cmd)
+	if err == nil {
+		t.Error("expected an error for a missing values file")
+	}
+}
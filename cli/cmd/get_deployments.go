@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type getDeploymentsStruct struct {
+	project      *string
+	stage        *string
+	outputFormat *string
+}
+
+var deploymentsParameter getDeploymentsStruct
+
+// releaseRevision is a revision of a Helm release as returned by the helm-service
+type releaseRevision struct {
+	Release     string    `json:"release" yaml:"release"`
+	Revision    int       `json:"revision" yaml:"revision"`
+	Status      string    `json:"status" yaml:"status"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Deployed    time.Time `json:"deployed" yaml:"deployed"`
+	Images      []string  `json:"images,omitempty" yaml:"images,omitempty"`
+}
+
+// deploymentHistoryEntry is a deployment of a service in a stage as returned by the helm-service
+type deploymentHistoryEntry struct {
+	KeptnContext       string            `json:"keptnContext,omitempty" yaml:"keptnContext,omitempty"`
+	Image              string            `json:"image,omitempty" yaml:"image,omitempty"`
+	Tag                string            `json:"tag,omitempty" yaml:"tag,omitempty"`
+	ImageDigest        string            `json:"imageDigest,omitempty" yaml:"imageDigest,omitempty"`
+	DeploymentStrategy string            `json:"deploymentStrategy,omitempty" yaml:"deploymentStrategy,omitempty"`
+	Time               time.Time         `json:"time" yaml:"time"`
+	Result             string            `json:"result" yaml:"result"`
+	ResultDetails      string            `json:"resultDetails,omitempty" yaml:"resultDetails,omitempty"`
+	Revisions          []releaseRevision `json:"revisions" yaml:"revisions"`
+}
+
+type deploymentHistory struct {
+	Deployments []deploymentHistoryEntry `json:"deployments" yaml:"deployments"`
+}
+
+var getDeploymentsCmd = &cobra.Command{
+	Use:     "deployments SERVICENAME --project=PROJECTNAME --stage=STAGENAME",
+	Aliases: []string{"deployment"},
+	Short:   "Get the deployment history of a service in a stage",
+	Long: `Get the deployment history of a service in a stage, starting with the latest deployment.
+Each deployment lists the keptnContext, the deployed artifact, the time and result of the deployment,
+as well as the created revisions of the Helm releases of the service. Deployments without keptnContext
+were not created by keptn, e.g., manual rollbacks.`,
+	Example: `keptn get deployments carts --project=sockshop --stage=production
+KEPTN CONTEXT                          IMAGE                                  TIME                     RESULT    REVISIONS
+6d0fc4d9-8a0e-4a3c-9b44-d1b1ac1d3e2a   docker.io/keptnexamples/carts:0.10.2   2020-06-02T10:21:05Z     pass      sockshop-production-carts:2
+
+# Get the deployment history as json output
+keptn get deployments carts --project=sockshop --stage=production -o=json
+	`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			cmd.SilenceUsage = false
+			return errors.New("required argument SERVICENAME not set")
+		}
+		_, _, err := credentialmanager.NewCredentialManager().GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		if *deploymentsParameter.outputFormat != "" {
+			if *deploymentsParameter.outputFormat != "yaml" && *deploymentsParameter.outputFormat != "json" {
+				return errors.New("Invalid output format, only yaml or json allowed")
+			}
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager().GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		if mocking {
+			return nil
+		}
+
+		endpoint := endPoint.Scheme + "://" + endPoint.Host + "/api/helm-service/v1/project/" + *deploymentsParameter.project +
+			"/stage/" + *deploymentsParameter.stage + "/service/" + args[0] + "/deployment"
+		history, err := getDeploymentHistory(endpoint, apiToken)
+		if err != nil {
+			return fmt.Errorf("Failed to retrieve deployments of service %s: %v", args[0], err)
+		}
+
+		switch strings.ToLower(*deploymentsParameter.outputFormat) {
+		case "yaml":
+			yamlBytes, err := yaml.Marshal(history)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(yamlBytes))
+		case "json":
+			jsonBytes, err := json.MarshalIndent(history, "", "   ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(jsonBytes))
+		default:
+			printDeploymentHistory(history)
+		}
+		return nil
+	},
+}
+
+func getDeploymentHistory(endpoint string, apiToken string) (*deploymentHistory, error) {
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			DialContext:     apiutils.ResolveXipIoWithContext,
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("x-token", apiToken)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, errors.New("Received not successful response: " + string(body))
+	}
+	history := &deploymentHistory{}
+	if err := json.Unmarshal(body, history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func printDeploymentHistory(history *deploymentHistory) {
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 10, 8, 0, '\t', 0)
+	fmt.Fprintln(w, "KEPTN CONTEXT\tIMAGE\tTIME\tRESULT\tREVISIONS")
+
+	for _, deployment := range history.Deployments {
+		keptnContext := deployment.KeptnContext
+		if keptnContext == "" {
+			keptnContext = "n/a"
+		}
+		image := deployment.Image
+		if deployment.Tag != "" {
+			image += ":" + deployment.Tag
+		}
+		if deployment.ImageDigest != "" {
+			image += "@" + deployment.ImageDigest
+		}
+		revisions := []string{}
+		for _, revision := range deployment.Revisions {
+			revisions = append(revisions, revision.Release+":"+strconv.Itoa(revision.Revision))
+		}
+		fmt.Fprintln(w, keptnContext+"\t"+image+"\t"+deployment.Time.Format(time.RFC3339)+"\t"+
+			deployment.Result+"\t"+strings.Join(revisions, ", "))
+	}
+	w.Flush()
+}
+
+func init() {
+	getCmd.AddCommand(getDeploymentsCmd)
+
+	deploymentsParameter.project = getDeploymentsCmd.Flags().StringP("project", "", "",
+		"keptn project name")
+	getDeploymentsCmd.MarkFlagRequired("project")
+	deploymentsParameter.stage = getDeploymentsCmd.Flags().StringP("stage", "", "",
+		"keptn stage name")
+	getDeploymentsCmd.MarkFlagRequired("stage")
+	deploymentsParameter.outputFormat = getDeploymentsCmd.Flags().StringP("output", "o", "",
+		"Output format. One of json|yaml")
+}
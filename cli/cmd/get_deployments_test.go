@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+// TestGetDeployments tests the get deployments command
+func TestGetDeployments(t *testing.T) {
+
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("get deployments carts --project=sockshop --stage=production --mock")
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestGetDeploymentsWithoutService tests that the get deployments command requires a service
+func TestGetDeploymentsWithoutService(t *testing.T) {
+
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("get deployments --project=sockshop --stage=production --mock")
+	_, err := executeActionCommandC(cmd)
+	if err == nil {
+		t.Error("expected an error as the service is missing")
+	}
+}
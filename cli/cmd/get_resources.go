@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type getResourcesStruct struct {
+	project      *string
+	stage        *string
+	service      *string
+	filter       *string
+	withVersions *bool
+	outputFormat *string
+}
+
+// resourceVersion is a version (i.e. commit) of a resource
+type resourceVersion struct {
+	Version string `json:"version" yaml:"version"`
+	Author  string `json:"author" yaml:"author"`
+	Time    string `json:"time" yaml:"time"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// serviceResource is a resource of a service
+type serviceResource struct {
+	ResourceURI   string           `json:"resourceURI" yaml:"resourceURI"`
+	LatestVersion *resourceVersion `json:"latestVersion,omitempty" yaml:"latestVersion,omitempty"`
+}
+
+// serviceResources is a page of resources of a service
+type serviceResources struct {
+	NextPageKey string             `json:"nextPageKey"`
+	Resources   []*serviceResource `json:"resources"`
+}
+
+var getResources getResourcesStruct
+
+// getResourcesCmd represents the get resources command
+var getResourcesCmd = &cobra.Command{
+	Use:     "resources --project=PROJECTNAME --stage=STAGENAME --service=SERVICENAME",
+	Aliases: []string{"resource"},
+	Short:   "Get the resources of a service",
+	Long: `Get the resources (e.g. SLOs, JMeter scripts, Helm charts) of a service in a stage of a keptn project.
+
+The resources can be filtered by a path prefix (e.g. helm/) or a glob pattern (e.g. *.jmx).
+With --with-versions, the latest version (i.e. commit) that modified each resource is shown.
+`,
+	Example: `keptn get resources --project=sockshop --stage=staging --service=carts
+NAME
+slo.yaml
+jmeter/load.jmx
+
+keptn get resources --project=sockshop --stage=staging --service=carts --filter=*.jmx --with-versions
+NAME              VERSION    AUTHOR    DATE
+jmeter/load.jmx   3e5d3ba    keptn     2020-06-02T10:25:58+02:00
+`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		_, _, err := credentialmanager.NewCredentialManager().GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		if *getResources.outputFormat != "" {
+			if *getResources.outputFormat != "yaml" && *getResources.outputFormat != "json" {
+				return errors.New("Invalid output format, only yaml or json allowed")
+			}
+		}
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager().GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if !mocking {
+			resources := []*serviceResource{}
+			nextPageKey := ""
+			for {
+				query := url.Values{}
+				if nextPageKey != "" {
+					query.Set("nextPageKey", nextPageKey)
+				}
+				if *getResources.filter != "" {
+					query.Set("resourceFilter", *getResources.filter)
+				}
+				if *getResources.withVersions {
+					query.Set("includeLatestVersion", "true")
+				}
+
+				page := &serviceResources{}
+				err := getJSONResponse(endPoint.Scheme+"://"+endPoint.Host+"/api/configuration-service/v1/project/"+
+					*getResources.project+"/stage/"+*getResources.stage+"/service/"+*getResources.service+"/resource?"+query.Encode(), apiToken, page)
+				if err != nil {
+					return fmt.Errorf("Failed to retrieve resources of service %s: %v", *getResources.service, err)
+				}
+				resources = append(resources, page.Resources...)
+
+				if page.NextPageKey == "" || page.NextPageKey == "0" {
+					break
+				}
+				nextPageKey = page.NextPageKey
+			}
+			return printServiceResources(os.Stdout, resources, *getResources.withVersions, *getResources.outputFormat)
+		}
+
+		fmt.Println("Skipping get resources due to mocking flag set to true")
+		return nil
+	},
+}
+
+// getJSONResponse sends a GET request to the endpoint and unmarshals the JSON response into target
+func getJSONResponse(endpoint string, apiToken string, target interface{}) error {
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			DialContext:     apiutils.ResolveXipIoWithContext,
+		},
+	}
+
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("x-token", apiToken)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.New("Received not successful response: " + string(body))
+	}
+	return json.Unmarshal(body, target)
+}
+
+// printServiceResources prints the resources as table, yaml, or json
+func printServiceResources(out io.Writer, resources []*serviceResource, withVersions bool, outputFormat string) error {
+	switch strings.ToLower(outputFormat) {
+	case "yaml":
+		yamlBytes, err := yaml.Marshal(resources)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(yamlBytes))
+	case "json":
+		jsonBytes, err := json.MarshalIndent(resources, "", "   ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(jsonBytes))
+	default:
+		w := new(tabwriter.Writer)
+		w.Init(out, 10, 8, 2, ' ', 0)
+		if withVersions {
+			fmt.Fprintln(w, "NAME\tVERSION\tAUTHOR\tDATE")
+		} else {
+			fmt.Fprintln(w, "NAME")
+		}
+		for _, resource := range resources {
+			if !withVersions {
+				fmt.Fprintln(w, resource.ResourceURI)
+			} else if resource.LatestVersion == nil {
+				fmt.Fprintln(w, resource.ResourceURI+"\tn/a\tn/a\tn/a")
+			} else {
+				version := resource.LatestVersion.Version
+				if len(version) > 7 {
+					version = version[:7]
+				}
+				fmt.Fprintln(w, resource.ResourceURI+"\t"+version+"\t"+resource.LatestVersion.Author+"\t"+resource.LatestVersion.Time)
+			}
+		}
+		w.Flush()
+	}
+	return nil
+}
+
+func init() {
+	getCmd.AddCommand(getResourcesCmd)
+
+	getResources.project = getResourcesCmd.Flags().StringP("project", "", "", "keptn project name")
+	getResourcesCmd.MarkFlagRequired("project")
+	getResources.stage = getResourcesCmd.Flags().StringP("stage", "", "", "keptn stage name")
+	getResourcesCmd.MarkFlagRequired("stage")
+	getResources.service = getResourcesCmd.Flags().StringP("service", "", "", "keptn service name")
+	getResourcesCmd.MarkFlagRequired("service")
+	getResources.filter = getResourcesCmd.Flags().StringP("filter", "", "",
+		"Only show resources starting with this path prefix or matching this glob pattern")
+	getResources.withVersions = getResourcesCmd.Flags().BoolP("with-versions", "", false,
+		"Show the latest version that modified each resource")
+	getResources.outputFormat = getResourcesCmd.Flags().StringP("output", "o", "",
+		"Output format. One of json|yaml")
+}
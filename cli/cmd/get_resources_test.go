@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+func TestGetResources(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("get resources --project=sockshop --stage=staging --service=carts --filter=*.jmx --with-versions --mock")
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+func TestPrintServiceResources(t *testing.T) {
+	resources := []*serviceResource{
+		{
+			ResourceURI: "jmeter/load.jmx",
+			LatestVersion: &resourceVersion{
+				Version: "3e5d3ba3ce1c1d2a2bd0f77ac2d6b1bd4fc2a3e4",
+				Author:  "keptn",
+				Time:    "2020-06-02T10:25:58+02:00",
+			},
+		},
+		{ResourceURI: "slo.yaml"},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := printServiceResources(buf, resources, true, ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"NAME", "jmeter/load.jmx  3e5d3ba   keptn", "slo.yaml         n/a"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("output does not contain %q: %s", expected, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := printServiceResources(buf, resources, false, "json"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"resourceURI": "slo.yaml"`) {
+		t.Errorf("unexpected json output: %s", buf.String())
+	}
+}
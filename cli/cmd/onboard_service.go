@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"bytes"
+	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"os"
 
 	"github.com/keptn/keptn/cli/pkg/websockethelper"
@@ -12,29 +17,53 @@ import (
 	apiutils "github.com/keptn/go-utils/pkg/api/utils"
 	keptn "github.com/keptn/go-utils/pkg/lib"
 	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/kustomize"
 	"github.com/keptn/keptn/cli/pkg/logging"
 	"github.com/keptn/keptn/cli/pkg/validator"
 	keptnutils "github.com/keptn/kubernetes-utils/pkg"
 	"github.com/spf13/cobra"
+	helmchart "helm.sh/helm/v3/pkg/chart"
 )
 
 type onboardServiceCmdParams struct {
 	Project            *string
 	ChartFilePath      *string
+	KustomizationPath  *string
+	ChartRepository    *string
+	ChartVersion       *string
 	DeploymentStrategy *string
 }
 
+// chartReference references a chart in a Helm chart repository, which is pulled by the helm-service
+type chartReference struct {
+	RepoURL string `json:"repoURL"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// createServiceWithChartReference extends the service entity by a chart reference
+type createServiceWithChartReference struct {
+	apimodels.CreateService
+	ChartRef *chartReference `json:"chartRef"`
+}
+
 var onboardServiceParams *onboardServiceCmdParams
 
 // serviceCmd represents the service command
 var serviceCmd = &cobra.Command{
 	Use:   "service SERVICENAME --project=PROJECTNAME --chart=FILEPATH",
-	Short: "Onboards a new service and its Helm chart to a project",
-	Long: `Onboards a new service and its Helm chart to the provided project. Therefore, this command 
+	Short: "Onboards a new service and its Helm chart or kustomization to a project",
+	Long: `Onboards a new service and its Helm chart or kustomization to the provided project. Therefore, this command 
 takes a folder to a Helm chart or an already packed Helm chart as .tgz.
+Alternatively, it takes a folder containing a kustomization with a base in base/ and optional overlays 
+for the stages in overlays/STAGENAME/.
+If a chart repository is provided, the chart flag contains the name of a chart in this repository. Then, the chart 
+is not uploaded but pulled from the repository by Keptn.
 `,
 	Example: `keptn onboard service SERVICENAME --project=PROJECTNAME --chart=FILEPATH
-keptn onboard service SERVICENAME --project=PROJECTNAME --chart=HELM_CHART.tgz`,
+keptn onboard service SERVICENAME --project=PROJECTNAME --chart=HELM_CHART.tgz
+keptn onboard service SERVICENAME --project=PROJECTNAME --kustomization=FILEPATH
+keptn onboard service SERVICENAME --project=PROJECTNAME --chart=CHARTNAME --chart-repo=REPOSITORY_URL --chart-version=VERSION`,
 	SilenceUsage: true,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -59,6 +88,31 @@ keptn onboard service SERVICENAME --project=PROJECTNAME --chart=HELM_CHART.tgz`,
 			return errors.New("The provided deployment strategy is not supported. Select: [direct|blue_green_service]")
 		}
 
+		if (*onboardServiceParams.ChartFilePath == "") == (*onboardServiceParams.KustomizationPath == "") {
+			return errors.New("Either a Helm chart or a kustomization has to be provided")
+		}
+
+		if *onboardServiceParams.ChartRepository != "" {
+			if *onboardServiceParams.ChartFilePath == "" {
+				return errors.New("The name of the chart in the chart repository has to be provided by the chart flag")
+			}
+			// The chart is validated by the helm-service after pulling it
+			return nil
+		}
+		if *onboardServiceParams.ChartVersion != "" {
+			return errors.New("A chart version can only be provided together with a chart repository")
+		}
+
+		// validate kustomization flag
+		if *onboardServiceParams.KustomizationPath != "" {
+			*onboardServiceParams.KustomizationPath = keptnutils.ExpandTilde(*onboardServiceParams.KustomizationPath)
+
+			if _, err := os.Stat(*onboardServiceParams.KustomizationPath); os.IsNotExist(err) {
+				return errors.New("Provided kustomization does not exist")
+			}
+			return kustomize.Validate(*onboardServiceParams.KustomizationPath)
+		}
+
 		// validate chart flag
 		*onboardServiceParams.ChartFilePath = keptnutils.ExpandTilde(*onboardServiceParams.ChartFilePath)
 
@@ -89,7 +143,47 @@ keptn onboard service SERVICENAME --project=PROJECTNAME --chart=HELM_CHART.tgz`,
 		}
 		logging.PrintLog("Starting to onboard service", logging.InfoLevel)
 
-		chart, err := keptnutils.LoadChartFromPath(*onboardServiceParams.ChartFilePath)
+		if *onboardServiceParams.ChartRepository != "" {
+			service := createServiceWithChartReference{
+				CreateService: apimodels.CreateService{ServiceName: &args[0]},
+				ChartRef: &chartReference{
+					RepoURL: *onboardServiceParams.ChartRepository,
+					Name:    *onboardServiceParams.ChartFilePath,
+					Version: *onboardServiceParams.ChartVersion,
+				},
+			}
+			deplStrategies, err := getDeploymentStrategies(*onboardServiceParams.DeploymentStrategy)
+			if err != nil {
+				return err
+			}
+			service.DeploymentStrategies = deplStrategies
+
+			logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+			if !mocking {
+				eventContext, err := createServiceFromChartRepository(endPoint.String()+"/v1/project/"+*onboardServiceParams.Project+"/service",
+					apiToken, service)
+				if err != nil {
+					logging.PrintLog("Onboard service was unsuccessful", logging.QuietLevel)
+					return fmt.Errorf("Onboard service was unsuccessful. %s", err.Error())
+				}
+
+				// if eventContext is available, open WebSocket communication
+				if eventContext != nil && !SuppressWSCommunication {
+					return websockethelper.PrintWSContentEventContext(eventContext, endPoint)
+				}
+				return nil
+			}
+
+			fmt.Println("Skipping onboard service due to mocking flag set to true")
+			return nil
+		}
+
+		var chart *helmchart.Chart
+		if *onboardServiceParams.KustomizationPath != "" {
+			chart, err = kustomize.LoadChart(*onboardServiceParams.KustomizationPath, args[0])
+		} else {
+			chart, err = keptnutils.LoadChartFromPath(*onboardServiceParams.ChartFilePath)
+		}
 		if err != nil {
 			return err
 		}
@@ -105,19 +199,11 @@ keptn onboard service SERVICENAME --project=PROJECTNAME --chart=HELM_CHART.tgz`,
 			HelmChart:   helmChart,
 		}
 
-		if *onboardServiceParams.DeploymentStrategy != "" {
-			deplStrategies := make(map[string]string)
-
-			if *onboardServiceParams.DeploymentStrategy == "direct" {
-				deplStrategies["*"] = keptn.Direct.String()
-			} else if *onboardServiceParams.DeploymentStrategy == "blue_green_service" {
-				deplStrategies["*"] = keptn.Duplicate.String()
-			} else {
-				return fmt.Errorf("The provided deployment strategy %s is not supported. Select: [direct|blue_green_service]", *onboardServiceParams.DeploymentStrategy)
-			}
-
-			service.DeploymentStrategies = deplStrategies
+		deplStrategies, err := getDeploymentStrategies(*onboardServiceParams.DeploymentStrategy)
+		if err != nil {
+			return err
 		}
+		service.DeploymentStrategies = deplStrategies
 
 		apiHandler := apiutils.NewAuthenticatedAPIHandler(endPoint.String(), apiToken, "x-token", nil, endPoint.Scheme)
 		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
@@ -142,14 +228,90 @@ keptn onboard service SERVICENAME --project=PROJECTNAME --chart=HELM_CHART.tgz`,
 	},
 }
 
+// getDeploymentStrategies maps the deployment strategy flag to the deployment strategies of all stages
+func getDeploymentStrategies(deploymentStrategy string) (map[string]string, error) {
+
+	if deploymentStrategy == "" {
+		return nil, nil
+	}
+	deplStrategies := make(map[string]string)
+	if deploymentStrategy == "direct" {
+		deplStrategies["*"] = keptn.Direct.String()
+	} else if deploymentStrategy == "blue_green_service" {
+		deplStrategies["*"] = keptn.Duplicate.String()
+	} else {
+		return nil, fmt.Errorf("The provided deployment strategy %s is not supported. Select: [direct|blue_green_service]", deploymentStrategy)
+	}
+	return deplStrategies, nil
+}
+
+// createServiceFromChartRepository creates the service using the API directly because the service entity of
+// the API client does not contain the chart reference
+func createServiceFromChartRepository(endpoint string, apiToken string, service createServiceWithChartReference) (*apimodels.EventContext, error) {
+
+	payload, err := json.Marshal(service)
+	if err != nil {
+		return nil, err
+	}
+	return sendEventContextRequest("POST", endpoint, apiToken, payload)
+}
+
+// sendEventContextRequest sends a request to an API endpoint of Keptn, which is not covered by go-utils,
+// and returns the event context of the response
+func sendEventContextRequest(method string, endpoint string, apiToken string, payload []byte) (*apimodels.EventContext, error) {
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			DialContext:     apiutils.ResolveXipIoWithContext,
+		},
+	}
+
+	req, err := http.NewRequest(method, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("x-token", apiToken)
+	req.Header.Add("content-type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, errors.New("Received not successful response: " + string(body))
+	}
+	if len(body) == 0 {
+		return nil, nil
+	}
+	eventContext := &apimodels.EventContext{}
+	if err := json.Unmarshal(body, eventContext); err != nil {
+		return nil, err
+	}
+	return eventContext, nil
+}
+
 func init() {
 	onboardCmd.AddCommand(serviceCmd)
 	onboardServiceParams = &onboardServiceCmdParams{}
 	onboardServiceParams.Project = serviceCmd.Flags().StringP("project", "p", "", "The name of the project")
 	serviceCmd.MarkFlagRequired("project")
 
-	onboardServiceParams.ChartFilePath = serviceCmd.Flags().StringP("chart", "", "", "A path to a Helm chart folder or an already archived Helm chart")
-	serviceCmd.MarkFlagRequired("chart")
+	onboardServiceParams.ChartFilePath = serviceCmd.Flags().StringP("chart", "", "", "A path to a Helm chart folder or an already archived Helm chart, "+
+		"or the name of the chart if a chart repository is provided")
+
+	onboardServiceParams.ChartRepository = serviceCmd.Flags().StringP("chart-repo", "", "", "The URL of a Helm chart repository containing the chart")
+
+	onboardServiceParams.ChartVersion = serviceCmd.Flags().StringP("chart-version", "", "", "The version or a version constraint of the chart in the chart repository. "+
+		"If no version is specified, the latest stable version is used")
+
+	onboardServiceParams.KustomizationPath = serviceCmd.Flags().StringP("kustomization", "", "", "A path to a folder containing a kustomization base and overlays for the stages")
 
 	onboardServiceParams.DeploymentStrategy = serviceCmd.Flags().StringP("deployment-strategy", "", "", "Allows to define a deployment strategy that overrides the shipyard definition for this service")
 }
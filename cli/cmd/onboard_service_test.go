@@ -46,3 +46,33 @@ func TestOnboardServiceDeploymentStrategy(t *testing.T) {
 		t.Errorf("Error actual = %v, and Expected = %v.", err, expected)
 	}
 }
+
+// TestOnboardServiceChartVersionWithoutRepository tests the onboard service command.
+func TestOnboardServiceChartVersionWithoutRepository(t *testing.T) {
+
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("onboard service carts --project=sockshop --chart=carts --chart-version=0.1.0 --deployment-strategy=direct")
+	_, err := executeActionCommandC(cmd)
+	if err == nil {
+		t.Errorf("Expected error event, but no one received.")
+	}
+
+	expected := "A chart version can only be provided together with a chart repository"
+	if err.Error() != expected {
+		t.Errorf("Error actual = %v, and Expected = %v.", err, expected)
+	}
+}
+
+// TestOnboardServiceFromChartRepository tests the onboard service command.
+func TestOnboardServiceFromChartRepository(t *testing.T) {
+
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("onboard service carts --project=sockshop --chart=carts " +
+		"--chart-repo=https://charts.example.com --chart-version=0.1.0 --deployment-strategy=direct --mock")
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
@@ -6,7 +6,7 @@ import (
 
 // createCmd implements the create command
 var updateCmd = &cobra.Command{
-	Use: "update [project]",
+	Use: "update [project | stage]",
 }
 
 func init() {
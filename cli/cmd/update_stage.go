@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/keptn/keptn/cli/pkg/websockethelper"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+type updateStageCmdParams struct {
+	Project *string
+	Name    *string
+}
+
+type stageUpdate struct {
+	StageName string `json:"stageName"`
+}
+
+var updateStageParams *updateStageCmdParams
+
+// upStageCmd represents the update stage command
+var upStageCmd = &cobra.Command{
+	Use:   "stage STAGENAME --project=PROJECTNAME --name=NEW_STAGENAME",
+	Short: "Renames a stage of a project",
+	Long: `Renames a stage identified by stage name in a project.
+
+The Git branch of the stage is renamed while keeping its history. If a Git upstream is configured, the renamed branch is pushed
+and the old branch is deleted in the upstream. Besides, the stage is renamed in the shipyard file of the project.
+A namespace of the stage is not renamed.
+`,
+	Example:      `keptn update stage dev --project=sockshop --name=staging`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		_, _, err := credentialmanager.NewCredentialManager().GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		if len(args) != 1 {
+			cmd.SilenceUsage = false
+			return errors.New("required argument STAGENAME not set")
+		}
+
+		if !keptn.ValidateKeptnEntityName(*updateStageParams.Name) {
+			errorMsg := "Stage name contains upper case letter(s) or special character(s).\n"
+			errorMsg += "Keptn relies on the following conventions: "
+			errorMsg += "start with a lower case letter, then lower case letters, numbers, and hyphens are allowed.\n"
+			errorMsg += "Please update stage name and try again."
+			return errors.New(errorMsg)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager().GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+		logging.PrintLog("Starting to update stage", logging.InfoLevel)
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		payload, err := json.Marshal(stageUpdate{StageName: *updateStageParams.Name})
+		if err != nil {
+			return err
+		}
+
+		if !mocking {
+			eventContext, err := sendEventContextRequest("PUT",
+				endPoint.String()+"/v1/project/"+*updateStageParams.Project+"/stage/"+args[0], apiToken, payload)
+			if err != nil {
+				fmt.Println("Update stage was unsuccessful")
+				return fmt.Errorf("Update stage was unsuccessful. %s", err.Error())
+			}
+
+			// if eventContext is available, open WebSocket communication
+			if eventContext != nil && !SuppressWSCommunication {
+				return websockethelper.PrintWSContentEventContext(eventContext, endPoint)
+			}
+
+			return nil
+		}
+
+		fmt.Println("Skipping update stage due to mocking flag set to true")
+		return nil
+	},
+}
+
+func init() {
+	updateCmd.AddCommand(upStageCmd)
+	updateStageParams = &updateStageCmdParams{}
+	updateStageParams.Project = upStageCmd.Flags().StringP("project", "p", "", "The name of the project")
+	upStageCmd.MarkFlagRequired("project")
+	updateStageParams.Name = upStageCmd.Flags().StringP("name", "n", "", "The new name of the stage")
+	upStageCmd.MarkFlagRequired("name")
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+func TestUpdateStageCmd(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("update stage %s --project=%s --name=%s --mock", "dev", "sockshop", "staging")
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+func TestUpdateStageCmdWithInvalidName(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("update stage %s --project=%s --name=%s --mock", "dev", "sockshop", "Staging")
+	_, err := executeActionCommandC(cmd)
+	if err == nil {
+		t.Error("expected an error for an invalid stage name")
+	}
+}
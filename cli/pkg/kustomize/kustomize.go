@@ -0,0 +1,81 @@
+package kustomize
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"helm.sh/helm/v3/pkg/chart"
+	kustomizebuild "k8s.io/cli-runtime/pkg/kustomize"
+	"sigs.k8s.io/kustomize/pkg/fs"
+)
+
+// keyword marks a chart which transports a kustomization to the helm-service
+const keyword = "kustomize"
+
+// filePrefix is the prefix of the kustomization files in the chart
+const filePrefix = "kustomize/"
+
+// Validate checks that the directory contains a base and that the base and all overlays can be built
+func Validate(dir string) error {
+
+	if _, err := os.Stat(filepath.Join(dir, "base", "kustomization.yaml")); os.IsNotExist(err) {
+		return errors.New("Provided kustomization does not contain base/kustomization.yaml")
+	}
+
+	paths := []string{filepath.Join(dir, "base")}
+	overlays, err := ioutil.ReadDir(filepath.Join(dir, "overlays"))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, overlay := range overlays {
+		if overlay.IsDir() {
+			paths = append(paths, filepath.Join(dir, "overlays", overlay.Name()))
+		}
+	}
+
+	for _, path := range paths {
+		var out bytes.Buffer
+		if err := kustomizebuild.RunKustomizeBuild(&out, fs.MakeRealFS(), path); err != nil {
+			return fmt.Errorf("Error when building kustomization %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// LoadChart packs the kustomization of the directory as files of a chart without templates.
+// The helm-service stores the base and the overlay of each stage and deploys the objects built by kustomize.
+func LoadChart(dir string, service string) (*chart.Chart, error) {
+
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: "v2",
+			Name:       service,
+			Keywords:   []string{keyword},
+			Version:    "0.1.0",
+		},
+	}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		ch.Files = append(ch.Files, &chart.File{Name: filePrefix + filepath.ToSlash(relPath), Data: data})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
+}
@@ -9,6 +9,7 @@ import (
 	"helm.sh/helm/v3/pkg/chart"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var reservedFileNameSuffixes = [...]string{"-istio-destinationrule.yaml", "-istio-virtualservice.yaml"}
@@ -33,7 +34,11 @@ func ValidateHelmChart(ch *chart.Chart) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	if resDeployment, err := validateDeployments(deployments); !resDeployment || err != nil {
+	statefulSets, daemonSets, err := getRenderedStatefulSetsAndDaemonSets(ch)
+	if err != nil {
+		return false, err
+	}
+	if resWorkloads, err := validateWorkloads(deployments, statefulSets, daemonSets); !resWorkloads || err != nil {
 		return false, err
 	}
 	if !validateTemplateFileNames(ch) {
@@ -81,14 +86,25 @@ func validateServices(services []*corev1.Service) (bool, error) {
 	return true, nil
 }
 
-func validateDeployments(deployments []*appsv1.Deployment) (bool, error) {
+func validateWorkloads(deployments []*appsv1.Deployment, statefulSets []*appsv1.StatefulSet,
+	daemonSets []*appsv1.DaemonSet) (bool, error) {
 	for _, depl := range deployments {
 		if !validateDeployment(depl) {
 			return false, nil
 		}
 	}
-	if len(deployments) != 1 {
-		logging.PrintLog("Helm chart must contain exactly one deployment", logging.QuietLevel)
+	for _, sts := range statefulSets {
+		if !validatePodSelector("StatefulSet", sts.Name, sts.Spec.Selector, sts.Spec.Template.ObjectMeta.Labels) {
+			return false, nil
+		}
+	}
+	for _, ds := range daemonSets {
+		if !validatePodSelector("DaemonSet", ds.Name, ds.Spec.Selector, ds.Spec.Template.ObjectMeta.Labels) {
+			return false, nil
+		}
+	}
+	if len(deployments)+len(statefulSets)+len(daemonSets) != 1 {
+		logging.PrintLog("Helm chart must contain exactly one deployment, statefulset, or daemonset", logging.QuietLevel)
 		return false, nil
 	}
 	return true, nil
@@ -117,28 +133,33 @@ func validateDeployment(depl *appsv1.Deployment) bool {
 		logging.PrintLog(fmt.Sprintf("Deployment %s does not have kind \"deployment\"", depl.Name), logging.QuietLevel)
 		return false
 	}
-	if depl.Spec.Selector == nil {
-		logging.PrintLog(fmt.Sprintf("Deployment %s does not contain \"selector\"", depl.Name), logging.QuietLevel)
+	return validatePodSelector("Deployment", depl.Name, depl.Spec.Selector, depl.Spec.Template.ObjectMeta.Labels)
+}
+
+// validatePodSelector validates that the workload selects its pods by the app label
+func validatePodSelector(kind string, name string, selector *metav1.LabelSelector, podLabels map[string]string) bool {
+	if selector == nil {
+		logging.PrintLog(fmt.Sprintf("%s %s does not contain \"selector\"", kind, name), logging.QuietLevel)
 		return false
 	}
-	if depl.Spec.Selector.MatchLabels == nil {
-		logging.PrintLog(fmt.Sprintf("Deployment %s does not contain \"selector.matchLabels\"", depl.Name), logging.QuietLevel)
+	if selector.MatchLabels == nil {
+		logging.PrintLog(fmt.Sprintf("%s %s does not contain \"selector.matchLabels\"", kind, name), logging.QuietLevel)
 		return false
 	}
-	if depl.Spec.Template.ObjectMeta.Labels == nil {
-		logging.PrintLog(fmt.Sprintf("Deployment %s does not contain \"spec.template.metadata.labels\"", depl.Name), logging.QuietLevel)
+	if podLabels == nil {
+		logging.PrintLog(fmt.Sprintf("%s %s does not contain \"spec.template.metadata.labels\"", kind, name), logging.QuietLevel)
 		return false
 	}
-	mLabelApp, okmLabelApp := depl.Spec.Selector.MatchLabels["app"]
-	mLabelAppk8sName, okmLabelAppk8sName := depl.Spec.Selector.MatchLabels["app.kubernetes.io/name"]
+	mLabelApp, okmLabelApp := selector.MatchLabels["app"]
+	mLabelAppk8sName, okmLabelAppk8sName := selector.MatchLabels["app.kubernetes.io/name"]
 	if (!okmLabelApp || mLabelApp == "") && (!okmLabelAppk8sName || mLabelAppk8sName == "") {
-		logging.PrintLog(fmt.Sprintf("Deployment %s does not contain \"spec.selector.matchLabels.app\"", depl.Name), logging.QuietLevel)
+		logging.PrintLog(fmt.Sprintf("%s %s does not contain \"spec.selector.matchLabels.app\"", kind, name), logging.QuietLevel)
 		return false
 	}
-	podLabelApp, okPodLabelApp := depl.Spec.Template.ObjectMeta.Labels["app"]
-	podLabelAppk8sName, okPodLabelAppk8sName := depl.Spec.Template.ObjectMeta.Labels["app.kubernetes.io/name"]
+	podLabelApp, okPodLabelApp := podLabels["app"]
+	podLabelAppk8sName, okPodLabelAppk8sName := podLabels["app.kubernetes.io/name"]
 	if (!okPodLabelApp || podLabelApp == "") && (!okPodLabelAppk8sName || podLabelAppk8sName == "") {
-		logging.PrintLog(fmt.Sprintf("Deployment %s does not contain \"spec.template.metadata.labels.app\"", depl.Name), logging.QuietLevel)
+		logging.PrintLog(fmt.Sprintf("%s %s does not contain \"spec.template.metadata.labels.app\"", kind, name), logging.QuietLevel)
 		return false
 	}
 	return true
@@ -345,3 +345,84 @@ func TestTemplateFileNames(t *testing.T) {
 	assert.False(t, res)
 	os.RemoveAll("carts")
 }
+
+func TestCheckStatefulSetAndDaemonSet(t *testing.T) {
+
+	const values = `
+image: docker.io/keptnexamples/carts-db:0.8.1
+replicaCount: 1
+`
+
+	const statefulSet = `--- 
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: carts-db
+spec:
+  serviceName: carts-db
+  replicas: {{ .Values.replicaCount }}
+  selector:
+    matchLabels:
+      app: carts-db
+  template:
+    metadata:
+      labels:
+        app: carts-db
+    spec:
+      containers:
+      - name: carts-db
+        image: "{{ .Values.image }}"
+`
+
+	const daemonSet = `--- 
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: carts-agent
+spec:
+  selector:
+    matchLabels:
+      app: carts-agent
+  template:
+    metadata:
+      labels:
+        app2: carts-agent
+    spec:
+      containers:
+      - name: carts-agent
+        image: "{{ .Values.image }}"
+`
+
+	tests := []struct {
+		name     string
+		workload string
+		want     bool
+	}{
+		{name: "statefulset", workload: statefulSet, want: true},
+		{name: "daemonset without app label", workload: daemonSet, want: false},
+		{name: "deployment and statefulset", workload: defaultDeployment + statefulSet, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := os.MkdirAll("carts/templates", 0777)
+			check(err, t)
+			err = ioutil.WriteFile("carts/Chart.yaml", []byte(defaultChart), 0644)
+			check(err, t)
+			err = ioutil.WriteFile("carts/values.yaml", []byte(values), 0644)
+			check(err, t)
+			err = ioutil.WriteFile("carts/templates/workload.yaml", []byte(tt.workload), 0644)
+			check(err, t)
+			err = ioutil.WriteFile("carts/templates/service.yaml", []byte(defaultService), 0644)
+			check(err, t)
+
+			ch, err := keptnutils.LoadChartFromPath("carts")
+			check(err, t)
+
+			res, err := ValidateHelmChart(ch)
+			check(err, t)
+			assert.Equal(t, tt.want, res)
+			os.RemoveAll("carts")
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// getRenderedStatefulSetsAndDaemonSets returns all statefulsets and daemonsets contained in the provided chart
+func getRenderedStatefulSetsAndDaemonSets(ch *chart.Chart) ([]*appsv1.StatefulSet, []*appsv1.DaemonSet, error) {
+
+	renderedTemplates, err := renderTemplatesWithKeptnValues(ch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statefulSets := []*appsv1.StatefulSet{}
+	daemonSets := []*appsv1.DaemonSet{}
+	for _, v := range renderedTemplates {
+		dec := kyaml.NewYAMLToJSONDecoder(strings.NewReader(v))
+		for {
+			var doc json.RawMessage
+			err := dec.Decode(&doc)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				continue
+			}
+
+			var typeMeta metav1.TypeMeta
+			if err := json.Unmarshal(doc, &typeMeta); err != nil {
+				continue
+			}
+			switch strings.ToLower(typeMeta.Kind) {
+			case "statefulset":
+				var sts appsv1.StatefulSet
+				if err := json.Unmarshal(doc, &sts); err == nil {
+					statefulSets = append(statefulSets, &sts)
+				}
+			case "daemonset":
+				var ds appsv1.DaemonSet
+				if err := json.Unmarshal(doc, &ds); err == nil {
+					daemonSets = append(daemonSets, &ds)
+				}
+			}
+		}
+	}
+	return statefulSets, daemonSets, nil
+}
+
+// renderTemplatesWithKeptnValues renders the templates of the chart with the values set by Keptn
+func renderTemplatesWithKeptnValues(ch *chart.Chart) (map[string]string, error) {
+	keptnValues := map[string]interface{}{
+		"keptn": map[string]interface{}{
+			"project":    "prj",
+			"stage":      "stage",
+			"service":    "svc",
+			"deployment": "dpl",
+		},
+	}
+
+	cvals, err := chartutil.CoalesceValues(ch, keptnValues)
+	if err != nil {
+		return nil, err
+	}
+	options := chartutil.ReleaseOptions{
+		Name: "testRelease",
+	}
+	valuesToRender, err := chartutil.ToRenderValues(ch, cvals, options, nil)
+	if err != nil {
+		return nil, err
+	}
+	return engine.Render(ch, valuesToRender)
+}
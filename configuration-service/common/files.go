@@ -2,68 +2,93 @@ package common
 
 import (
 	"encoding/base64"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 )
 
+// ChangeSet contains the files to be written and deleted within a single commit (see CommitChanges)
+type ChangeSet struct {
+	files     map[string][]byte
+	deletions []string
+}
+
+// NewChangeSet creates an empty change set
+func NewChangeSet() *ChangeSet {
+	return &ChangeSet{
+		files: map[string][]byte{},
+	}
+}
+
 // WriteBase64EncodedFile writes a file using the base64 encoded input
-func WriteBase64EncodedFile(path string, content string) error {
+func (c *ChangeSet) WriteBase64EncodedFile(path string, content string) error {
 	data, err := base64.StdEncoding.DecodeString(content)
 	if err != nil {
 		return err
 	}
-	return WriteFile(path, data)
+	c.WriteFile(path, data)
+	return nil
 }
 
-// WriteFile writes to a file in the filesystem if it exists, it is overwritten
-func WriteFile(path string, content []byte) error {
-	pathArr := strings.Split(path, "/")
-	directory := ""
-	for _, pathItem := range pathArr[0 : len(pathArr)-1] {
-		directory += pathItem + "/"
-	}
+// WriteFile writes a file; if it exists, it is overwritten
+func (c *ChangeSet) WriteFile(path string, content []byte) {
+	c.files[cleanPath(path)] = content
+}
 
-	err := os.MkdirAll(directory, os.ModePerm)
-	if err != nil {
-		return err
-	}
-	// detect if file exists
-	_, err = os.Stat(path)
+// WriteDirectory writes all files of a directory in the filesystem to the given path
+func (c *ChangeSet) WriteDirectory(path string, dir string) error {
+	return filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		c.WriteFile(path+"/"+filepath.ToSlash(relativePath), content)
+		return nil
+	})
+}
 
-	// delete the file and re-create it, if it existed previously
-	if !os.IsNotExist(err) {
-		DeleteFile(path)
-	}
-	file, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+// Delete deletes a file or a directory including all of its files. Deletions are applied before any files are written.
+func (c *ChangeSet) Delete(path string) {
+	c.deletions = append(c.deletions, cleanPath(path))
+}
 
-	file, err = os.OpenFile(path, os.O_RDWR, 0644)
-	if err != nil {
-		return err
+// apply applies the changes to the files of a tree, storing the content of the written files
+func (c *ChangeSet) apply(s storer.EncodedObjectStorer, files map[string]treeFile) error {
+	for _, deletion := range c.deletions {
+		deleteTreeFiles(files, deletion)
 	}
-	defer file.Close()
-
-	// write some text line-by-line to file
-	_, err = file.WriteString(string(content))
-	if err != nil {
-		return err
+	for path, content := range c.files {
+		hash, err := writeBlob(s, content)
+		if err != nil {
+			return err
+		}
+		// a file replaces a directory with the same path, as well as files in the place of its parent directories
+		deleteTreeFiles(files, path)
+		for dir := filepath.Dir(path); dir != "."; dir = filepath.Dir(dir) {
+			delete(files, dir)
+		}
+		files[path] = treeFile{hash: hash, mode: filemode.Regular}
 	}
-
-	// save changes
-	err = file.Sync()
 	return nil
 }
 
-// DeleteFile deletes a file
-func DeleteFile(path string) error {
-	var err = os.Remove(path)
-	if err != nil {
-		return err
+func deleteTreeFiles(files map[string]treeFile, path string) {
+	for file := range files {
+		if file == path || strings.HasPrefix(file, path+"/") {
+			delete(files, file)
+		}
 	}
-	return nil
 }
 
 // FileExists checks wether a file is available or not
@@ -4,17 +4,46 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/url"
+	"io"
 	"os"
+	"path"
+	"regexp"
+	"sort"
 	"strings"
-
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
 	"github.com/keptn/keptn/configuration-service/config"
+	"github.com/keptn/keptn/configuration-service/models"
 	utils "github.com/keptn/kubernetes-utils/pkg"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
+// ErrInvalidVersion indicates that a version is not a valid commit ID
+var ErrInvalidVersion = errors.New("invalid version")
+
+// ErrVersionNotFound indicates that a version or the resource in this version does not exist
+var ErrVersionNotFound = errors.New("version not found")
+
+// ErrFileNotFound indicates that a file does not exist in the latest version of a branch
+var ErrFileNotFound = errors.New("file not found")
+
+var commitIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+
+// commitTimeFormat is the strict ISO 8601 format used by git (e.g. for %aI)
+const commitTimeFormat = "2006-01-02T15:04:05-07:00"
+
+const remoteName = "origin"
+
 // GitCredentials contains git ccredentials info
 type GitCredentials struct {
 	User      string `json:"user,omitempty"`
@@ -22,66 +51,293 @@ type GitCredentials struct {
 	RemoteURI string `json:"remoteURI,omitempty"`
 }
 
-// CloneRepo clones an upstream repository into a local folder "project" and returns
-// whether the Git repo is already initialized.
-func CloneRepo(project string, user string, token string, uri string) (bool, error) {
-	uri = getRepoURI(uri, user, token)
+func openRepository(project string) (*git.Repository, error) {
+	return git.PlainOpen(config.ConfigDir + "/" + project)
+}
 
-	msg, err := utils.ExecuteCommandInDirectory("git", []string{"clone", uri, project}, config.ConfigDir)
-	const emptyRepoWarning = "warning: You appear to have cloned an empty repository."
-	if strings.Contains(msg, emptyRepoWarning) {
-		return false, err
+func branchRef(branch string) plumbing.ReferenceName {
+	return plumbing.NewBranchReferenceName(branch)
+}
+
+func upstreamBranchRef(branch string) plumbing.ReferenceName {
+	return plumbing.NewRemoteReferenceName(remoteName, branch)
+}
+
+// fetchHeadRef is the reference the upstream HEAD is fetched into when syncing a branch. Like the FETCH_HEAD of
+// git pull, but there is one per branch, so that branches can be synced concurrently.
+func fetchHeadRef(branch string) plumbing.ReferenceName {
+	return plumbing.ReferenceName("refs/fetch-head/" + branch)
+}
+
+func branchCommit(repo *git.Repository, branch string) (*object.Commit, error) {
+	ref, err := repo.Reference(branchRef(branch), true)
+	if err != nil {
+		return nil, err
 	}
-	return true, err
+	return repo.CommitObject(ref.Hash())
 }
 
-func getRepoURI(uri string, user string, token string) string {
-	if strings.Contains(user, "@") {
-		// username contains an @, probably an e-mail; need to encode it
-		// see https://stackoverflow.com/a/29356143
-		user = url.QueryEscape(user)
+func branchTree(repo *git.Repository, branch string) (*object.Tree, error) {
+	commit, err := branchCommit(repo, branch)
+	if err != nil {
+		return nil, err
 	}
+	return commit.Tree()
+}
 
-	if strings.Contains(uri, user+"@") {
-		uri = strings.Replace(uri, "https://"+user+"@", "https://"+user+":"+token+"@", 1)
+func getBranchTree(project string, branch string) (*object.Tree, error) {
+	repo, err := openRepository(project)
+	if err != nil {
+		return nil, err
+	}
+	return branchTree(repo, branch)
+}
+
+func getAuth(credentials *GitCredentials) transport.AuthMethod {
+	return &githttp.BasicAuth{
+		Username: credentials.User,
+		Password: credentials.Token,
 	}
+}
+
+func newUpstream(repo *git.Repository, credentials *GitCredentials) *git.Remote {
+	return git.NewRemote(repo.Storer, &gitconfig.RemoteConfig{
+		Name: remoteName,
+		URLs: []string{credentials.RemoteURI},
+	})
+}
 
-	if !strings.Contains(uri, user+":"+token+"@") {
-		uri = strings.Replace(uri, "https://", "https://"+user+":"+token+"@", 1)
+// fetchUpstream fetches the HEAD of the upstream repository (like git pull <url>) in order to merge it into
+// the given branch
+func fetchUpstream(repo *git.Repository, credentials *GitCredentials, branch string) error {
+	refSpec := gitconfig.RefSpec("+" + plumbing.HEAD + ":" + fetchHeadRef(branch))
+	err := newUpstream(repo, credentials).Fetch(&git.FetchOptions{
+		RefSpecs: []gitconfig.RefSpec{refSpec},
+		Auth:     getAuth(credentials),
+		Tags:     git.NoTags,
+		Force:    true,
+	})
+	if err == git.NoErrAlreadyUpToDate || err == transport.ErrEmptyRemoteRepository {
+		return nil
+	}
+	var noMatchingRefSpec git.NoMatchingRefSpecError
+	if errors.As(err, &noMatchingRefSpec) {
+		// the upstream repository does not have a HEAD
+		return nil
 	}
+	if err != nil {
+		return obfuscateErrorMessage(err, credentials)
+	}
+	return nil
+}
+
+// pushUpstream pushes the given refspecs to the upstream repository
+func pushUpstream(repo *git.Repository, credentials *GitCredentials, refSpecs ...gitconfig.RefSpec) error {
+	err := newUpstream(repo, credentials).Push(&git.PushOptions{
+		RefSpecs: refSpecs,
+		Auth:     getAuth(credentials),
+	})
+	if err == git.NoErrAlreadyUpToDate {
+		return nil
+	}
+	if err != nil {
+		return obfuscateErrorMessage(err, credentials)
+	}
+	return nil
+}
 
-	return uri
+func pushBranchRefSpec(branch string) gitconfig.RefSpec {
+	return gitconfig.RefSpec(branchRef(branch) + ":" + branchRef(branch))
 }
 
-// CheckoutBranch checks out the given branch
-func CheckoutBranch(project string, branch string, disableUpstreamSync bool) error {
+func deleteBranchRefSpec(branch string) gitconfig.RefSpec {
+	return gitconfig.RefSpec(":" + branchRef(branch))
+}
+
+// CloneRepo clones an upstream repository into a local folder "project" and returns
+// whether the Git repo is already initialized.
+func CloneRepo(project string, user string, token string, uri string) (bool, error) {
 	projectConfigPath := config.ConfigDir + "/" + project
-	_, err := utils.ExecuteCommandInDirectory("git", []string{"checkout", branch}, projectConfigPath)
+	credentials := &GitCredentials{User: user, Token: token, RemoteURI: uri}
+
+	_, err := git.PlainClone(projectConfigPath, true, &git.CloneOptions{
+		URL:  uri,
+		Auth: getAuth(credentials),
+		Tags: git.NoTags,
+	})
+	if err == transport.ErrEmptyRemoteRepository {
+		// the branches of an empty repository are created upstream with the first push
+		repo, err := git.PlainInit(projectConfigPath, true)
+		if err != nil {
+			return false, err
+		}
+		_, err = repo.CreateRemote(&gitconfig.RemoteConfig{Name: remoteName, URLs: []string{uri}})
+		return false, err
+	}
+	if err != nil {
+		return false, obfuscateErrorMessage(err, credentials)
+	}
+	return true, nil
+}
+
+// InitRepo creates a new, empty repository for the project
+func InitRepo(project string) error {
+	_, err := git.PlainInit(config.ConfigDir+"/"+project, true)
+	return err
+}
+
+// SyncBranch makes sure that the given branch exists locally and, unless disabled, merges the HEAD
+// of the upstream repository into it (like git checkout <branch> && git pull <url>)
+func SyncBranch(project string, branch string, disableUpstreamSync bool) error {
+	repo, err := openRepository(project)
 	if err != nil {
 		return err
 	}
+
+	_, err = repo.Reference(branchRef(branch), true)
+	if err == plumbing.ErrReferenceNotFound {
+		// like git checkout, create the local branch if the branch exists in the upstream repository
+		upstream, err := repo.Reference(upstreamBranchRef(branch), true)
+		if err != nil {
+			return fmt.Errorf("branch %s does not exist", branch)
+		}
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(branchRef(branch), upstream.Hash())); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
 	if disableUpstreamSync {
 		return nil
 	}
 	credentials, err := GetCredentials(project)
 	if err == nil && credentials != nil {
-		repoURI := getRepoURI(credentials.RemoteURI, credentials.User, credentials.Token)
-		_, err = utils.ExecuteCommandInDirectory("git", []string{"pull", "-s", "recursive", "-X", "theirs", repoURI}, projectConfigPath)
-		if err != nil {
+		if err := fetchUpstream(repo, credentials, branch); err != nil {
+			return err
+		}
+		if err := mergeUpstreamBranch(repo, branch, credentials.RemoteURI); err != nil {
 			return obfuscateErrorMessage(err, credentials)
 		}
 	}
 	return nil
 }
 
+// mergeUpstreamBranch merges the fetched upstream HEAD into the local branch. If both have diverged,
+// the changes of the upstream win in case of conflicts (like git pull -s recursive -X theirs).
+func mergeUpstreamBranch(repo *git.Repository, branch string, remoteURI string) error {
+	local, err := repo.Reference(branchRef(branch), true)
+	if err == plumbing.ErrReferenceNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	upstream, err := repo.Reference(fetchHeadRef(branch), true)
+	if err == plumbing.ErrReferenceNotFound || (err == nil && upstream.Hash() == local.Hash()) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	localCommit, err := repo.CommitObject(local.Hash())
+	if err != nil {
+		return err
+	}
+	upstreamCommit, err := repo.CommitObject(upstream.Hash())
+	if err != nil {
+		return err
+	}
+
+	if isAncestor, err := upstreamCommit.IsAncestor(localCommit); err != nil || isAncestor {
+		return err
+	}
+
+	newHead := upstream.Hash()
+	if isAncestor, err := localCommit.IsAncestor(upstreamCommit); err != nil {
+		return err
+	} else if !isAncestor {
+		newHead, err = mergeCommits(repo, localCommit, upstreamCommit, "Merge "+remoteURI)
+		if err != nil {
+			return err
+		}
+	}
+	return repo.Storer.CheckAndSetReference(plumbing.NewHashReference(branchRef(branch), newHead), local)
+}
+
+// mergeCommits creates a merge commit of two diverged commits and returns its hash
+func mergeCommits(repo *git.Repository, ours *object.Commit, theirs *object.Commit, message string) (plumbing.Hash, error) {
+	var baseTree *object.Tree
+	bases, err := ours.MergeBase(theirs)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if len(bases) > 0 {
+		if baseTree, err = bases[0].Tree(); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
+	baseFiles, err := readTreeFiles(baseTree)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	ourFiles, err := readCommitFiles(ours)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	theirFiles, err := readCommitFiles(theirs)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	treeHash, err := writeTree(repo.Storer, mergeTreeFiles(baseFiles, ourFiles, theirFiles))
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return createCommit(repo, message, treeHash, ours.Hash, theirs.Hash)
+}
+
+// createCommit creates a commit of the given tree and returns its hash
+func createCommit(repo *git.Repository, message string, tree plumbing.Hash, parents ...plumbing.Hash) (plumbing.Hash, error) {
+	signature := object.Signature{
+		Name:  "keptn",
+		Email: "keptn@keptn.com",
+		When:  time.Now(),
+	}
+	commit := &object.Commit{
+		Author:       signature,
+		Committer:    signature,
+		Message:      message + "\n",
+		TreeHash:     tree,
+		ParentHashes: parents,
+	}
+	obj := repo.Storer.NewEncodedObject()
+	if err := commit.Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return repo.Storer.SetEncodedObject(obj)
+}
+
 // CreateBranch creates a new branch
 func CreateBranch(project string, branch string, sourceBranch string) error {
-	projectConfigPath := config.ConfigDir + "/" + project
-	err := CheckoutBranch(project, sourceBranch, false)
+	err := SyncBranch(project, sourceBranch, false)
+	if err != nil {
+		return err
+	}
+	repo, err := openRepository(project)
+	if err != nil {
+		return err
+	}
+	source, err := repo.Reference(branchRef(sourceBranch), true)
 	if err != nil {
 		return err
 	}
-	_, err = utils.ExecuteCommandInDirectory("git", []string{"checkout", "-b", branch}, projectConfigPath)
+	if _, err := repo.Reference(branchRef(branch), false); err == nil {
+		return fmt.Errorf("branch %s already exists", branch)
+	}
+	err = repo.Storer.SetReference(plumbing.NewHashReference(branchRef(branch), source.Hash()))
 	if err != nil {
 		return err
 	}
@@ -89,11 +345,77 @@ func CreateBranch(project string, branch string, sourceBranch string) error {
 	// if an upstream has been defined, push the new branch
 	credentials, err := GetCredentials(project)
 	if err == nil && credentials != nil {
-		repoURI := getRepoURI(credentials.RemoteURI, credentials.User, credentials.Token)
-		_, err = utils.ExecuteCommandInDirectory("git", []string{"push", "--set-upstream", repoURI, branch}, projectConfigPath)
-		if err != nil {
-			return obfuscateErrorMessage(err, credentials)
+		return pushUpstream(repo, credentials, pushBranchRefSpec(branch))
+	}
+
+	return nil
+}
+
+// removeBranch removes a local branch as well as its upstream tracking branch and fetched upstream HEAD
+func removeBranch(repo *git.Repository, branch string) error {
+	if err := repo.Storer.RemoveReference(branchRef(branch)); err != nil {
+		return err
+	}
+	if err := repo.Storer.RemoveReference(fetchHeadRef(branch)); err != nil {
+		return err
+	}
+	return repo.Storer.RemoveReference(upstreamBranchRef(branch))
+}
+
+// DeleteBranch deletes a branch locally and, if an upstream has been defined, in the upstream repository
+func DeleteBranch(project string, branch string) error {
+	repo, err := openRepository(project)
+	if err != nil {
+		return err
+	}
+	if _, err := repo.Reference(branchRef(branch), false); err != nil {
+		return err
+	}
+	if err := removeBranch(repo, branch); err != nil {
+		return err
+	}
+
+	// if an upstream has been defined, delete the branch there as well
+	credentials, err := GetCredentials(project)
+	if err == nil && credentials != nil {
+		return pushUpstream(repo, credentials, deleteBranchRefSpec(branch))
+	}
+
+	return nil
+}
+
+// RenameBranch renames a branch while keeping its history. If an upstream has been defined,
+// the renamed branch is pushed and the old branch is deleted in the upstream repository.
+func RenameBranch(project string, branch string, newBranch string) error {
+	err := SyncBranch(project, branch, false)
+	if err != nil {
+		return err
+	}
+	repo, err := openRepository(project)
+	if err != nil {
+		return err
+	}
+	ref, err := repo.Reference(branchRef(branch), true)
+	if err != nil {
+		return err
+	}
+	if _, err := repo.Reference(branchRef(newBranch), false); err == nil {
+		return fmt.Errorf("branch %s already exists", newBranch)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(branchRef(newBranch), ref.Hash())); err != nil {
+		return err
+	}
+	if err := removeBranch(repo, branch); err != nil {
+		return err
+	}
+
+	// if an upstream has been defined, push the renamed branch and delete the old one
+	credentials, err := GetCredentials(project)
+	if err == nil && credentials != nil {
+		if err := pushUpstream(repo, credentials, pushBranchRefSpec(newBranch)); err != nil {
+			return err
 		}
+		return pushUpstream(repo, credentials, deleteBranchRefSpec(branch))
 	}
 
 	return nil
@@ -101,77 +423,108 @@ func CreateBranch(project string, branch string, sourceBranch string) error {
 
 // AddOrigin adds a remote Git repository
 func AddOrigin(project string) error {
-	projectConfigPath := config.ConfigDir + "/" + project
-
 	// if an upstream has been defined, add the origin and push
 	credentials, err := GetCredentials(project)
 	if err == nil && credentials != nil {
-		repoURI := getRepoURI(credentials.RemoteURI, credentials.User, credentials.Token)
-		_, err = utils.ExecuteCommandInDirectory("git", []string{"remote", "add", "origin", repoURI}, projectConfigPath)
+		repo, err := openRepository(project)
+		if err != nil {
+			return err
+		}
+		_, err = repo.CreateRemote(&gitconfig.RemoteConfig{Name: remoteName, URLs: []string{credentials.RemoteURI}})
 		if err != nil {
-			removeRemoteOrigin(project)
 			return obfuscateErrorMessage(err, credentials)
 		}
 
-		if err := setUpstreamsAndPush(project, credentials, repoURI); err != nil {
-			removeRemoteOrigin(project)
+		if err := pushAllBranches(project, repo, credentials); err != nil {
+			repo.DeleteRemote(remoteName)
 			return fmt.Errorf("failed to set upstream: %v\nKeptn requires an uninitialized repo", err)
 		}
 	}
 	return err
 }
 
-func removeRemoteOrigin(project string) error {
-	projectConfigPath := config.ConfigDir + "/" + project
-	_, err := utils.ExecuteCommandInDirectory("git", []string{"remote", "remove", "origin"}, projectConfigPath)
-	return err
-}
-
-func setUpstreamsAndPush(project string, credentials *GitCredentials, repoURI string) error {
-	projectConfigPath := config.ConfigDir + "/" + project
+func pushAllBranches(project string, repo *git.Repository, credentials *GitCredentials) error {
 	branches, err := GetBranches(project)
 	if err != nil {
 		return obfuscateErrorMessage(err, credentials)
 	}
 
+	refSpecs := []gitconfig.RefSpec{}
 	for _, branch := range branches {
-		err := CheckoutBranch(project, branch, true)
+		refSpecs = append(refSpecs, pushBranchRefSpec(branch))
+	}
+	return pushUpstream(repo, credentials, refSpecs...)
+}
+
+// CommitChanges commits the change set to the given branch without touching a working directory. If an upstream
+// has been defined, the upstream changes are merged and the branch is pushed. It returns the new version (i.e.
+// commit hash) of the branch.
+func CommitChanges(project string, branch string, message string, changes *ChangeSet) (string, error) {
+	repo, err := openRepository(project)
+	if err != nil {
+		return "", err
+	}
+
+	head, err := repo.Reference(branchRef(branch), true)
+	var parents []plumbing.Hash
+	var tree *object.Tree
+	if err == plumbing.ErrReferenceNotFound {
+		// the first commit of a new repository
+		head = nil
+	} else if err != nil {
+		return "", err
+	} else {
+		commit, err := repo.CommitObject(head.Hash())
 		if err != nil {
-			return obfuscateErrorMessage(err, credentials)
+			return "", err
 		}
-		_, err = utils.ExecuteCommandInDirectory("git", []string{"push", "--set-upstream", repoURI, branch}, projectConfigPath)
-		if err != nil {
-			return obfuscateErrorMessage(err, credentials)
+		if tree, err = commit.Tree(); err != nil {
+			return "", err
 		}
+		parents = append(parents, head.Hash())
 	}
-	return nil
-}
 
-// StageAndCommitAll stages all current changes and commits them to the current branch
-func StageAndCommitAll(project string, message string, withPull bool) error {
-	projectConfigPath := config.ConfigDir + "/" + project
-	_, err := utils.ExecuteCommandInDirectory("git", []string{"add", "."}, projectConfigPath)
+	files, err := readTreeFiles(tree)
 	if err != nil {
-		return err
+		return "", err
+	}
+	if err := changes.apply(repo.Storer, files); err != nil {
+		return "", err
+	}
+	treeHash, err := writeTree(repo.Storer, files)
+	if err != nil {
+		return "", err
+	}
+
+	// like git commit, do not create a commit if there is no delta
+	if tree == nil || tree.Hash != treeHash {
+		commitHash, err := createCommit(repo, message, treeHash, parents...)
+		if err != nil {
+			return "", err
+		}
+		if err := repo.Storer.CheckAndSetReference(plumbing.NewHashReference(branchRef(branch), commitHash), head); err != nil {
+			return "", err
+		}
 	}
 
-	_, err = utils.ExecuteCommandInDirectory("git", []string{"commit", "-m", message}, projectConfigPath)
-	// in this case, ignore errors since the only instance when this can occur at this stage is when there is nothin to commit (no delta)
 	credentials, err := GetCredentials(project)
 	if err == nil && credentials != nil {
-		repoURI := getRepoURI(credentials.RemoteURI, credentials.User, credentials.Token)
-		if withPull {
-			_, err = utils.ExecuteCommandInDirectory("git", []string{"pull", "-s", "recursive", "-X", "theirs", repoURI}, projectConfigPath)
-			if err != nil {
-				return obfuscateErrorMessage(err, credentials)
-			}
+		if err := fetchUpstream(repo, credentials, branch); err != nil {
+			return "", err
 		}
-		_, err = utils.ExecuteCommandInDirectory("git", []string{"push", repoURI}, projectConfigPath)
-		if err != nil {
-			return obfuscateErrorMessage(err, credentials)
+		if err := mergeUpstreamBranch(repo, branch, credentials.RemoteURI); err != nil {
+			return "", obfuscateErrorMessage(err, credentials)
+		}
+		if err := pushUpstream(repo, credentials, pushBranchRefSpec(branch)); err != nil {
+			return "", err
 		}
 	}
-	return nil
+
+	ref, err := repo.Reference(branchRef(branch), true)
+	if err != nil {
+		return "", err
+	}
+	return ref.Hash().String(), nil
 }
 
 func obfuscateErrorMessage(err error, credentials *GitCredentials) error {
@@ -182,16 +535,6 @@ func obfuscateErrorMessage(err error, credentials *GitCredentials) error {
 	return err
 }
 
-// GetCurrentVersion gets the latest version (i.e. commit hash) of the currently checked out branch
-func GetCurrentVersion(project string) (string, error) {
-	projectConfigPath := config.ConfigDir + "/" + project
-	out, err := utils.ExecuteCommandInDirectory("git", []string{"rev-parse", "HEAD"}, projectConfigPath)
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSuffix(out, "\n"), nil
-}
-
 // ProjectExists checks if a project exists
 func ProjectExists(project string) bool {
 	projectConfigPath := config.ConfigDir + "/" + project
@@ -209,8 +552,8 @@ func StageExists(project string, stage string, disableUpstreamSync bool) bool {
 	if !ProjectExists(project) {
 		return false
 	}
-	// try to checkout the branch containing the stage config
-	err := CheckoutBranch(project, stage, disableUpstreamSync)
+	// make sure the branch containing the stage config is available and up to date
+	err := SyncBranch(project, stage, disableUpstreamSync)
 	if err != nil {
 		return false
 	}
@@ -219,21 +562,10 @@ func StageExists(project string, stage string, disableUpstreamSync bool) bool {
 
 // ServiceExists checks if a service exists in a given stage of a project
 func ServiceExists(project string, stage string, service string, disableUpstreamSync bool) bool {
-	if !ProjectExists(project) {
-		return false
-	}
-	// try to checkout the branch containing the stage config
-	err := CheckoutBranch(project, stage, disableUpstreamSync)
-	if err != nil {
+	if !StageExists(project, stage, disableUpstreamSync) {
 		return false
 	}
-	serviceConfigPath := config.ConfigDir + "/" + project + "/" + service
-	_, err = os.Stat(serviceConfigPath)
-	// create file if not exists
-	if os.IsNotExist(err) {
-		return false
-	}
-	return true
+	return PathExists(project, stage, service)
 }
 
 // StoreGitCredentials stores the specified git credentials as a secret in the cluster
@@ -329,12 +661,310 @@ func DeleteCredentials(project string) error {
 
 // GetBranches returns a list of branches within the project
 func GetBranches(project string) ([]string, error) {
-	projectConfigPath := config.ConfigDir + "/" + project
-	out, err := utils.ExecuteCommandInDirectory("git", []string{"for-each-ref", `--format=%(refname:short)`, "refs/heads/*"}, projectConfigPath)
+	repo, err := openRepository(project)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := repo.Branches()
+	if err != nil {
+		return nil, err
+	}
+	branches := []string{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		branches = append(branches, ref.Name().Short())
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	branches := strings.Split(strings.TrimSpace(out), "\n")
+	sort.Strings(branches)
 
 	return branches, nil
 }
+
+// cleanPath turns a resource path into a path relative to the root of the repository
+func cleanPath(file string) string {
+	return strings.TrimPrefix(path.Clean("/"+file), "/")
+}
+
+// GetFile returns the content of a file in the latest version of a branch.
+// The path of the file is relative to the root of the project repository.
+func GetFile(project string, branch string, file string) ([]byte, error) {
+	tree, err := getBranchTree(project, branch)
+	if err != nil {
+		return nil, err
+	}
+	return readFile(tree, cleanPath(file))
+}
+
+func readFile(tree *object.Tree, file string) ([]byte, error) {
+	entry, err := tree.FindEntry(file)
+	if err != nil || !entry.Mode.IsFile() {
+		return nil, ErrFileNotFound
+	}
+	f, err := tree.TreeEntryFile(entry)
+	if err != nil {
+		return nil, err
+	}
+	content, err := f.Contents()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
+
+// PathExists checks whether a file or directory exists in the latest version of a branch.
+// The path is relative to the root of the project repository.
+func PathExists(project string, branch string, file string) bool {
+	tree, err := getBranchTree(project, branch)
+	if err != nil {
+		return false
+	}
+	if file = cleanPath(file); file == "" {
+		return true
+	}
+	_, err = tree.FindEntry(file)
+	return err == nil
+}
+
+// ListFiles returns the paths of all files within a directory in the latest version of a branch, in the order
+// of a walk through the directory. The paths are relative to the directory, which in turn is relative to the
+// root of the project repository.
+func ListFiles(project string, branch string, dir string) ([]string, error) {
+	tree, err := getBranchTree(project, branch)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	if dir = cleanPath(dir); dir != "" {
+		if tree, err = tree.Tree(dir); err != nil {
+			return files, nil
+		}
+	}
+
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !entry.Mode.IsFile() || strings.Contains(path.Join(dir, name), ".git") {
+			continue
+		}
+		files = append(files, name)
+	}
+
+	// directories are walked in lexical order, i.e. the path separator is ordered before any other character
+	sort.Slice(files, func(i, j int) bool {
+		return strings.ReplaceAll(files[i], "/", "\x00") < strings.ReplaceAll(files[j], "/", "\x00")
+	})
+	return files, nil
+}
+
+// ExportDirectory writes the content of a directory in the latest version of a branch to the target directory.
+// The path of the directory is relative to the root of the project repository and is kept within the target directory.
+func ExportDirectory(project string, branch string, dir string, targetDir string) error {
+	tree, err := getBranchTree(project, branch)
+	if err != nil {
+		return err
+	}
+	if err := exportTree(tree, cleanPath(dir), targetDir); err == object.ErrDirectoryNotFound {
+		return ErrFileNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// resolveVersion returns the commit of a version (i.e. a possibly abbreviated commit ID) contained in the history of the branch
+func resolveVersion(repo *git.Repository, branch string, version string) (*object.Commit, error) {
+	if !commitIDRegex.MatchString(version) {
+		return nil, ErrInvalidVersion
+	}
+	head, err := branchCommit(repo, branch)
+	if err != nil {
+		return nil, ErrVersionNotFound
+	}
+
+	version = strings.ToLower(version)
+	var commit *object.Commit
+	err = object.NewCommitPreorderIter(head, nil, nil).ForEach(func(c *object.Commit) error {
+		if strings.HasPrefix(c.Hash.String(), version) {
+			commit = c
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if commit == nil {
+		return nil, ErrVersionNotFound
+	}
+	return commit, nil
+}
+
+// GetFileRevision returns the content of a file as of the given version (i.e. commit ID) of a branch.
+// The path of the file is relative to the root of the project repository.
+func GetFileRevision(project string, branch string, version string, file string) ([]byte, error) {
+	repo, err := openRepository(project)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := resolveVersion(repo, branch, version)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	content, err := readFile(tree, cleanPath(file))
+	if err == ErrFileNotFound {
+		return nil, ErrVersionNotFound
+	}
+	return content, err
+}
+
+// ExportDirectoryRevision writes the content of a directory as of the given version (i.e. commit ID) of a branch
+// to the target directory. The path of the directory is relative to the root of the project repository and
+// is kept within the target directory.
+func ExportDirectoryRevision(project string, branch string, version string, dir string, targetDir string) error {
+	repo, err := openRepository(project)
+	if err != nil {
+		return err
+	}
+	commit, err := resolveVersion(repo, branch, version)
+	if err != nil {
+		return err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	if err := exportTree(tree, cleanPath(dir), targetDir); err == object.ErrDirectoryNotFound {
+		return ErrVersionNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetFileHistory returns the versions of a branch which changed the given file or directory, starting with the latest version.
+// The path is relative to the root of the project repository.
+func GetFileHistory(project string, branch string, file string) ([]*models.ResourceVersion, error) {
+	return getFileHistory(project, branch, file, 0)
+}
+
+// GetLatestFileVersion returns the latest version of a branch which changed the given file or directory.
+// If the file has never been committed, nil is returned.
+func GetLatestFileVersion(project string, branch string, file string) (*models.ResourceVersion, error) {
+	versions, err := getFileHistory(project, branch, file, 1)
+	if err != nil || len(versions) == 0 {
+		return nil, err
+	}
+	return versions[0], nil
+}
+
+func getFileHistory(project string, branch string, file string, maxCount int) ([]*models.ResourceVersion, error) {
+	repo, err := openRepository(project)
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.Reference(branchRef(branch), true)
+	if err != nil {
+		return nil, err
+	}
+
+	file = cleanPath(file)
+	commits, err := repo.Log(&git.LogOptions{
+		From: head.Hash(),
+		PathFilter: func(changedFile string) bool {
+			return changedFile == file || strings.HasPrefix(changedFile, file+"/")
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []*models.ResourceVersion{}
+	err = commits.ForEach(func(commit *object.Commit) error {
+		versions = append(versions, &models.ResourceVersion{
+			Version: commit.Hash.String(),
+			Author:  commit.Author.Name,
+			Time:    commit.Author.When.Format(commitTimeFormat),
+			Message: commitSubject(commit.Message),
+		})
+		if maxCount > 0 && len(versions) >= maxCount {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// commitSubject returns the first paragraph of a commit message as a single line (like %s of git log)
+func commitSubject(message string) string {
+	subject := strings.SplitN(strings.TrimSpace(message), "\n\n", 2)[0]
+	return strings.Join(strings.Fields(strings.ReplaceAll(subject, "\n", " ")), " ")
+}
+
+// GetBranchDiff returns the files that differ between two branches, together with their unified diffs.
+// If a path is given, only files within this path (relative to the root of the project repository) are compared.
+func GetBranchDiff(project string, fromBranch string, toBranch string, path string) ([]*models.FileDiff, error) {
+	repo, err := openRepository(project)
+	if err != nil {
+		return nil, err
+	}
+	fromTree, err := branchTree(repo, fromBranch)
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := branchTree(repo, toBranch)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return nil, err
+	}
+
+	path = cleanPath(path)
+	diffs := []*models.FileDiff{}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		file := change.To.Name
+		status := "changed"
+		switch action {
+		case merkletrie.Insert:
+			status = "added"
+		case merkletrie.Delete:
+			file = change.From.Name
+			status = "removed"
+		}
+		if path != "" && file != path && !strings.HasPrefix(file, path+"/") {
+			continue
+		}
+
+		patch, err := change.Patch()
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, &models.FileDiff{
+			Path:   file,
+			Status: status,
+			Diff:   patch.String(),
+		})
+	}
+	return diffs, nil
+}
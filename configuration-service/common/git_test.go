@@ -2,7 +2,15 @@ package common
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/keptn/keptn/configuration-service/config"
 )
 
 func Test_obfuscateErrorMessage(t *testing.T) {
@@ -55,3 +63,517 @@ func Test_obfuscateErrorMessage(t *testing.T) {
 		})
 	}
 }
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=keptn", "GIT_AUTHOR_EMAIL=keptn@keptn.sh",
+		"GIT_COMMITTER_NAME=keptn", "GIT_COMMITTER_EMAIL=keptn@keptn.sh")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %s", args, string(out))
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func setupVersionedProject(t *testing.T) (string, []string) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.ConfigDir = dir
+	projectDir := filepath.Join(dir, "sockshop")
+	if err := os.MkdirAll(filepath.Join(projectDir, "carts", "helm", "carts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) string {
+		return runGit(t, projectDir, args...)
+	}
+
+	git("init", "-q")
+	git("checkout", "-q", "-b", "master")
+	commits := []string{}
+	for _, content := range []string{"v1", "v2"} {
+		if err := ioutil.WriteFile(filepath.Join(projectDir, "carts", "helm", "carts", "values.yaml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "-A")
+		git("commit", "-q", "-m", "set "+content)
+		commits = append(commits, git("rev-parse", "HEAD"))
+	}
+	return dir, commits
+}
+
+func TestGetFileRevision(t *testing.T) {
+	dir, commits := setupVersionedProject(t)
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		version string
+		want    string
+		wantErr error
+	}{
+		{name: "first version", version: commits[0], want: "v1"},
+		{name: "latest version", version: commits[1], want: "v2"},
+		{name: "abbreviated commit ID", version: commits[0][:7], want: "v1"},
+		{name: "invalid version", version: "master~1", wantErr: ErrInvalidVersion},
+		{name: "unknown version", version: "deadbeef", wantErr: ErrVersionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetFileRevision("sockshop", "master", tt.version, "carts/helm/carts/values.yaml")
+			if err != tt.wantErr {
+				t.Errorf("GetFileRevision() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("GetFileRevision() = %s, want %s", string(got), tt.want)
+			}
+		})
+	}
+
+	if _, err := GetFileRevision("sockshop", "master", commits[0], "carts/unknown.yaml"); err != ErrVersionNotFound {
+		t.Errorf("GetFileRevision() error = %v, wantErr %v", err, ErrVersionNotFound)
+	}
+}
+
+func TestExportDirectoryRevision(t *testing.T) {
+	dir, commits := setupVersionedProject(t)
+	defer os.RemoveAll(dir)
+
+	targetDir, err := ioutil.TempDir("", "revision")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(targetDir)
+
+	if err := ExportDirectoryRevision("sockshop", "master", commits[0], "carts/helm/carts", targetDir); err != nil {
+		t.Fatalf("ExportDirectoryRevision() error = %v", err)
+	}
+	got, err := ioutil.ReadFile(filepath.Join(targetDir, "carts", "helm", "carts", "values.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "v1" {
+		t.Errorf("ExportDirectoryRevision() exported %s, want v1", string(got))
+	}
+}
+
+func TestGetFileHistory(t *testing.T) {
+	dir, commits := setupVersionedProject(t)
+	defer os.RemoveAll(dir)
+
+	versions, err := GetFileHistory("sockshop", "master", "carts/helm/carts")
+	if err != nil {
+		t.Fatalf("GetFileHistory() error = %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("GetFileHistory() returned %d versions, want 2", len(versions))
+	}
+	if versions[0].Version != commits[1] || versions[1].Version != commits[0] {
+		t.Errorf("GetFileHistory() returned versions in wrong order")
+	}
+	if versions[0].Author != "keptn" || versions[0].Message != "set v2" {
+		t.Errorf("GetFileHistory() = %+v, want author keptn and message 'set v2'", versions[0])
+	}
+
+	versions, err = GetFileHistory("sockshop", "master", "carts/unknown.yaml")
+	if err != nil {
+		t.Fatalf("GetFileHistory() error = %v", err)
+	}
+	if len(versions) != 0 {
+		t.Errorf("GetFileHistory() returned %d versions for unknown file, want 0", len(versions))
+	}
+}
+
+func TestGetBranchDiff(t *testing.T) {
+	dir, _ := setupVersionedProject(t)
+	defer os.RemoveAll(dir)
+
+	projectDir := filepath.Join(dir, "sockshop")
+	runGit(t, projectDir, "checkout", "-q", "-b", "dev")
+	runGit(t, projectDir, "checkout", "-q", "-b", "production")
+	if err := ioutil.WriteFile(filepath.Join(projectDir, "carts", "helm", "carts", "values.yaml"), []byte("v3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(projectDir, "carts", "slo.yaml"), []byte("objectives: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(projectDir, "shipyard.yaml"), []byte("stages: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, projectDir, "add", "-A")
+	runGit(t, projectDir, "commit", "-q", "-m", "update production")
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus map[string]string
+	}{
+		{
+			name: "whole project",
+			path: "",
+			wantStatus: map[string]string{
+				"carts/helm/carts/values.yaml": "changed",
+				"carts/slo.yaml":               "added",
+				"shipyard.yaml":                "added",
+			},
+		},
+		{
+			name: "single service",
+			path: "carts",
+			wantStatus: map[string]string{
+				"carts/helm/carts/values.yaml": "changed",
+				"carts/slo.yaml":               "added",
+			},
+		},
+		{
+			name: "single resource",
+			path: "carts/slo.yaml",
+			wantStatus: map[string]string{
+				"carts/slo.yaml": "added",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs, err := GetBranchDiff("sockshop", "dev", "production", tt.path)
+			if err != nil {
+				t.Fatalf("GetBranchDiff() error = %v", err)
+			}
+			if len(diffs) != len(tt.wantStatus) {
+				t.Fatalf("GetBranchDiff() returned %d files, want %d", len(diffs), len(tt.wantStatus))
+			}
+			for _, diff := range diffs {
+				if diff.Status != tt.wantStatus[diff.Path] {
+					t.Errorf("GetBranchDiff() status of %s = %s, want %s", diff.Path, diff.Status, tt.wantStatus[diff.Path])
+				}
+				if !strings.Contains(diff.Diff, "+++ b/"+diff.Path) {
+					t.Errorf("GetBranchDiff() diff of %s is not a unified diff: %s", diff.Path, diff.Diff)
+				}
+			}
+		})
+	}
+
+	diffs, err := GetBranchDiff("sockshop", "production", "dev", "carts/slo.yaml")
+	if err != nil {
+		t.Fatalf("GetBranchDiff() error = %v", err)
+	}
+	if len(diffs) != 1 || diffs[0].Status != "removed" {
+		t.Errorf("GetBranchDiff() = %+v, want carts/slo.yaml to be removed", diffs)
+	}
+}
+
+func TestGetLatestFileVersion(t *testing.T) {
+	dir, commits := setupVersionedProject(t)
+	defer os.RemoveAll(dir)
+
+	version, err := GetLatestFileVersion("sockshop", "master", "carts/helm/carts/values.yaml")
+	if err != nil {
+		t.Fatalf("GetLatestFileVersion() error = %v", err)
+	}
+	if version == nil || version.Version != commits[1] {
+		t.Errorf("GetLatestFileVersion() = %+v, want version %s", version, commits[1])
+	}
+
+	version, err = GetLatestFileVersion("sockshop", "master", "carts/unknown.yaml")
+	if err != nil {
+		t.Fatalf("GetLatestFileVersion() error = %v", err)
+	}
+	if version != nil {
+		t.Errorf("GetLatestFileVersion() = %+v, want nil for unknown file", version)
+	}
+}
+
+func TestCommitChanges(t *testing.T) {
+	dir, commits := setupVersionedProject(t)
+	defer os.RemoveAll(dir)
+
+	changes := NewChangeSet()
+	changes.WriteFile("carts/slo.yaml", []byte("objectives: []\n"))
+	changes.Delete("carts/helm")
+	version, err := CommitChanges("sockshop", "master", "Added resources", changes)
+	if err != nil {
+		t.Fatalf("CommitChanges() error = %v", err)
+	}
+
+	// the commit has to be readable by git itself
+	projectDir := filepath.Join(dir, "sockshop")
+	if head := runGit(t, projectDir, "rev-parse", "master"); version != head {
+		t.Errorf("CommitChanges() = %s, want head of master %s", version, head)
+	}
+	if parent := runGit(t, projectDir, "rev-parse", "master~1"); parent != commits[1] {
+		t.Errorf("CommitChanges() created commit with parent %s, want %s", parent, commits[1])
+	}
+	if got := runGit(t, projectDir, "show", "master:carts/slo.yaml"); got != "objectives: []" {
+		t.Errorf("CommitChanges() committed %s, want objectives: []", got)
+	}
+	if PathExists("sockshop", "master", "carts/helm/carts/values.yaml") {
+		t.Errorf("CommitChanges() did not delete carts/helm")
+	}
+
+	// committing the same changes again does not create a new version
+	changes = NewChangeSet()
+	changes.WriteFile("carts/slo.yaml", []byte("objectives: []\n"))
+	unchangedVersion, err := CommitChanges("sockshop", "master", "Added resources", changes)
+	if err != nil {
+		t.Fatalf("CommitChanges() error = %v", err)
+	}
+	if unchangedVersion != version {
+		t.Errorf("CommitChanges() = %s without any delta, want %s", unchangedVersion, version)
+	}
+}
+
+func TestCommitChangesToNewRepository(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	config.ConfigDir = dir
+
+	if err := InitRepo("sockshop"); err != nil {
+		t.Fatalf("InitRepo() error = %v", err)
+	}
+	changes := NewChangeSet()
+	changes.WriteFile("metadata.yaml", []byte("projectname: sockshop\n"))
+	if _, err := CommitChanges("sockshop", "master", "Added metadata.yaml", changes); err != nil {
+		t.Fatalf("CommitChanges() error = %v", err)
+	}
+
+	branches, err := GetBranches("sockshop")
+	if err != nil {
+		t.Fatalf("GetBranches() error = %v", err)
+	}
+	if len(branches) != 1 || branches[0] != "master" {
+		t.Errorf("GetBranches() = %v, want [master]", branches)
+	}
+	got, err := GetFile("sockshop", "master", "metadata.yaml")
+	if err != nil {
+		t.Fatalf("GetFile() error = %v", err)
+	}
+	if string(got) != "projectname: sockshop\n" {
+		t.Errorf("GetFile() = %s, want projectname: sockshop", string(got))
+	}
+}
+
+func TestGetFile(t *testing.T) {
+	dir, _ := setupVersionedProject(t)
+	defer os.RemoveAll(dir)
+
+	got, err := GetFile("sockshop", "master", "/carts/helm/carts/values.yaml")
+	if err != nil {
+		t.Fatalf("GetFile() error = %v", err)
+	}
+	if string(got) != "v2" {
+		t.Errorf("GetFile() = %s, want v2", string(got))
+	}
+
+	for _, file := range []string{"carts/unknown.yaml", "carts/helm"} {
+		if _, err := GetFile("sockshop", "master", file); err != ErrFileNotFound {
+			t.Errorf("GetFile(%s) error = %v, want %v", file, err, ErrFileNotFound)
+		}
+	}
+}
+
+func TestListFiles(t *testing.T) {
+	dir, _ := setupVersionedProject(t)
+	defer os.RemoveAll(dir)
+
+	changes := NewChangeSet()
+	changes.WriteFile("carts/helm/carts/templates/deployment.yaml", []byte("kind: Deployment\n"))
+	changes.WriteFile("carts/helm/carts-generated/values.yaml", []byte("v1"))
+	changes.WriteFile("shipyard.yaml", []byte("stages: []\n"))
+	if _, err := CommitChanges("sockshop", "master", "Added resources", changes); err != nil {
+		t.Fatalf("CommitChanges() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		dir  string
+		want []string
+	}{
+		{
+			name: "whole branch",
+			dir:  "",
+			want: []string{
+				"carts/helm/carts/templates/deployment.yaml",
+				"carts/helm/carts/values.yaml",
+				"carts/helm/carts-generated/values.yaml",
+				"shipyard.yaml",
+			},
+		},
+		{
+			name: "directory",
+			dir:  "carts/helm",
+			want: []string{"carts/templates/deployment.yaml", "carts/values.yaml", "carts-generated/values.yaml"},
+		},
+		{
+			name: "unknown directory",
+			dir:  "orders",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ListFiles("sockshop", "master", tt.dir)
+			if err != nil {
+				t.Fatalf("ListFiles() error = %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ListFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranchLifecycle(t *testing.T) {
+	dir, commits := setupVersionedProject(t)
+	defer os.RemoveAll(dir)
+
+	if err := CreateBranch("sockshop", "dev", "master"); err != nil {
+		t.Fatalf("CreateBranch() error = %v", err)
+	}
+	if err := CreateBranch("sockshop", "dev", "master"); err == nil {
+		t.Errorf("CreateBranch() of an existing branch did not return an error")
+	}
+	if err := RenameBranch("sockshop", "dev", "staging"); err != nil {
+		t.Fatalf("RenameBranch() error = %v", err)
+	}
+	if got := runGit(t, filepath.Join(dir, "sockshop"), "rev-parse", "staging"); got != commits[1] {
+		t.Errorf("RenameBranch() moved staging to %s, want %s", got, commits[1])
+	}
+
+	branches, err := GetBranches("sockshop")
+	if err != nil {
+		t.Fatalf("GetBranches() error = %v", err)
+	}
+	if strings.Join(branches, ",") != "master,staging" {
+		t.Errorf("GetBranches() = %v, want [master staging]", branches)
+	}
+
+	if err := DeleteBranch("sockshop", "staging"); err != nil {
+		t.Fatalf("DeleteBranch() error = %v", err)
+	}
+	if StageExists("sockshop", "staging", true) {
+		t.Errorf("DeleteBranch() did not delete staging")
+	}
+}
+
+func TestMergeUpstreamBranch(t *testing.T) {
+	// setup points the local and the upstream master branch to the versions at the given indices
+	setup := func(t *testing.T, local int, upstream int) (string, []string, *git.Repository) {
+		dir, commits := setupVersionedProject(t)
+		projectDir := filepath.Join(dir, "sockshop")
+		runGit(t, projectDir, "update-ref", "refs/heads/master", commits[local])
+		runGit(t, projectDir, "update-ref", "refs/fetch-head/master", commits[upstream])
+		repo, err := openRepository("sockshop")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dir, commits, repo
+	}
+
+	t.Run("fast-forward", func(t *testing.T) {
+		dir, _, repo := setup(t, 0, 1)
+		defer os.RemoveAll(dir)
+
+		if err := mergeUpstreamBranch(repo, "master", "https://example.com/sockshop.git"); err != nil {
+			t.Fatalf("mergeUpstreamBranch() error = %v", err)
+		}
+		if got, _ := GetFile("sockshop", "master", "carts/helm/carts/values.yaml"); string(got) != "v2" {
+			t.Errorf("mergeUpstreamBranch() did not fast-forward master")
+		}
+	})
+
+	t.Run("diverged", func(t *testing.T) {
+		dir, commits := setupVersionedProject(t)
+		defer os.RemoveAll(dir)
+
+		// the upstream changes values.yaml as well and adds a new file on top of the first version
+		projectDir := filepath.Join(dir, "sockshop")
+		runGit(t, projectDir, "checkout", "-q", "-b", "upstream", commits[0])
+		if err := ioutil.WriteFile(filepath.Join(projectDir, "carts", "helm", "carts", "values.yaml"), []byte("v3"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(projectDir, "carts", "slo.yaml"), []byte("objectives: []"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, projectDir, "add", "-A")
+		runGit(t, projectDir, "commit", "-q", "-m", "set v3")
+		runGit(t, projectDir, "update-ref", "refs/fetch-head/master", "upstream")
+
+		repo, err := openRepository("sockshop")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := mergeUpstreamBranch(repo, "master", "https://example.com/sockshop.git"); err != nil {
+			t.Fatalf("mergeUpstreamBranch() error = %v", err)
+		}
+		if got := runGit(t, projectDir, "rev-parse", "master^1"); got != commits[1] {
+			t.Errorf("mergeUpstreamBranch() created merge commit with first parent %s, want %s", got, commits[1])
+		}
+		if got := runGit(t, projectDir, "show", "master:carts/helm/carts/values.yaml"); got != "v3" {
+			t.Errorf("mergeUpstreamBranch() merged values.yaml = %s, want the upstream version v3", got)
+		}
+		if got := runGit(t, projectDir, "show", "master:carts/slo.yaml"); got != "objectives: []" {
+			t.Errorf("mergeUpstreamBranch() merged slo.yaml = %s, want objectives: []", got)
+		}
+	})
+
+	t.Run("ahead of upstream", func(t *testing.T) {
+		dir, commits, repo := setup(t, 1, 0)
+		defer os.RemoveAll(dir)
+
+		if err := mergeUpstreamBranch(repo, "master", "https://example.com/sockshop.git"); err != nil {
+			t.Fatalf("mergeUpstreamBranch() error = %v", err)
+		}
+		if got := runGit(t, filepath.Join(dir, "sockshop"), "rev-parse", "master"); got != commits[1] {
+			t.Errorf("mergeUpstreamBranch() moved master to %s, want %s", got, commits[1])
+		}
+	})
+}
+
+func TestFetchUpstream(t *testing.T) {
+	dir, commits := setupVersionedProject(t)
+	defer os.RemoveAll(dir)
+	projectDir := filepath.Join(dir, "sockshop")
+	runGit(t, projectDir, "branch", "dev", commits[0])
+
+	// the HEAD of the upstream repository is its master branch, which is ahead of the local master branch
+	upstreamDir := filepath.Join(dir, "upstream")
+	runGit(t, dir, "clone", "-q", projectDir, upstreamDir)
+	if err := ioutil.WriteFile(filepath.Join(upstreamDir, "carts", "slo.yaml"), []byte("objectives: []"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, upstreamDir, "add", "-A")
+	runGit(t, upstreamDir, "commit", "-q", "-m", "add slo.yaml")
+	upstreamHead := runGit(t, upstreamDir, "rev-parse", "HEAD")
+
+	repo, err := openRepository("sockshop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	credentials := &GitCredentials{RemoteURI: upstreamDir}
+	if err := fetchUpstream(repo, credentials, "dev"); err != nil {
+		t.Fatalf("fetchUpstream() error = %v", err)
+	}
+	// like git pull <url>, the upstream HEAD is merged into the branch, not the upstream branch of the same name
+	if err := mergeUpstreamBranch(repo, "dev", credentials.RemoteURI); err != nil {
+		t.Fatalf("mergeUpstreamBranch() error = %v", err)
+	}
+	if got := runGit(t, projectDir, "rev-parse", "dev"); got != upstreamHead {
+		t.Errorf("mergeUpstreamBranch() moved dev to %s, want the upstream HEAD %s", got, upstreamHead)
+	}
+	if got := runGit(t, projectDir, "rev-parse", "master"); got != commits[1] {
+		t.Errorf("fetchUpstream() moved master to %s, want %s", got, commits[1])
+	}
+
+	// an upstream repository without a HEAD is not an error
+	runGit(t, upstreamDir, "symbolic-ref", "HEAD", "refs/heads/unborn")
+	if err := fetchUpstream(repo, credentials, "dev"); err != nil {
+		t.Errorf("fetchUpstream() of an upstream without HEAD error = %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package common
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// treeFile is a file within a git tree
+type treeFile struct {
+	hash plumbing.Hash
+	mode filemode.FileMode
+}
+
+// readTreeFiles returns all files of a tree, including the files of its subtrees, by their path
+func readTreeFiles(tree *object.Tree) (map[string]treeFile, error) {
+	files := map[string]treeFile{}
+	if tree == nil {
+		return files, nil
+	}
+
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if entry.Mode != filemode.Dir {
+			files[name] = treeFile{hash: entry.Hash, mode: entry.Mode}
+		}
+	}
+}
+
+func readCommitFiles(commit *object.Commit) (map[string]treeFile, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	return readTreeFiles(tree)
+}
+
+// writeTree stores the tree containing the given files, including all of its subtrees, and returns its hash
+func writeTree(s storer.EncodedObjectStorer, files map[string]treeFile) (plumbing.Hash, error) {
+	entries := []object.TreeEntry{}
+	subtrees := map[string]map[string]treeFile{}
+	for path, file := range files {
+		if i := strings.Index(path, "/"); i >= 0 {
+			dir := path[:i]
+			if subtrees[dir] == nil {
+				subtrees[dir] = map[string]treeFile{}
+			}
+			subtrees[dir][path[i+1:]] = file
+			continue
+		}
+		entries = append(entries, object.TreeEntry{Name: path, Mode: file.mode, Hash: file.hash})
+	}
+	for dir, subtreeFiles := range subtrees {
+		hash, err := writeTree(s, subtreeFiles)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		entries = append(entries, object.TreeEntry{Name: dir, Mode: filemode.Dir, Hash: hash})
+	}
+
+	// git sorts the entries of a tree by name, comparing the names of subtrees as if they ended with a slash
+	sortKey := func(entry object.TreeEntry) string {
+		if entry.Mode == filemode.Dir {
+			return entry.Name + "/"
+		}
+		return entry.Name
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return sortKey(entries[i]) < sortKey(entries[j])
+	})
+
+	obj := s.NewEncodedObject()
+	if err := (&object.Tree{Entries: entries}).Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return s.SetEncodedObject(obj)
+}
+
+// writeBlob stores the content of a file and returns its hash
+func writeBlob(s storer.EncodedObjectStorer, content []byte) (plumbing.Hash, error) {
+	obj := s.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	obj.SetSize(int64(len(content)))
+	writer, err := obj.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, err := writer.Write(content); err != nil {
+		writer.Close()
+		return plumbing.ZeroHash, err
+	}
+	if err := writer.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return s.SetEncodedObject(obj)
+}
+
+// mergeTreeFiles merges the files of two trees with a common base file by file. If a file has been
+// changed in both trees, their version wins.
+func mergeTreeFiles(base map[string]treeFile, ours map[string]treeFile, theirs map[string]treeFile) map[string]treeFile {
+	merged := map[string]treeFile{}
+	paths := map[string]bool{}
+	for _, files := range []map[string]treeFile{base, ours, theirs} {
+		for path := range files {
+			paths[path] = true
+		}
+	}
+
+	for path := range paths {
+		baseFile, inBase := base[path]
+		ourFile, inOurs := ours[path]
+		theirFile, inTheirs := theirs[path]
+
+		file, keep := theirFile, inTheirs
+		if inTheirs == inBase && theirFile == baseFile {
+			// only we have changed the file
+			file, keep = ourFile, inOurs
+		}
+		if keep {
+			merged[path] = file
+		}
+	}
+	return merged
+}
+
+// exportTree writes the files of a directory within a tree to the target directory, keeping the path of the directory
+func exportTree(tree *object.Tree, dir string, targetDir string) error {
+	if dir != "" {
+		var err error
+		if tree, err = tree.Tree(dir); err != nil {
+			return object.ErrDirectoryNotFound
+		}
+	}
+
+	return tree.Files().ForEach(func(file *object.File) error {
+		targetPath := filepath.Join(targetDir, dir, file.Name)
+		if err := os.MkdirAll(filepath.Dir(targetPath), os.ModePerm); err != nil {
+			return err
+		}
+		mode, err := file.Mode.ToOSFileMode()
+		if err != nil {
+			return err
+		}
+		reader, err := file.Reader()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		target, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+		if err != nil {
+			return err
+		}
+		defer target.Close()
+		_, err = io.Copy(target, reader)
+		return err
+	})
+}
@@ -1,10 +1,17 @@
 package common
 
-import "sync"
+import (
+	"sort"
+	"sync"
+)
 
 var mutex = &sync.Mutex{}
 
-var projectLocks = map[string]*sync.Mutex{}
+var projectLocks = map[string]*sync.RWMutex{}
+
+var branchLocks = map[string]*sync.RWMutex{}
+
+var viewLocks = map[string]*sync.Mutex{}
 
 // Lock locks the mutex
 func Lock() {
@@ -16,21 +23,128 @@ func Unlock() {
 	mutex.Unlock()
 }
 
-// LockProject
-func LockProject(project string) {
+func getProjectLock(project string) *sync.RWMutex {
+	Lock()
+	defer Unlock()
 	if projectLocks[project] == nil {
-		Lock()
-		projectLocks[project] = &sync.Mutex{}
-		Unlock()
+		projectLocks[project] = &sync.RWMutex{}
+	}
+	return projectLocks[project]
+}
+
+func getBranchLock(project string, branch string) *sync.RWMutex {
+	Lock()
+	defer Unlock()
+	key := project + "/" + branch
+	if branchLocks[key] == nil {
+		branchLocks[key] = &sync.RWMutex{}
 	}
-	projectLocks[project].Lock()
+	return branchLocks[key]
 }
 
+func getViewLock(project string) *sync.Mutex {
+	Lock()
+	defer Unlock()
+	if viewLocks[project] == nil {
+		viewLocks[project] = &sync.Mutex{}
+	}
+	return viewLocks[project]
+}
+
+// LockProject locks all branches of a project, e.g., to create or delete a project
+func LockProject(project string) {
+	getProjectLock(project).Lock()
+}
+
+// UnlockProject unlocks all branches of a project
 func UnlockProject(project string) {
-	if projectLocks[project] == nil {
-		Lock()
-		projectLocks[project] = &sync.Mutex{}
-		Unlock()
+	getProjectLock(project).Unlock()
+}
+
+// LockBranch locks a branch of a project for writing. Writes to different branches do not block each other.
+func LockBranch(project string, branch string) {
+	getProjectLock(project).RLock()
+	getBranchLock(project, branch).Lock()
+}
+
+// UnlockBranch unlocks a branch of a project that has been locked for writing
+func UnlockBranch(project string, branch string) {
+	getBranchLock(project, branch).Unlock()
+	getProjectLock(project).RUnlock()
+}
+
+// LockBranches locks several branches of a project for writing. The branches are locked in a fixed order,
+// so that concurrent calls with the same branches cannot deadlock.
+func LockBranches(project string, branches ...string) {
+	// the project must be read-locked only once, as a waiting LockProject blocks further read locks
+	getProjectLock(project).RLock()
+	for _, branch := range sortedBranches(branches) {
+		getBranchLock(project, branch).Lock()
 	}
-	projectLocks[project].Unlock()
+}
+
+// UnlockBranches unlocks several branches of a project that have been locked by LockBranches
+func UnlockBranches(project string, branches ...string) {
+	for _, branch := range sortedBranches(branches) {
+		getBranchLock(project, branch).Unlock()
+	}
+	getProjectLock(project).RUnlock()
+}
+
+func sortedBranches(branches []string) []string {
+	sorted := []string{}
+	seen := map[string]bool{}
+	for _, branch := range branches {
+		if !seen[branch] {
+			seen[branch] = true
+			sorted = append(sorted, branch)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// RLockBranch syncs a branch of a project with the upstream repository, unless disabled, and locks it for reading,
+// so that it can be read concurrently with other reads. As syncing updates the branch, it runs under the write lock
+// of the branch, which is released before the branch is locked for reading. Hence, only reads without upstream sync
+// run concurrently with other reads of the same branch. If the branch cannot be synced, e.g., because it does not
+// exist, an error is returned and the branch is not locked.
+func RLockBranch(project string, branch string, disableUpstreamSync bool) error {
+	if disableUpstreamSync {
+		rLockBranch(project, branch)
+		if PathExists(project, branch, "") {
+			return nil
+		}
+		// the local branch might have to be created from its upstream tracking branch first
+		RUnlockBranch(project, branch)
+	}
+
+	LockBranch(project, branch)
+	err := SyncBranch(project, branch, disableUpstreamSync)
+	UnlockBranch(project, branch)
+	if err != nil {
+		return err
+	}
+	rLockBranch(project, branch)
+	return nil
+}
+
+func rLockBranch(project string, branch string) {
+	getProjectLock(project).RLock()
+	getBranchLock(project, branch).RLock()
+}
+
+// RUnlockBranch unlocks a branch of a project that has been locked for reading
+func RUnlockBranch(project string, branch string) {
+	getBranchLock(project, branch).RUnlock()
+	getProjectLock(project).RUnlock()
+}
+
+// lockProjectView locks the materialized view of a project, so that concurrent updates of it are not lost
+func lockProjectView(project string) {
+	getViewLock(project).Lock()
+}
+
+func unlockProjectView(project string) {
+	getViewLock(project).Unlock()
 }
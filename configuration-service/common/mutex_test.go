@@ -0,0 +1,207 @@
+package common
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/configuration-service/config"
+)
+
+// acquires returns whether lock returns within a short time
+func acquires(lock func()) bool {
+	acquired := make(chan bool)
+	go func() {
+		lock()
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		return true
+	case <-time.After(100 * time.Millisecond):
+		return false
+	}
+}
+
+func TestBranchLocks(t *testing.T) {
+	dir := setupStageProject(t, "lock-test")
+	defer os.RemoveAll(dir)
+
+	LockBranch("lock-test", "production")
+
+	rLockBranch := func(branch string, disableUpstreamSync bool) func() {
+		return func() {
+			if err := RLockBranch("lock-test", branch, disableUpstreamSync); err != nil {
+				t.Errorf("RLockBranch() of %s error = %v", branch, err)
+			}
+		}
+	}
+	if !acquires(rLockBranch("dev", false)) {
+		t.Errorf("RLockBranch() of dev is blocked by a write to production")
+	}
+	if !acquires(rLockBranch("dev", true)) {
+		t.Errorf("RLockBranch() of dev without upstream sync is blocked by another read of dev")
+	}
+	if acquires(rLockBranch("dev", false)) {
+		t.Errorf("RLockBranch() of dev with upstream sync is not blocked by another read of dev")
+	}
+	if !acquires(func() { LockBranch("lock-test", "staging") }) {
+		t.Errorf("LockBranch() of staging is blocked by a write to production")
+	}
+	if err := RLockBranch("lock-test", "qa", true); err == nil {
+		t.Errorf("RLockBranch() of a branch that does not exist did not return an error")
+	}
+
+	production := make(chan bool)
+	go func() {
+		production <- acquires(rLockBranch("production", true))
+	}()
+	if <-production {
+		t.Errorf("RLockBranch() of production is not blocked by a write to production")
+	}
+
+	project := make(chan bool)
+	go func() {
+		project <- acquires(func() { LockProject("lock-test") })
+	}()
+	if <-project {
+		t.Errorf("LockProject() is not blocked by locked branches")
+	}
+}
+
+func TestLockBranches(t *testing.T) {
+	// locking the same branches in a different order or twice must not deadlock
+	if !acquires(func() {
+		LockBranches("lock-order-test", "dev", "production")
+		UnlockBranches("lock-order-test", "dev", "production")
+		LockBranches("lock-order-test", "production", "dev")
+		UnlockBranches("lock-order-test", "production", "dev")
+		LockBranches("lock-order-test", "dev", "dev")
+		UnlockBranches("lock-order-test", "dev", "dev")
+	}) {
+		t.Errorf("LockBranches() did not return")
+	}
+}
+
+// setupStageProject creates a project with a service in the stages dev, staging, and production
+func setupStageProject(tb testing.TB, project string) string {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	config.ConfigDir = dir
+
+	if err := InitRepo(project); err != nil {
+		tb.Fatal(err)
+	}
+	changes := NewChangeSet()
+	changes.WriteFile("metadata.yaml", []byte("projectname: "+project+"\n"))
+	if _, err := CommitChanges(project, "master", "Added metadata.yaml", changes); err != nil {
+		tb.Fatal(err)
+	}
+	for _, stage := range []string{"dev", "staging", "production"} {
+		if err := CreateBranch(project, stage, "master"); err != nil {
+			tb.Fatal(err)
+		}
+		changes := NewChangeSet()
+		changes.WriteFile("carts/slo.yaml", []byte("objectives: []\n"))
+		changes.WriteFile("carts/helm/carts/values.yaml", []byte("replicas: 1\n"))
+		if _, err := CommitChanges(project, stage, "Added resources", changes); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return dir
+}
+
+type benchmarkLocks struct {
+	lockRead    func(stage string) error
+	unlockRead  func(stage string)
+	lockWrite   func(stage string)
+	unlockWrite func(stage string)
+}
+
+// BenchmarkStageReads measures the throughput of reading the resources of the dev and staging stage while the
+// Helm chart of the production stage is updated continuously. Reads sync the stage with the upstream repository
+// by default, which is what the project lock is compared with.
+func BenchmarkStageReads(b *testing.B) {
+	dir := setupStageProject(b, "sockshop")
+	defer os.RemoveAll(dir)
+
+	b.Run("project lock", func(b *testing.B) {
+		benchmarkStageReads(b, benchmarkLocks{
+			lockRead: func(stage string) error {
+				LockProject("sockshop")
+				if err := SyncBranch("sockshop", stage, false); err != nil {
+					UnlockProject("sockshop")
+					return err
+				}
+				return nil
+			},
+			unlockRead:  func(string) { UnlockProject("sockshop") },
+			lockWrite:   func(string) { LockProject("sockshop") },
+			unlockWrite: func(string) { UnlockProject("sockshop") },
+		})
+	})
+	for _, disableUpstreamSync := range []bool{false, true} {
+		name := "branch locks"
+		if disableUpstreamSync {
+			name += " without upstream sync"
+		}
+		disableUpstreamSync := disableUpstreamSync
+		b.Run(name, func(b *testing.B) {
+			benchmarkStageReads(b, benchmarkLocks{
+				lockRead:    func(stage string) error { return RLockBranch("sockshop", stage, disableUpstreamSync) },
+				unlockRead:  func(stage string) { RUnlockBranch("sockshop", stage) },
+				lockWrite:   func(stage string) { LockBranch("sockshop", stage) },
+				unlockWrite: func(stage string) { UnlockBranch("sockshop", stage) },
+			})
+		})
+	}
+}
+
+func benchmarkStageReads(b *testing.B, locks benchmarkLocks) {
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			changes := NewChangeSet()
+			changes.WriteFile("carts/helm/carts/values.yaml", []byte(fmt.Sprintf("replicas: %d\n", i)))
+			locks.lockWrite("production")
+			_, err := CommitChanges("sockshop", "production", "Updated resources", changes)
+			locks.unlockWrite("production")
+			if err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	}()
+
+	b.ResetTimer()
+	b.SetParallelism(4)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			stage := []string{"dev", "staging"}[i%2]
+			err := locks.lockRead(stage)
+			if err == nil {
+				_, err = GetFile("sockshop", stage, "carts/slo.yaml")
+				locks.unlockRead(stage)
+			}
+			if err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+	b.StopTimer()
+
+	close(stop)
+	<-stopped
+}
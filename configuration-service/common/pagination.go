@@ -2,7 +2,6 @@ package common
 
 import (
 	"math"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -51,7 +50,12 @@ func Paginate(totalCount int, pageSize *int64, nextPageKeyString *string) *Pagin
 }
 
 // GetPaginatedResources returns a paginates resources set
-func GetPaginatedResources(dir string, pageSize *int64, nextPageKey *string) *models.Resources {
+func GetPaginatedResources(files []string, pageSize *int64, nextPageKey *string) *models.Resources {
+	return GetFilteredPaginatedResources(files, "", pageSize, nextPageKey)
+}
+
+// GetFilteredPaginatedResources returns a paginated set of the resources matching the filter (see matchesResourceFilter)
+func GetFilteredPaginatedResources(allFiles []string, filter string, pageSize *int64, nextPageKey *string) *models.Resources {
 	var result = &models.Resources{
 		PageSize:    0,
 		NextPageKey: "0",
@@ -59,24 +63,10 @@ func GetPaginatedResources(dir string, pageSize *int64, nextPageKey *string) *mo
 		Resources:   []*models.Resource{},
 	}
 	var files = []string{}
-	err := filepath.Walk(dir,
-		func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-			if strings.Contains(path, ".git") {
-				return nil
-			}
-			// don't expose the internal directory structure of the container
-			cutPrefix := strings.TrimPrefix(strings.TrimPrefix(dir, "./"), "/")
-			path = strings.Replace(path, cutPrefix, "", 1)
-			if !info.IsDir() {
-				files = append(files, strings.TrimPrefix(path, "/"))
-			}
-			return nil
-		})
-	if err != nil {
-		return result
+	for _, file := range allFiles {
+		if matchesResourceFilter(file, filter) {
+			files = append(files, file)
+		}
 	}
 
 	paginationInfo := Paginate(len(files), pageSize, nextPageKey)
@@ -94,3 +84,40 @@ func GetPaginatedResources(dir string, pageSize *int64, nextPageKey *string) *mo
 	result.NextPageKey = paginationInfo.NewNextPageKey
 	return result
 }
+
+// matchesResourceFilter checks whether the resource URI starts with the filter or matches it as glob pattern.
+// A pattern without a '/' is also matched against the file name, e.g. *.jmx matches jmeter/load.jmx.
+func matchesResourceFilter(resourceURI string, filter string) bool {
+	if filter == "" || strings.HasPrefix(resourceURI, filter) {
+		return true
+	}
+	if !strings.ContainsAny(filter, "*?[") {
+		return false
+	}
+	if matched, _ := filepath.Match(filter, resourceURI); matched {
+		return true
+	}
+	if !strings.Contains(filter, "/") {
+		matched, _ := filepath.Match(filter, filepath.Base(resourceURI))
+		return matched
+	}
+	return false
+}
+
+// PaginateResourceVersions returns a page of the given resource versions
+func PaginateResourceVersions(versions []*models.ResourceVersion, pageSize *int64, nextPageKey *string) *models.ResourceVersions {
+	var result = &models.ResourceVersions{
+		PageSize:    0,
+		NextPageKey: "0",
+		TotalCount:  float64(len(versions)),
+		Versions:    []*models.ResourceVersion{},
+	}
+
+	paginationInfo := Paginate(len(versions), pageSize, nextPageKey)
+	if paginationInfo.NextPageKey < int64(len(versions)) {
+		result.Versions = versions[paginationInfo.NextPageKey:paginationInfo.EndIndex]
+	}
+	result.PageSize = float64(len(result.Versions))
+	result.NextPageKey = paginationInfo.NewNextPageKey
+	return result
+}
@@ -43,3 +43,24 @@ func TestPaginationThreePages(t *testing.T) {
 	assert.Equal(t, paginationInfo.EndIndex, int64(41), "Expect end index to be set to 41")
 	assert.Equal(t, paginationInfo.NewNextPageKey, "0", "Expect new next page key to be set to 0")
 }
+
+// TestMatchesResourceFilter checks whether resource URIs are matched by prefix and glob filters
+func TestMatchesResourceFilter(t *testing.T) {
+	tests := []struct {
+		resourceURI string
+		filter      string
+		want        bool
+	}{
+		{resourceURI: "slo.yaml", filter: "", want: true},
+		{resourceURI: "helm/carts/values.yaml", filter: "helm/", want: true},
+		{resourceURI: "jmeter/load.jmx", filter: "helm/", want: false},
+		{resourceURI: "jmeter/load.jmx", filter: "*.jmx", want: true},
+		{resourceURI: "jmeter/load.jmx", filter: "jmeter/*.jmx", want: true},
+		{resourceURI: "jmeter/load.jmx", filter: "helm/*.jmx", want: false},
+		{resourceURI: "slo.yaml", filter: "s?o.yaml", want: true},
+		{resourceURI: "slo.yaml", filter: "*.jmx", want: false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchesResourceFilter(tt.resourceURI, tt.filter), "Unexpected match of %s with filter %s", tt.resourceURI, tt.filter)
+	}
+}
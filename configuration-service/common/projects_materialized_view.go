@@ -45,6 +45,9 @@ func GetProjectsMaterializedView() *projectsMaterializedView {
 
 // CreateProject creates a project
 func (mv *projectsMaterializedView) CreateProject(prj *models.Project) error {
+	lockProjectView(prj.ProjectName)
+	defer unlockProjectView(prj.ProjectName)
+
 	existingProject, err := mv.GetProject(prj.ProjectName)
 	if existingProject != nil {
 		return nil
@@ -58,6 +61,9 @@ func (mv *projectsMaterializedView) CreateProject(prj *models.Project) error {
 
 // UpdatedShipyard updates the shipyard of a project
 func (mv *projectsMaterializedView) UpdateShipyard(projectName string, shipyardContent string) error {
+	lockProjectView(projectName)
+	defer unlockProjectView(projectName)
+
 	existingProject, err := mv.GetProject(projectName)
 	if err != nil {
 		return err
@@ -85,6 +91,9 @@ func (mv *projectsMaterializedView) DeleteProject(projectName string) error {
 
 // CreateStage creates a stage
 func (mv *projectsMaterializedView) CreateStage(project string, stage string) error {
+	lockProjectView(project)
+	defer unlockProjectView(project)
+
 	fmt.Println("Adding stage " + stage + " to project " + project)
 	prj, err := mv.GetProject(project)
 
@@ -144,11 +153,14 @@ func (mv *projectsMaterializedView) updateProject(prj *models.ExpandedProject) e
 
 // DeleteStage deletes a stage
 func (mv *projectsMaterializedView) DeleteStage(project string, stage string) error {
+	lockProjectView(project)
+	defer unlockProjectView(project)
+
 	mv.Logger.Info("Deleting stage " + stage + " from project " + project)
 	prj, err := mv.GetProject(project)
 
 	if err != nil {
-		fmt.Sprintf("Could not delete stage %s from project %s : %s\n", stage, project, err.Error())
+		mv.Logger.Error(fmt.Sprintf("Could not delete stage %s from project %s : %s\n", stage, project, err.Error()))
 		return err
 	}
 
@@ -169,11 +181,58 @@ func (mv *projectsMaterializedView) DeleteStage(project string, stage string) er
 	prj.Stages = prj.Stages[:len(prj.Stages)-1]
 
 	err = mv.updateProject(prj)
+	if err != nil {
+		return err
+	}
+
+	mv.Logger.Info("Deleted stage " + stage + " from project " + project)
+	return nil
+}
+
+// RenameStage renames a stage while keeping its services
+func (mv *projectsMaterializedView) RenameStage(project string, stage string, newStage string) error {
+	lockProjectView(project)
+	defer unlockProjectView(project)
+
+	mv.Logger.Info("Renaming stage " + stage + " of project " + project + " to " + newStage)
+	prj, err := mv.GetProject(project)
+
+	if err != nil {
+		mv.Logger.Error(fmt.Sprintf("Could not rename stage %s of project %s : %s\n", stage, project, err.Error()))
+		return err
+	}
+	if prj == nil {
+		return ErrProjectNotFound
+	}
+
+	var existingStage *models.ExpandedStage
+	for _, stg := range prj.Stages {
+		if stg.StageName == newStage {
+			return fmt.Errorf("stage %s already exists in project %s", newStage, project)
+		}
+		if stg.StageName == stage {
+			existingStage = stg
+		}
+	}
+	if existingStage == nil {
+		return ErrStageNotFound
+	}
+	existingStage.StageName = newStage
+
+	err = mv.updateProject(prj)
+	if err != nil {
+		return err
+	}
+
+	mv.Logger.Info("Renamed stage " + stage + " of project " + project + " to " + newStage)
 	return nil
 }
 
 // CreateService creates a service
 func (mv *projectsMaterializedView) CreateService(project string, stage string, service string) error {
+	lockProjectView(project)
+	defer unlockProjectView(project)
+
 	existingProject, err := mv.GetProject(project)
 	if err != nil {
 		mv.Logger.Error("Could not add service " + service + " to stage " + stage + " in project " + project + ". Could not load project: " + err.Error())
@@ -206,6 +265,9 @@ func (mv *projectsMaterializedView) CreateService(project string, stage string,
 
 // DeleteService deletes a service
 func (mv *projectsMaterializedView) DeleteService(project string, stage string, service string) error {
+	lockProjectView(project)
+	defer unlockProjectView(project)
+
 	existingProject, err := mv.GetProject(project)
 	if err != nil {
 		mv.Logger.Error("Could not delete service " + service + " from stage " + stage + " in project " + project + ". Could not load project: " + err.Error())
@@ -249,6 +311,9 @@ func (mv *projectsMaterializedView) UpdateEventOfService(event interface{}, even
 		return err
 	}
 
+	lockProjectView(keptnBase.Project)
+	defer unlockProjectView(keptnBase.Project)
+
 	existingProject, err := mv.GetProject(keptnBase.Project)
 	if err != nil {
 		mv.Logger.Error("Could not update service " + keptnBase.Service + " in stage " + keptnBase.Stage + " in project " + keptnBase.Project + ". Could not load project: " + err.Error())
@@ -287,6 +352,9 @@ func (mv *projectsMaterializedView) UpdateEventOfService(event interface{}, even
 
 // CreateOpenApproval creates an open approval
 func (mv *projectsMaterializedView) CreateOpenApproval(project, stage, service string, approval *models.Approval) error {
+	lockProjectView(project)
+	defer unlockProjectView(project)
+
 	existingProject, err := mv.GetProject(project)
 	if err != nil {
 		mv.Logger.Error("Could create approval for service " + service + " in stage " + stage + " in project " + project + ". Could not load project: " + err.Error())
@@ -304,6 +372,9 @@ func (mv *projectsMaterializedView) CreateOpenApproval(project, stage, service s
 
 // CloseOpenApproval closes an open approval
 func (mv *projectsMaterializedView) CloseOpenApproval(project, stage, service, approvalEventID string) error {
+	lockProjectView(project)
+	defer unlockProjectView(project)
+
 	existingProject, err := mv.GetProject(project)
 	if err != nil {
 		mv.Logger.Error("Could not close approval for service " + service + " in stage " + stage + " in project " + project + ". Could not load project: " + err.Error())
@@ -341,6 +412,9 @@ func (mv *projectsMaterializedView) CloseOpenApproval(project, stage, service, a
 
 // CreateRemediation creates a remediation action
 func (mv *projectsMaterializedView) CreateRemediation(project, stage, service string, remediation *models.Remediation) error {
+	lockProjectView(project)
+	defer unlockProjectView(project)
+
 	existingProject, err := mv.GetProject(project)
 	if err != nil {
 		mv.Logger.Error("Could not create remediation for service " + service + " in stage " + stage + " in project " + project + ". Could not load project: " + err.Error())
@@ -359,6 +433,9 @@ func (mv *projectsMaterializedView) CreateRemediation(project, stage, service st
 
 // CloseOpenRemediations closes a open remediation actions for a given keptnContext
 func (mv *projectsMaterializedView) CloseOpenRemediations(project, stage, service, keptnContext string) error {
+	lockProjectView(project)
+	defer unlockProjectView(project)
+
 	existingProject, err := mv.GetProject(project)
 	if err != nil {
 		mv.Logger.Error("Could not close remediation for service " + service + " in stage " + stage + " in project " + project + ". Could not load project: " + err.Error())
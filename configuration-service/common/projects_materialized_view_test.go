@@ -474,6 +474,112 @@ func Test_projectsMaterializedView_DeleteStage(t *testing.T) {
 	}
 }
 
+func Test_projectsMaterializedView_RenameStage(t *testing.T) {
+	getProject := func(projectName string) (project *models.ExpandedProject, err error) {
+		return &models.ExpandedProject{
+			ProjectName: "test-project",
+			Stages: []*models.ExpandedStage{
+				{
+					Services:  []*models.ExpandedService{{ServiceName: "carts"}},
+					StageName: "dev",
+				},
+				{
+					Services:  nil,
+					StageName: "production",
+				},
+			},
+		}, nil
+	}
+	type fields struct {
+		ProjectRepo ProjectRepo
+	}
+	type args struct {
+		project  string
+		stage    string
+		newStage string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Rename stage and keep its services",
+			fields: fields{
+				ProjectRepo: &mockProjectRepo{
+					GetProjectMock: getProject,
+					UpdateProjectMock: func(project *models.ExpandedProject) error {
+						if len(project.Stages) != 2 {
+							return errors.New("unexpected length of stages array")
+						}
+						if project.Stages[0].StageName != "staging" {
+							return errors.New("stage was not renamed")
+						}
+						if len(project.Stages[0].Services) != 1 || project.Stages[0].Services[0].ServiceName != "carts" {
+							return errors.New("services of stage were not kept")
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				project:  "test-project",
+				stage:    "dev",
+				newStage: "staging",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Rename stage to existing stage",
+			fields: fields{
+				ProjectRepo: &mockProjectRepo{
+					GetProjectMock: getProject,
+					UpdateProjectMock: func(project *models.ExpandedProject) error {
+						// should not be called in this case
+						return errors.New("update func should not be called in this case")
+					},
+				},
+			},
+			args: args{
+				project:  "test-project",
+				stage:    "dev",
+				newStage: "production",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Rename stage that did not exist before",
+			fields: fields{
+				ProjectRepo: &mockProjectRepo{
+					GetProjectMock: getProject,
+					UpdateProjectMock: func(project *models.ExpandedProject) error {
+						// should not be called in this case
+						return errors.New("update func should not be called in this case")
+					},
+				},
+			},
+			args: args{
+				project:  "test-project",
+				stage:    "hardening",
+				newStage: "staging",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mv := &projectsMaterializedView{
+				ProjectRepo: tt.fields.ProjectRepo,
+				Logger:      keptn.NewLogger("", "", "configuration-service"),
+			}
+			if err := mv.RenameStage(tt.args.project, tt.args.stage, tt.args.newStage); (err != nil) != tt.wantErr {
+				t.Errorf("RenameStage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_projectsMaterializedView_CreateService(t *testing.T) {
 	type fields struct {
 		ProjectRepo ProjectRepo
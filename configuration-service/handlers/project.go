@@ -6,7 +6,6 @@ import (
 	"time"
 
 	keptn "github.com/keptn/go-utils/pkg/lib"
-	k8sutils "github.com/keptn/kubernetes-utils/pkg"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
@@ -70,11 +69,9 @@ func PostProjectHandlerFunc(params project.PostProjectParams) middleware.Respond
 	////////////////////////////////////////////////////
 	// clone existing repo
 	////////////////////////////////////////////////////
-	var initializedGit bool
 	if params.Project.GitUser != "" && params.Project.GitToken != "" && params.Project.GitRemoteURI != "" {
 		// try to clone the repo
-		var err error
-		initializedGit, err = common.CloneRepo(params.Project.ProjectName, params.Project.GitUser, params.Project.GitToken, params.Project.GitRemoteURI)
+		_, err := common.CloneRepo(params.Project.ProjectName, params.Project.GitUser, params.Project.GitToken, params.Project.GitRemoteURI)
 		if err != nil {
 			logger.Error(fmt.Sprintf("Could not clone git repository during creating project %s", params.Project.ProjectName))
 			logger.Error(err.Error())
@@ -99,7 +96,7 @@ func PostProjectHandlerFunc(params project.PostProjectParams) middleware.Respond
 			return project.NewPostProjectBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not create project")})
 		}
 
-		_, err = k8sutils.ExecuteCommandInDirectory("git", []string{"init"}, projectConfigPath)
+		err = common.InitRepo(params.Project.ProjectName)
 		if err != nil {
 			logger.Error(fmt.Sprintf("Could not initialize git repository during creating project %s", params.Project.ProjectName))
 			logger.Error(err.Error())
@@ -114,23 +111,10 @@ func PostProjectHandlerFunc(params project.PostProjectParams) middleware.Respond
 
 	metadataString, err := yaml.Marshal(newProjectMetadata)
 
-	err = common.WriteFile(projectConfigPath+"/metadata.yaml", metadataString)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could not write metadata.yaml during creating project %s", params.Project.ProjectName))
-		logger.Error(err.Error())
-		// Cleanup credentials before we exit
-		if credentialsCreated {
-			err = common.DeleteCredentials(params.Project.ProjectName)
-			if err != nil {
-				logger.Error(fmt.Sprintf("Could not delete credentials during creating project %s", params.Project.ProjectName))
-				logger.Error(err.Error())
-			}
-		}
-
-		return project.NewPostProjectBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not store project metadata")})
-	}
+	changes := common.NewChangeSet()
+	changes.WriteFile("metadata.yaml", metadataString)
 
-	err = common.StageAndCommitAll(params.Project.ProjectName, "Added metadata.yaml", initializedGit)
+	_, err = common.CommitChanges(params.Project.ProjectName, "master", "Added metadata.yaml", changes)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Could not commit metadata.yaml during creating project %s", params.Project.ProjectName))
 		logger.Error(err.Error())
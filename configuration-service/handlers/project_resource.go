@@ -3,14 +3,12 @@ package handlers
 import (
 	"encoding/base64"
 	"fmt"
-	"io/ioutil"
 	"strings"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
 	utils "github.com/keptn/go-utils/pkg/lib"
 	"github.com/keptn/keptn/configuration-service/common"
-	"github.com/keptn/keptn/configuration-service/config"
 	"github.com/keptn/keptn/configuration-service/models"
 	"github.com/keptn/keptn/configuration-service/restapi/operations/project_resource"
 )
@@ -22,18 +20,19 @@ func GetProjectProjectNameResourceHandlerFunc(params project_resource.GetProject
 		return project_resource.NewGetProjectProjectNameResourceNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project does not exist")})
 	}
 
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
-
-	err := common.CheckoutBranch(params.ProjectName, "master", *params.DisableUpstreamSync)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out master branch of project %s", params.ProjectName))
+	if err := common.RLockBranch(params.ProjectName, "master", *params.DisableUpstreamSync); err != nil {
+		logger.Error(fmt.Sprintf("Could not update master branch of project %s", params.ProjectName))
 		logger.Error(err.Error())
 		return project_resource.NewGetProjectProjectNameResourceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
 	}
+	defer common.RUnlockBranch(params.ProjectName, "master")
 
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	result := common.GetPaginatedResources(projectConfigPath, params.PageSize, params.NextPageKey)
+	files, err := common.ListFiles(params.ProjectName, "master", "")
+	if err != nil {
+		logger.Error(err.Error())
+		return project_resource.NewGetProjectProjectNameResourceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve project resources")})
+	}
+	result := common.GetPaginatedResources(files, params.PageSize, params.NextPageKey)
 
 	return project_resource.NewGetProjectProjectNameResourceOK().WithPayload(result)
 }
@@ -45,24 +44,23 @@ func PutProjectProjectNameResourceHandlerFunc(params project_resource.PutProject
 		return project_resource.NewPostProjectProjectNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Project does not exist")})
 	}
 
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
 
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	logger.Debug("Updating resource(s) in: " + projectConfigPath)
-	logger.Debug("Checking out master branch")
+	logger.Debug("Updating resource(s) in project " + params.ProjectName)
+	logger.Debug("Updating master branch")
 
-	err := common.CheckoutBranch(params.ProjectName, "master", false)
+	err := common.SyncBranch(params.ProjectName, "master", false)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out master branch of project %s", params.ProjectName))
+		logger.Error(fmt.Sprintf("Could not update master branch of project %s", params.ProjectName))
 		logger.Error(err.Error())
 		return project_resource.NewPutProjectProjectNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
 	}
 
+	changes := common.NewChangeSet()
 	for _, res := range params.Resources.Resources {
-		filePath := projectConfigPath + "/" + *res.ResourceURI
-		logger.Debug("Updating resource: " + filePath)
-		common.WriteBase64EncodedFile(projectConfigPath+"/"+*res.ResourceURI, res.ResourceContent)
+		logger.Debug("Updating resource: " + *res.ResourceURI)
+		changes.WriteBase64EncodedFile(*res.ResourceURI, res.ResourceContent)
 		if strings.ToLower(*res.ResourceURI) == "shipyard.yaml" {
 			mv := common.GetProjectsMaterializedView()
 			logger.Debug("updating shipyard.yaml content for project " + params.ProjectName + " in mongoDB table")
@@ -74,8 +72,8 @@ func PutProjectProjectNameResourceHandlerFunc(params project_resource.PutProject
 		}
 	}
 
-	logger.Debug("Staging Changes")
-	err = common.StageAndCommitAll(params.ProjectName, "Updated resources", true)
+	logger.Debug("Committing changes")
+	newVersion, err := common.CommitChanges(params.ProjectName, "master", "Updated resources", changes)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Could not commit to master branch of project %s", params.ProjectName))
 		logger.Error(err.Error())
@@ -83,12 +81,6 @@ func PutProjectProjectNameResourceHandlerFunc(params project_resource.PutProject
 	}
 	logger.Debug("Successfully updated resources")
 
-	newVersion, err := common.GetCurrentVersion(params.ProjectName)
-	if err != nil {
-		logger.Error(err.Error())
-		return project_resource.NewPutProjectProjectNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Coult not retrieve latest version")})
-	}
-
 	return project_resource.NewPutProjectProjectNameResourceCreated().WithPayload(&models.Version{
 		Version: newVersion,
 	})
@@ -101,28 +93,27 @@ func PostProjectProjectNameResourceHandlerFunc(params project_resource.PostProje
 		return project_resource.NewPostProjectProjectNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Project does not exist")})
 	}
 
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
 
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	logger.Debug("Creating new resource(s) in: " + projectConfigPath)
-	logger.Debug("Checking out master branch")
+	logger.Debug("Creating new resource(s) in project " + params.ProjectName)
+	logger.Debug("Updating master branch")
 
-	err := common.CheckoutBranch(params.ProjectName, "master", false)
+	err := common.SyncBranch(params.ProjectName, "master", false)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out master branch of project %s", params.ProjectName))
+		logger.Error(fmt.Sprintf("Could not update master branch of project %s", params.ProjectName))
 		logger.Error(err.Error())
 		return project_resource.NewPostProjectProjectNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
 	}
 
+	changes := common.NewChangeSet()
 	for _, res := range params.Resources.Resources {
-		filePath := projectConfigPath + "/" + *res.ResourceURI
-		logger.Debug("Adding resource: " + filePath)
-		common.WriteBase64EncodedFile(projectConfigPath+"/"+*res.ResourceURI, res.ResourceContent)
+		logger.Debug("Adding resource: " + *res.ResourceURI)
+		changes.WriteBase64EncodedFile(*res.ResourceURI, res.ResourceContent)
 	}
 
-	logger.Debug("Staging Changes")
-	err = common.StageAndCommitAll(params.ProjectName, "Added resources", true)
+	logger.Debug("Committing changes")
+	newVersion, err := common.CommitChanges(params.ProjectName, "master", "Added resources", changes)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Could not commit to master branch of project %s", params.ProjectName))
 		logger.Error(err.Error())
@@ -131,12 +122,6 @@ func PostProjectProjectNameResourceHandlerFunc(params project_resource.PostProje
 	}
 	logger.Debug("Successfully added resources")
 
-	newVersion, err := common.GetCurrentVersion(params.ProjectName)
-	if err != nil {
-		logger.Error(err.Error())
-		return project_resource.NewPostProjectProjectNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not retrieve latest version")})
-	}
-
 	return project_resource.NewPostProjectProjectNameResourceCreated().WithPayload(&models.Version{
 		Version: newVersion,
 	})
@@ -149,25 +134,36 @@ func GetProjectProjectNameResourceResourceURIHandlerFunc(params project_resource
 		return project_resource.NewGetProjectProjectNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
 	}
 
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
-
-	logger.Debug("Checking out master branch")
-	err := common.CheckoutBranch(params.ProjectName, "master", *params.DisableUpstreamSync)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could check out master branch of project %s", params.ProjectName))
+	logger.Debug("Updating master branch")
+	if err := common.RLockBranch(params.ProjectName, "master", *params.DisableUpstreamSync); err != nil {
+		logger.Error(fmt.Sprintf("Could not update master branch of project %s", params.ProjectName))
 		logger.Error(err.Error())
 		return project_resource.NewGetProjectProjectNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
 	}
-
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	resourcePath := projectConfigPath + "/" + params.ResourceURI
-	if !common.FileExists(resourcePath) {
-		return project_resource.NewGetProjectProjectNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project resource not found")})
+	defer common.RUnlockBranch(params.ProjectName, "master")
+
+	if params.GitCommitID != nil && *params.GitCommitID != "" {
+		logger.Debug("Reading " + params.ResourceURI + " at version " + *params.GitCommitID)
+		dat, err := common.GetFileRevision(params.ProjectName, "master", *params.GitCommitID, params.ResourceURI)
+		if err == common.ErrInvalidVersion {
+			return project_resource.NewGetProjectProjectNameResourceResourceURIDefault(400).WithPayload(&models.Error{Code: 400, Message: swag.String("Invalid version")})
+		} else if err == common.ErrVersionNotFound {
+			return project_resource.NewGetProjectProjectNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project resource not found in this version")})
+		} else if err != nil {
+			logger.Error(err.Error())
+			return project_resource.NewGetProjectProjectNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not read file")})
+		}
+		return project_resource.NewGetProjectProjectNameResourceResourceURIOK().WithPayload(
+			&models.Resource{
+				ResourceURI:     &params.ResourceURI,
+				ResourceContent: base64.StdEncoding.EncodeToString(dat),
+			})
 	}
 
-	dat, err := ioutil.ReadFile(resourcePath)
-	if err != nil {
+	dat, err := common.GetFile(params.ProjectName, "master", params.ResourceURI)
+	if err == common.ErrFileNotFound {
+		return project_resource.NewGetProjectProjectNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project resource not found")})
+	} else if err != nil {
 		logger.Error(err.Error())
 		return project_resource.NewGetProjectProjectNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not read file")})
 	}
@@ -181,6 +177,33 @@ func GetProjectProjectNameResourceResourceURIHandlerFunc(params project_resource
 		})
 }
 
+// GetProjectProjectNameResourceResourceURIVersionsHandlerFunc gets the versions of the specified resource
+func GetProjectProjectNameResourceResourceURIVersionsHandlerFunc(params project_resource.GetProjectProjectNameResourceResourceURIVersionsParams) middleware.Responder {
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ProjectExists(params.ProjectName) {
+		return project_resource.NewGetProjectProjectNameResourceResourceURIVersionsNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
+	}
+
+	logger.Debug("Updating master branch")
+	if err := common.RLockBranch(params.ProjectName, "master", *params.DisableUpstreamSync); err != nil {
+		logger.Error(fmt.Sprintf("Could not update master branch of project %s", params.ProjectName))
+		logger.Error(err.Error())
+		return project_resource.NewGetProjectProjectNameResourceResourceURIVersionsDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
+	}
+	defer common.RUnlockBranch(params.ProjectName, "master")
+
+	versions, err := common.GetFileHistory(params.ProjectName, "master", params.ResourceURI)
+	if err != nil {
+		logger.Error(err.Error())
+		return project_resource.NewGetProjectProjectNameResourceResourceURIVersionsDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve resource versions")})
+	}
+	if len(versions) == 0 {
+		return project_resource.NewGetProjectProjectNameResourceResourceURIVersionsNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project resource not found")})
+	}
+
+	return project_resource.NewGetProjectProjectNameResourceResourceURIVersionsOK().WithPayload(common.PaginateResourceVersions(versions, params.PageSize, params.NextPageKey))
+}
+
 // PutProjectProjectNameResourceResourceURIHandlerFunc updates a resource
 func PutProjectProjectNameResourceResourceURIHandlerFunc(params project_resource.PutProjectProjectNameResourceResourceURIParams) middleware.Responder {
 	logger := utils.NewLogger("", "", "configuration-service")
@@ -188,25 +211,24 @@ func PutProjectProjectNameResourceResourceURIHandlerFunc(params project_resource
 		return project_resource.NewPutProjectProjectNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Project does not exist")})
 	}
 
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
 
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	logger.Debug("Creating new resource(s) in: " + projectConfigPath)
-	logger.Debug("Checking out branch: master")
+	logger.Debug("Creating new resource(s) in project " + params.ProjectName)
+	logger.Debug("Updating branch: master")
 
-	err := common.CheckoutBranch(params.ProjectName, "master", false)
+	err := common.SyncBranch(params.ProjectName, "master", false)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out master branch of project %s", params.ProjectName))
+		logger.Error(fmt.Sprintf("Could not update master branch of project %s", params.ProjectName))
 		logger.Error(err.Error())
 		return project_resource.NewPutProjectProjectNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
 	}
 
-	filePath := projectConfigPath + "/" + params.ResourceURI
-	common.WriteBase64EncodedFile(filePath, params.Resource.ResourceContent)
+	changes := common.NewChangeSet()
+	changes.WriteBase64EncodedFile(params.ResourceURI, params.Resource.ResourceContent)
 
-	logger.Debug("Staging Changes")
-	err = common.StageAndCommitAll(params.ProjectName, "Updated resource: "+params.ResourceURI, true)
+	logger.Debug("Committing changes")
+	newVersion, err := common.CommitChanges(params.ProjectName, "master", "Updated resource: "+params.ResourceURI, changes)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Could not commit to master branch of project %s", params.ProjectName))
 		logger.Error(err.Error())
@@ -214,12 +236,6 @@ func PutProjectProjectNameResourceResourceURIHandlerFunc(params project_resource
 	}
 	logger.Debug("Successfully updated resource: " + params.ResourceURI)
 
-	newVersion, err := common.GetCurrentVersion(params.ProjectName)
-	if err != nil {
-		logger.Error(err.Error())
-		return project_resource.NewPutProjectProjectNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not retrieve latest version")})
-	}
-
 	return project_resource.NewPutProjectProjectNameResourceResourceURICreated().WithPayload(&models.Version{
 		Version: newVersion,
 	})
@@ -233,27 +249,26 @@ func DeleteProjectProjectNameResourceResourceURIHandlerFunc(params project_resou
 		return project_resource.NewDeleteProjectProjectNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Project does not exist")})
 	}
 
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
 
-	err := common.CheckoutBranch(params.ProjectName, "master", false)
+	err := common.SyncBranch(params.ProjectName, "master", false)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out master branch of project %s", params.ProjectName))
+		logger.Error(fmt.Sprintf("Could not update master branch of project %s", params.ProjectName))
 		logger.Error(err.Error())
 		return project_resource.NewDeleteProjectProjectNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
 	}
 
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	resourcePath := projectConfigPath + "/" + params.ResourceURI
-
-	err = common.DeleteFile(resourcePath)
-	if err != nil {
-		logger.Error(err.Error())
+	if !common.PathExists(params.ProjectName, "master", params.ResourceURI) {
+		logger.Error("Project resource " + params.ResourceURI + " does not exist")
 		return project_resource.NewDeleteProjectProjectNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not delete file")})
 	}
 
-	logger.Debug("Staging Changes")
-	err = common.StageAndCommitAll(params.ProjectName, "Deleted resources", true)
+	changes := common.NewChangeSet()
+	changes.Delete(params.ResourceURI)
+
+	logger.Debug("Committing changes")
+	_, err = common.CommitChanges(params.ProjectName, "master", "Deleted resources", changes)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Could not commit to master branch of project %s", params.ProjectName))
 		logger.Error(err.Error())
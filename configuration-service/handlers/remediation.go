@@ -11,8 +11,6 @@ import (
 
 // CreateRemediation creates a remediation
 func CreateRemediation(params remediation.CreateRemediationParams) middleware.Responder {
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
 	mv := common.GetProjectsMaterializedView()
 	err := mv.CreateRemediation(params.ProjectName, params.StageName, params.ServiceName, params.Remediation)
 
@@ -108,8 +106,6 @@ func GetRemediationsForContext(params remediation.GetRemediationsForContextParam
 
 // CloseRemediations closes all remediations with a given keptnContext of a service
 func CloseRemediations(params remediation.CloseRemediationsParams) middleware.Responder {
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
 	mv := common.GetProjectsMaterializedView()
 	err := mv.CloseOpenRemediations(params.ProjectName, params.StageName, params.ServiceName, params.KeptnContext)
 
@@ -4,14 +4,12 @@ import (
 	"fmt"
 	"github.com/keptn/keptn/configuration-service/restapi/operations/services"
 	"github.com/keptn/keptn/configuration-service/restapi/operations/stage"
-	"os"
 	"time"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
 	utils "github.com/keptn/go-utils/pkg/lib"
 	"github.com/keptn/keptn/configuration-service/common"
-	"github.com/keptn/keptn/configuration-service/config"
 	"github.com/keptn/keptn/configuration-service/models"
 	"github.com/keptn/keptn/configuration-service/restapi/operations/service"
 	"gopkg.in/yaml.v2"
@@ -88,11 +86,8 @@ func GetProjectProjectNameStageStageNameServiceServiceNameHandlerFunc(params ser
 func PostProjectProjectNameStageStageNameServiceHandlerFunc(params service.PostProjectProjectNameStageStageNameServiceParams) middleware.Responder {
 	logger := utils.NewLogger("", "", "configuration-service")
 
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
-
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	servicePath := projectConfigPath + "/" + params.Service.ServiceName
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 
 	if !common.StageExists(params.ProjectName, params.StageName, false) {
 		return service.NewPostProjectProjectNameStageStageNameServiceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Stage  " + params.StageName + " does not exist.")})
@@ -102,20 +97,7 @@ func PostProjectProjectNameStageStageNameServiceHandlerFunc(params service.PostP
 		return service.NewPostProjectProjectNameStageStageNameServiceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Service already exists")})
 	}
 
-	logger.Debug("Creating new resource(s) in: " + projectConfigPath + " in stage " + params.StageName)
-	logger.Debug("Checking out branch: " + params.StageName)
-	err := common.CheckoutBranch(params.ProjectName, params.StageName, false)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out %s branch of project %s", params.StageName, params.ProjectName))
-		logger.Error(err.Error())
-		return service.NewPostProjectProjectNameStageStageNameServiceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
-	}
-
-	err = os.MkdirAll(servicePath, os.ModePerm)
-	if err != nil {
-		logger.Error(err.Error())
-		return service.NewPostProjectProjectNameStageStageNameServiceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not create service directory")})
-	}
+	logger.Debug("Creating service " + params.Service.ServiceName + " in project " + params.ProjectName + " in stage " + params.StageName)
 
 	newServiceMetadata := &serviceMetadata{
 		ServiceName:       params.Service.ServiceName,
@@ -123,9 +105,12 @@ func PostProjectProjectNameStageStageNameServiceHandlerFunc(params service.PostP
 	}
 
 	metadataString, err := yaml.Marshal(newServiceMetadata)
-	err = common.WriteFile(servicePath+"/metadata.yaml", metadataString)
+	changes := common.NewChangeSet()
+	changes.WriteFile(params.Service.ServiceName+"/metadata.yaml", metadataString)
 
-	common.StageAndCommitAll(params.ProjectName, "Added service: "+params.Service.ServiceName, true)
+	if _, err := common.CommitChanges(params.ProjectName, params.StageName, "Added service: "+params.Service.ServiceName, changes); err != nil {
+		logger.Error(fmt.Sprintf("Could not commit service %s: %s", params.Service.ServiceName, err.Error()))
+	}
 
 	mv := common.GetProjectsMaterializedView()
 	err = mv.CreateService(params.ProjectName, params.StageName, params.Service.ServiceName)
@@ -143,7 +128,37 @@ func PutProjectProjectNameStageStageNameServiceServiceNameHandlerFunc(params ser
 
 // DeleteProjectProjectNameStageStageNameServiceServiceNameHandlerFunc deletes a service
 func DeleteProjectProjectNameStageStageNameServiceServiceNameHandlerFunc(params service.DeleteProjectProjectNameStageStageNameServiceServiceNameParams) middleware.Responder {
-	return middleware.NotImplemented("operation service.DeleteProjectProjectNameStageStageNameServiceServiceName has not yet been implemented")
+	logger := utils.NewLogger("", "", "configuration-service")
+
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
+
+	if !common.StageExists(params.ProjectName, params.StageName, false) {
+		return service.NewDeleteProjectProjectNameStageStageNameServiceServiceNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage " + params.StageName + " does not exist.")})
+	}
+
+	if !common.ServiceExists(params.ProjectName, params.StageName, params.ServiceName, false) {
+		return service.NewDeleteProjectProjectNameStageStageNameServiceServiceNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Service does not exist")})
+	}
+
+	logger.Debug("Deleting service " + params.ServiceName + " of project " + params.ProjectName + " in stage " + params.StageName)
+	changes := common.NewChangeSet()
+	changes.Delete(params.ServiceName)
+
+	_, err := common.CommitChanges(params.ProjectName, params.StageName, "Deleted service: "+params.ServiceName, changes)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Could not commit deletion of service %s: %s", params.ServiceName, err.Error()))
+		return service.NewDeleteProjectProjectNameStageStageNameServiceServiceNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not commit changes")})
+	}
+
+	mv := common.GetProjectsMaterializedView()
+	err = mv.DeleteService(params.ProjectName, params.StageName, params.ServiceName)
+	if err != nil {
+		return service.NewDeleteProjectProjectNameStageStageNameServiceServiceNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
+	}
+
+	logger.Debug("Service " + params.ServiceName + " has been deleted in stage " + params.StageName)
+	return service.NewDeleteProjectProjectNameStageStageNameServiceServiceNameNoContent()
 }
 
 func GetServices(params services.GetServicesParams) middleware.Responder {
@@ -7,7 +7,6 @@ import (
 	"github.com/go-openapi/swag"
 	utils "github.com/keptn/go-utils/pkg/lib"
 	"github.com/keptn/keptn/configuration-service/common"
-	"github.com/keptn/keptn/configuration-service/config"
 	"github.com/keptn/keptn/configuration-service/models"
 	"github.com/keptn/keptn/configuration-service/restapi/operations/service_default_resource"
 )
@@ -45,25 +44,16 @@ func PostProjectProjectNameServiceServiceNameResourceHandlerFunc(params service_
 		if !common.ServiceExists(params.ProjectName, branch, params.ServiceName, false) {
 			return service_default_resource.NewPostProjectProjectNameServiceServiceNameResourceDefault(404).WithPayload(&models.Error{Code: 400, Message: swag.String("Service does not exist")})
 		}
-		serviceConfigPath := config.ConfigDir + "/" + params.ProjectName + "/" + params.ServiceName
-
-		logger.Debug("Creating new resource(s) in: " + serviceConfigPath + " in stage " + branch)
-		logger.Debug("Checking out branch: " + branch)
-		err := common.CheckoutBranch(params.ProjectName, branch, false)
-		if err != nil {
-			logger.Error(fmt.Sprintf("Could not check out %s branch of project %s", branch, params.ProjectName))
-			logger.Error(err.Error())
-			return service_default_resource.NewPostProjectProjectNameServiceServiceNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
-		}
 
+		logger.Debug("Creating new resource(s) for service " + params.ServiceName + " in project " + params.ProjectName + " in stage " + branch)
+		changes := common.NewChangeSet()
 		for _, res := range params.Resources.Resources {
-			filePath := serviceConfigPath + "/" + *res.ResourceURI
+			filePath := params.ServiceName + "/" + *res.ResourceURI
 			logger.Debug("Adding resource: " + filePath)
-			common.WriteBase64EncodedFile(filePath, res.ResourceContent)
+			changes.WriteBase64EncodedFile(filePath, res.ResourceContent)
 		}
 
-		logger.Debug("Staging Changes")
-		err = common.StageAndCommitAll(params.ProjectName, "Added resources", true)
+		_, err := common.CommitChanges(params.ProjectName, branch, "Added resources", changes)
 		if err != nil {
 			logger.Error(fmt.Sprintf("Could not commit to %s branch of project %s", branch, params.ProjectName))
 			logger.Error(err.Error())
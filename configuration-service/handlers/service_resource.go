@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -13,17 +14,53 @@ import (
 	"github.com/go-openapi/swag"
 	utils "github.com/keptn/go-utils/pkg/lib"
 	"github.com/keptn/keptn/configuration-service/common"
-	"github.com/keptn/keptn/configuration-service/config"
 	"github.com/keptn/keptn/configuration-service/models"
 	"github.com/keptn/keptn/configuration-service/restapi/operations/service_resource"
 	"github.com/mholt/archiver"
-	"github.com/otiai10/copy"
 )
 
 // GetProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc get list of resources for the service
 func GetProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 	params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) middleware.Responder {
-	return middleware.NotImplemented("operation service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResource has not yet been implemented")
+	logger := utils.NewLogger("", "", "configuration-service")
+
+	if err := common.RLockBranch(params.ProjectName, params.StageName, *params.DisableUpstreamSync); err != nil {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceNotFound().
+			WithPayload(&models.Error{Code: 404, Message: swag.String("Service not found")})
+	}
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
+
+	if !common.ServiceExists(params.ProjectName, params.StageName, params.ServiceName, true) {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceNotFound().
+			WithPayload(&models.Error{Code: 404, Message: swag.String("Service not found")})
+	}
+
+	filter := ""
+	if params.ResourceFilter != nil {
+		filter = *params.ResourceFilter
+	}
+
+	files, err := common.ListFiles(params.ProjectName, params.StageName, params.ServiceName)
+	if err != nil {
+		logger.Error(err.Error())
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceDefault(500).
+			WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve service resources")})
+	}
+	result := common.GetFilteredPaginatedResources(files, filter, params.PageSize, params.NextPageKey)
+
+	if params.IncludeLatestVersion != nil && *params.IncludeLatestVersion {
+		for _, resource := range result.Resources {
+			version, err := common.GetLatestFileVersion(params.ProjectName, params.StageName, params.ServiceName+"/"+*resource.ResourceURI)
+			if err != nil {
+				logger.Error(err.Error())
+				return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceDefault(500).
+					WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve resource versions")})
+			}
+			resource.LatestVersion = version
+		}
+	}
+
+	return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceOK().WithPayload(result)
 }
 
 // GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc gets the specified resource
@@ -31,60 +68,67 @@ func GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHan
 	params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
 	logger := utils.NewLogger("", "", "configuration-service")
 
-    common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
+	if err := common.RLockBranch(params.ProjectName, params.StageName, *params.DisableUpstreamSync); err != nil {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINotFound().
+			WithPayload(&models.Error{Code: 404, Message: swag.String("Service not found")})
+	}
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
 
-	serviceConfigPath := config.ConfigDir + "/" + params.ProjectName + "/" + params.ServiceName
-	resourcePath := serviceConfigPath + "/" + params.ResourceURI
-	if !common.ServiceExists(params.ProjectName, params.StageName, params.ServiceName, *params.DisableUpstreamSync) {
+	if !common.ServiceExists(params.ProjectName, params.StageName, params.ServiceName, true) {
 		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINotFound().
 			WithPayload(&models.Error{Code: 404, Message: swag.String("Service not found")})
 	}
 
-	logger.Debug("Checking out " + params.StageName + " branch")
-	err := common.CheckoutBranch(params.ProjectName, params.StageName, *params.DisableUpstreamSync)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out %s branch of project %s", params.StageName, params.ProjectName))
-		logger.Error(err.Error())
-		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDefault(500).
-			WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
+	if params.GitCommitID != nil && *params.GitCommitID != "" {
+		logger.Debug("Reading " + params.ResourceURI + " at version " + *params.GitCommitID)
+		dat, err := getServiceResourceRevision(params.ProjectName, params.StageName, params.ServiceName, params.ResourceURI, *params.GitCommitID)
+		if err == common.ErrInvalidVersion {
+			return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDefault(400).
+				WithPayload(&models.Error{Code: 400, Message: swag.String("Invalid version")})
+		} else if err == common.ErrVersionNotFound {
+			return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINotFound().
+				WithPayload(&models.Error{Code: 404, Message: swag.String("Service resource not found in this version")})
+		} else if err != nil {
+			logger.Error(err.Error())
+			return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDefault(500).
+				WithPayload(&models.Error{Code: 500, Message: swag.String("Could not read file")})
+		}
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIOK().WithPayload(
+			&models.Resource{
+				ResourceURI:     &params.ResourceURI,
+				ResourceContent: base64.StdEncoding.EncodeToString(dat),
+			})
 	}
 
 	// archive the Helm chart
-	if strings.Contains(resourcePath, "helm") && strings.Contains(params.ResourceURI, ".tgz") {
+	if isHelmChartPackage(params.ResourceURI) {
 		logger.Debug("Archive the Helm chart: " + params.ResourceURI)
 
-		chartDir := strings.Replace(resourcePath, ".tgz", "", -1)
-		if err := archiver.Archive([]string{chartDir}, resourcePath); err != nil {
+		dat, err := packageHelmChart(params.ServiceName+"/"+params.ResourceURI, func(chartDir string, targetDir string) error {
+			return common.ExportDirectory(params.ProjectName, params.StageName, chartDir, targetDir)
+		})
+		if err != nil {
 			logger.Error(err.Error())
 			return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
 				WithPayload(&models.Error{Code: 400, Message: swag.String("Could archive the Helm chart directory")})
 		}
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIOK().WithPayload(
+			&models.Resource{
+				ResourceURI:     &params.ResourceURI,
+				ResourceContent: base64.StdEncoding.EncodeToString(dat),
+			})
 	}
 
-	if !common.FileExists(resourcePath) {
+	dat, err := common.GetFile(params.ProjectName, params.StageName, params.ServiceName+"/"+params.ResourceURI)
+	if err == common.ErrFileNotFound {
 		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINotFound().
 			WithPayload(&models.Error{Code: 404, Message: swag.String("Service resource not found")})
-	}
-
-	dat, err := ioutil.ReadFile(resourcePath)
-	if err != nil {
+	} else if err != nil {
 		logger.Error(err.Error())
 		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDefault(500).
 			WithPayload(&models.Error{Code: 500, Message: swag.String("Could not read file")})
 	}
 
-	// remove Helch chart .tgz file
-	if strings.Contains(resourcePath, "helm") && strings.HasSuffix(params.ResourceURI, ".tgz") {
-		logger.Debug("Remove the Helm chart: " + params.ResourceURI)
-
-		if err := os.Remove(resourcePath); err != nil {
-			logger.Error(err.Error())
-			return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-				WithPayload(&models.Error{Code: 400, Message: swag.String("Could not delete Helm chart package")})
-		}
-	}
-
 	resourceContent := base64.StdEncoding.EncodeToString(dat)
 	return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIOK().WithPayload(
 		&models.Resource{
@@ -93,6 +137,81 @@ func GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHan
 		})
 }
 
+// GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandlerFunc gets the versions of the specified resource
+func GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandlerFunc(
+	params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsParams) middleware.Responder {
+	logger := utils.NewLogger("", "", "configuration-service")
+
+	if err := common.RLockBranch(params.ProjectName, params.StageName, *params.DisableUpstreamSync); err != nil {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsNotFound().
+			WithPayload(&models.Error{Code: 404, Message: swag.String("Service not found")})
+	}
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
+
+	if !common.ServiceExists(params.ProjectName, params.StageName, params.ServiceName, true) {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsNotFound().
+			WithPayload(&models.Error{Code: 404, Message: swag.String("Service not found")})
+	}
+
+	// the versions of a Helm chart package are the versions of its chart directory
+	resourcePath := params.ServiceName + "/" + params.ResourceURI
+	if isHelmChartPackage(params.ResourceURI) {
+		resourcePath = strings.TrimSuffix(resourcePath, ".tgz")
+	}
+
+	versions, err := common.GetFileHistory(params.ProjectName, params.StageName, resourcePath)
+	if err != nil {
+		logger.Error(err.Error())
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsDefault(500).
+			WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve resource versions")})
+	}
+	if len(versions) == 0 {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsNotFound().
+			WithPayload(&models.Error{Code: 404, Message: swag.String("Service resource not found")})
+	}
+
+	return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsOK().
+		WithPayload(common.PaginateResourceVersions(versions, params.PageSize, params.NextPageKey))
+}
+
+func isHelmChartPackage(resourceURI string) bool {
+	return strings.Contains(resourceURI, "helm") && strings.HasSuffix(resourceURI, ".tgz")
+}
+
+// getServiceResourceRevision reads a service resource at the given version. Helm chart packages
+// are built from the chart directory at that version.
+func getServiceResourceRevision(project string, stage string, service string, resourceURI string, version string) ([]byte, error) {
+	resourcePath := service + "/" + resourceURI
+	if !isHelmChartPackage(resourceURI) {
+		return common.GetFileRevision(project, stage, version, resourcePath)
+	}
+
+	return packageHelmChart(resourcePath, func(chartDir string, targetDir string) error {
+		return common.ExportDirectoryRevision(project, stage, version, chartDir, targetDir)
+	})
+}
+
+// packageHelmChart builds the Helm chart package of a resource from its chart directory, which is
+// exported to a temporary directory by the given function
+func packageHelmChart(resourcePath string, exportChart func(chartDir string, targetDir string) error) ([]byte, error) {
+	tmpDir, err := ioutil.TempDir("", "helm-chart")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	chartDir := strings.TrimSuffix(resourcePath, ".tgz")
+	if err := exportChart(chartDir, tmpDir); err != nil {
+		return nil, err
+	}
+
+	packagePath := filepath.Join(tmpDir, filepath.Base(resourcePath))
+	if err := archiver.Archive([]string{filepath.Join(tmpDir, chartDir)}, packagePath); err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(packagePath)
+}
+
 // DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc deletes the specified resource
 func DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(
 	params service_resource.DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
@@ -106,11 +225,11 @@ func PostProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.ProjectExists(params.ProjectName) {
 		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-		WithPayload(&models.Error{Code: 400, Message: swag.String("Project " + params.ProjectName + " does not exist")})
+			WithPayload(&models.Error{Code: 400, Message: swag.String("Project " + params.ProjectName + " does not exist")})
 	}
 
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 
 	if !common.StageExists(params.ProjectName, params.StageName, false) {
 		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
@@ -121,33 +240,23 @@ func PostProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
 			WithPayload(&models.Error{Code: 400, Message: swag.String("Service " + params.ServiceName + " does not exist within stage " + params.StageName + " of project " + params.ProjectName)})
 	}
-	serviceConfigPath := config.ConfigDir + "/" + params.ProjectName + "/" + params.ServiceName
-
-	logger.Debug("Creating new resource(s) in: " + serviceConfigPath + " in stage " + params.StageName)
-	logger.Debug("Checking out branch: " + params.StageName)
-	err := common.CheckoutBranch(params.ProjectName, params.StageName, false)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out %s branch of project %s", params.StageName, params.ProjectName))
-		logger.Error(err.Error())
-		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
-	}
 
+	logger.Debug("Creating new resource(s) for service " + params.ServiceName + " in project " + params.ProjectName + " in stage " + params.StageName)
+	changes := common.NewChangeSet()
 	for _, res := range params.Resources.Resources {
-		filePath := serviceConfigPath + "/" + *res.ResourceURI
+		filePath := params.ServiceName + "/" + *res.ResourceURI
 		logger.Debug("Adding resource: " + filePath)
 
-		common.WriteBase64EncodedFile(filePath, res.ResourceContent)
-
-		if strings.Contains(filePath, "helm") && strings.HasSuffix(*res.ResourceURI, ".tgz") {
-			if resp := untarHelm(res, logger, filePath); resp != nil {
+		if isHelmChartPackage(filePath) {
+			if resp := untarHelm(res, logger, changes, filePath); resp != nil {
 				return resp
 			}
+			continue
 		}
+		changes.WriteBase64EncodedFile(filePath, res.ResourceContent)
 	}
 
-	logger.Debug("Staging Changes")
-	err = common.StageAndCommitAll(params.ProjectName, "Added resources", true)
+	newVersion, err := common.CommitChanges(params.ProjectName, params.StageName, "Added resources", changes)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Could not commit to %s branch of project %s", params.StageName, params.ProjectName))
 		logger.Error(err.Error())
@@ -156,19 +265,14 @@ func PostProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 	}
 	logger.Debug("Successfully added resources")
 
-	newVersion, err := common.GetCurrentVersion(params.ProjectName)
-	if err != nil {
-		logger.Error(err.Error())
-		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not retrieve latest version")})
-	}
 	return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceCreated().
 		WithPayload(&models.Version{
 			Version: newVersion,
 		})
 }
 
-func untarHelm(res *models.Resource, logger *utils.Logger, filePath string) middleware.Responder {
+// untarHelm adds the chart directory of a Helm chart package to the change set instead of the package itself
+func untarHelm(res *models.Resource, logger *utils.Logger, changes *common.ChangeSet, filePath string) middleware.Responder {
 	// unarchive the Helm chart
 	logger.Debug("Unarchive the Helm chart: " + *res.ResourceURI)
 	tmpDir, err := ioutil.TempDir("", "")
@@ -177,9 +281,17 @@ func untarHelm(res *models.Resource, logger *utils.Logger, filePath string) midd
 	}
 	defer os.RemoveAll(tmpDir)
 
+	packagePath, err := writeHelmChartPackage(res.ResourceContent)
+	if err != nil {
+		logger.Error(err.Error())
+		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
+			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not write Helm chart package")})
+	}
+	defer os.Remove(packagePath)
+
 	tarGz := archiver.NewTarGz()
 	tarGz.OverwriteExisting = true
-	if err := tarGz.Unarchive(filePath, tmpDir); err != nil {
+	if err := tarGz.Unarchive(packagePath, tmpDir); err != nil {
 		logger.Error(err.Error())
 		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
 			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not unarchive Helm chart")})
@@ -208,27 +320,31 @@ func untarHelm(res *models.Resource, logger *utils.Logger, filePath string) midd
 		}
 	}
 
-	dir, err := filepath.Abs(filepath.Dir(filePath))
-	if err != nil {
+	if err := changes.WriteDirectory(path.Dir(filePath), tmpDir); err != nil {
 		logger.Error(err.Error())
 		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Path of Helm chart is invalid")})
+			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not copy folder")})
 	}
+	return nil
+}
 
-	if err := copy.Copy(tmpDir, dir); err != nil {
-		logger.Error(err.Error())
-		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not copy folder")})
+// writeHelmChartPackage writes the base64 encoded Helm chart package to a temporary file and returns its path
+func writeHelmChartPackage(content string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return "", err
+	}
+	packageFile, err := ioutil.TempFile("", "helm-chart-*.tgz")
+	if err != nil {
+		return "", err
 	}
+	defer packageFile.Close()
 
-	// remove Helm chart .tgz file
-	logger.Debug("Remove the Helm chart: " + *res.ResourceURI)
-	if err := os.Remove(filePath); err != nil {
-		logger.Error(err.Error())
-		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not delete Helm chart package")})
+	if _, err := packageFile.Write(data); err != nil {
+		os.Remove(packageFile.Name())
+		return "", err
 	}
-	return nil
+	return packageFile.Name(), nil
 }
 
 // PutProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc updates a list of resources
@@ -239,9 +355,9 @@ func PutProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
 			WithPayload(&models.Error{Code: 400, Message: swag.String("Project " + params.ProjectName + " does not exist")})
 	}
-	
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
+
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 
 	if !common.StageExists(params.ProjectName, params.StageName, false) {
 		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
@@ -252,31 +368,22 @@ func PutProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
 			WithPayload(&models.Error{Code: 400, Message: swag.String("Service " + params.ServiceName + " does not exist within stage " + params.StageName + " of project " + params.ProjectName)})
 	}
-	serviceConfigPath := config.ConfigDir + "/" + params.ProjectName + "/" + params.ServiceName
-
-	logger.Debug("Updating resource(s) in: " + serviceConfigPath + " in stage " + params.StageName)
-	logger.Debug("Checking out branch: " + params.StageName)
-	err := common.CheckoutBranch(params.ProjectName, params.StageName, false)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out %s branch of project %s", params.StageName, params.ProjectName))
-		logger.Error(err.Error())
-		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
-	}
 
+	logger.Debug("Updating resource(s) for service " + params.ServiceName + " in project " + params.ProjectName + " in stage " + params.StageName)
+	changes := common.NewChangeSet()
 	for _, res := range params.Resources.Resources {
-		filePath := serviceConfigPath + "/" + *res.ResourceURI
+		filePath := params.ServiceName + "/" + *res.ResourceURI
 		logger.Debug("Updating resource: " + filePath)
-		common.WriteBase64EncodedFile(filePath, res.ResourceContent)
-		if strings.Contains(filePath, "helm") && strings.HasSuffix(*res.ResourceURI, ".tgz") {
-			if resp := untarHelm(res, logger, filePath); resp != nil {
+		if isHelmChartPackage(filePath) {
+			if resp := untarHelm(res, logger, changes, filePath); resp != nil {
 				return resp
 			}
+			continue
 		}
+		changes.WriteBase64EncodedFile(filePath, res.ResourceContent)
 	}
 
-	logger.Debug("Staging Changes")
-	err = common.StageAndCommitAll(params.ProjectName, "Updated resources", true)
+	newVersion, err := common.CommitChanges(params.ProjectName, params.StageName, "Updated resources", changes)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Could not commit to %s branch of project %s", params.StageName, params.ProjectName))
 		logger.Error(err.Error())
@@ -285,12 +392,6 @@ func PutProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 	}
 	logger.Debug("Successfully updated resources")
 
-	newVersion, err := common.GetCurrentVersion(params.ProjectName)
-	if err != nil {
-		logger.Error(err.Error())
-		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not retrieve latest version")})
-	}
 	return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceCreated().WithPayload(&models.Version{
 		Version: newVersion,
 	})
@@ -301,31 +402,19 @@ func PutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHan
 	params service_resource.PutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
 	logger := utils.NewLogger("", "", "configuration-service")
 
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 
 	if !common.ServiceExists(params.ProjectName, params.StageName, params.ServiceName, false) {
 		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIBadRequest().
 			WithPayload(&models.Error{Code: 400, Message: swag.String("Service does not exist")})
 	}
-	
-	serviceConfigPath := config.ConfigDir + "/" + params.ProjectName + "/" + params.ServiceName
-
-	logger.Debug("updating resource(s) in: " + serviceConfigPath + " in stage " + params.StageName)
-	logger.Debug("Checking out branch: " + params.StageName)
-	err := common.CheckoutBranch(params.ProjectName, params.StageName, false)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out %s branch of project %s", params.StageName, params.ProjectName))
-		logger.Error(err.Error())
-		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
-	}
 
-	filePath := serviceConfigPath + "/" + params.ResourceURI
-	common.WriteBase64EncodedFile(filePath, params.Resource.ResourceContent)
+	logger.Debug("Updating resource " + params.ResourceURI + " for service " + params.ServiceName + " in project " + params.ProjectName + " in stage " + params.StageName)
+	changes := common.NewChangeSet()
+	changes.WriteBase64EncodedFile(params.ServiceName+"/"+params.ResourceURI, params.Resource.ResourceContent)
 
-	logger.Debug("Staging Changes")
-	err = common.StageAndCommitAll(params.ProjectName, "Updated resource: "+params.ResourceURI, true)
+	newVersion, err := common.CommitChanges(params.ProjectName, params.StageName, "Updated resource: "+params.ResourceURI, changes)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Could not commit to %s branch of project %s", params.StageName, params.ProjectName))
 		logger.Error(err.Error())
@@ -334,12 +423,6 @@ func PutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHan
 	}
 	logger.Debug("Successfully updated resource: " + params.ResourceURI)
 
-	newVersion, err := common.GetCurrentVersion(params.ProjectName)
-	if err != nil {
-		logger.Error(err.Error())
-		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not retrieve latest version")})
-	}
 	return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURICreated().
 		WithPayload(&models.Version{
 			Version: newVersion,
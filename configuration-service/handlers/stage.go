@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/ghodss/yaml"
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime/middleware"
@@ -9,10 +11,8 @@ import (
 	keptnmodels "github.com/keptn/go-utils/pkg/lib"
 	utils "github.com/keptn/go-utils/pkg/lib"
 	"github.com/keptn/keptn/configuration-service/common"
-	"github.com/keptn/keptn/configuration-service/config"
 	"github.com/keptn/keptn/configuration-service/models"
 	"github.com/keptn/keptn/configuration-service/restapi/operations/stage"
-	"io/ioutil"
 )
 
 func getStages(params stage.GetProjectProjectNameStageParams) ([]*models.Stage, errors.Error) {
@@ -21,21 +21,17 @@ func getStages(params stage.GetProjectProjectNameStageParams) ([]*models.Stage,
 		return nil, errors.New(404, "Project does not exist.")
 	}
 
-	err := common.CheckoutBranch(params.ProjectName, "master", *params.DisableUpstreamSync)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out master branch of project %s", params.ProjectName))
+	if err := common.RLockBranch(params.ProjectName, "master", *params.DisableUpstreamSync); err != nil {
+		logger.Error(fmt.Sprintf("Could not update master branch of project %s", params.ProjectName))
 		logger.Error(err.Error())
 		return nil, errors.New(500, "Could not retrieve stages.")
 	}
+	defer common.RUnlockBranch(params.ProjectName, "master")
 
-	shipyardPath := config.ConfigDir + "/" + params.ProjectName + "/shipyard.yaml"
-
-	if !common.FileExists(shipyardPath) {
+	dat, err := common.GetFile(params.ProjectName, "master", "shipyard.yaml")
+	if err == common.ErrFileNotFound {
 		return nil, errors.New(500, "Could not retrieve stages.")
-	}
-
-	dat, err := ioutil.ReadFile(shipyardPath)
-	if err != nil {
+	} else if err != nil {
 		logger.Error(err.Error())
 		return nil, errors.New(500, "Could not read shipyard file.")
 	}
@@ -66,9 +62,10 @@ func PostProjectProjectNameStageHandlerFunc(params stage.PostProjectProjectNameS
 		return stage.NewPostProjectProjectNameStageBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Project does not exist.")})
 	}
 
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
-	
+	// the new stage branch is created from the master branch
+	common.LockBranches(params.ProjectName, "master", params.Stage.StageName)
+	defer common.UnlockBranches(params.ProjectName, "master", params.Stage.StageName)
+
 	err := common.CreateBranch(params.ProjectName, params.Stage.StageName, "master")
 	if err != nil {
 		logger.Error(fmt.Sprintf("Could not create %s branch for project %s", params.Stage.StageName, params.ProjectName))
@@ -87,12 +84,78 @@ func PostProjectProjectNameStageHandlerFunc(params stage.PostProjectProjectNameS
 
 // PutProjectProjectNameStageStageNameHandlerFunc updates a stage
 func PutProjectProjectNameStageStageNameHandlerFunc(params stage.PutProjectProjectNameStageStageNameParams) middleware.Responder {
-	return middleware.NotImplemented("operation stage.PutProjectProjectNameStageStageName has not yet been implemented")
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ProjectExists(params.ProjectName) {
+		return stage.NewPutProjectProjectNameStageStageNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Project does not exist.")})
+	}
+	if params.Stage == nil || params.Stage.StageName == "" {
+		return stage.NewPutProjectProjectNameStageStageNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("No stage name provided.")})
+	}
+	newStageName := params.Stage.StageName
+	if newStageName == params.StageName {
+		return stage.NewPutProjectProjectNameStageStageNameNoContent()
+	}
+	if params.StageName == "master" || newStageName == "master" {
+		return stage.NewPutProjectProjectNameStageStageNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("The master branch cannot be used as stage.")})
+	}
+
+	common.LockBranches(params.ProjectName, params.StageName, newStageName)
+	defer common.UnlockBranches(params.ProjectName, params.StageName, newStageName)
+
+	if !common.StageExists(params.ProjectName, params.StageName, false) {
+		return stage.NewPutProjectProjectNameStageStageNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage does not exist.")})
+	}
+	if common.StageExists(params.ProjectName, newStageName, false) {
+		return stage.NewPutProjectProjectNameStageStageNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage " + newStageName + " already exists.")})
+	}
+
+	err := common.RenameBranch(params.ProjectName, params.StageName, newStageName)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Could not rename %s branch of project %s to %s", params.StageName, params.ProjectName, newStageName))
+		logger.Error(err.Error())
+		return stage.NewPutProjectProjectNameStageStageNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not update stage.")})
+	}
+
+	mv := common.GetProjectsMaterializedView()
+	err = mv.RenameStage(params.ProjectName, params.StageName, newStageName)
+	if err != nil {
+		return stage.NewPutProjectProjectNameStageStageNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
+	}
+
+	return stage.NewPutProjectProjectNameStageStageNameNoContent()
 }
 
 // DeleteProjectProjectNameStageStageNameHandlerFunc deletes a stage
 func DeleteProjectProjectNameStageStageNameHandlerFunc(params stage.DeleteProjectProjectNameStageStageNameParams) middleware.Responder {
-	return middleware.NotImplemented("operation stage.DeleteProjectProjectNameStageStageName has not yet been implemented")
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ProjectExists(params.ProjectName) {
+		return stage.NewDeleteProjectProjectNameStageStageNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Project does not exist.")})
+	}
+	if params.StageName == "master" {
+		return stage.NewDeleteProjectProjectNameStageStageNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("The master branch cannot be deleted.")})
+	}
+
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
+
+	if !common.StageExists(params.ProjectName, params.StageName, false) {
+		return stage.NewDeleteProjectProjectNameStageStageNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage does not exist.")})
+	}
+
+	err := common.DeleteBranch(params.ProjectName, params.StageName)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Could not delete %s branch of project %s", params.StageName, params.ProjectName))
+		logger.Error(err.Error())
+		return stage.NewDeleteProjectProjectNameStageStageNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not delete stage.")})
+	}
+
+	mv := common.GetProjectsMaterializedView()
+	err = mv.DeleteStage(params.ProjectName, params.StageName)
+	if err != nil {
+		return stage.NewDeleteProjectProjectNameStageStageNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
+	}
+
+	return stage.NewDeleteProjectProjectNameStageStageNameNoContent()
 }
 
 // GetProjectProjectNameStageHandlerFunc gets list of stages for a project
@@ -148,3 +211,43 @@ func GetProjectProjectNameStageStageNameHandlerFunc(params stage.GetProjectProje
 	}
 	return stage.NewGetProjectProjectNameStageStageNameNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage not found")})
 }
+
+// GetStageDiff gets the differences of the configuration between two stages
+func GetStageDiff(params stage.GetStageDiffParams) middleware.Responder {
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ProjectExists(params.ProjectName) {
+		return stage.NewGetStageDiffNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
+	}
+
+	// syncing the stages with the upstream updates their branches
+	common.LockBranches(params.ProjectName, params.FromStage, params.ToStage)
+	defer common.UnlockBranches(params.ProjectName, params.FromStage, params.ToStage)
+
+	// checking the stages also gets their branches in sync with the upstream
+	for _, stageName := range []string{params.FromStage, params.ToStage} {
+		if !common.StageExists(params.ProjectName, stageName, false) {
+			return stage.NewGetStageDiffNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage " + stageName + " not found")})
+		}
+	}
+
+	path := ""
+	if params.ServiceName != nil && *params.ServiceName != "" {
+		path = *params.ServiceName
+	}
+	if params.ResourcePath != nil && *params.ResourcePath != "" {
+		path = strings.TrimPrefix(path+"/"+*params.ResourcePath, "/")
+	}
+
+	files, err := common.GetBranchDiff(params.ProjectName, params.FromStage, params.ToStage, path)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Could not compare stages %s and %s of project %s", params.FromStage, params.ToStage, params.ProjectName))
+		logger.Error(err.Error())
+		return stage.NewGetStageDiffDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not compare stages")})
+	}
+
+	return stage.NewGetStageDiffOK().WithPayload(&models.StageDiff{
+		FromStage: params.FromStage,
+		ToStage:   params.ToStage,
+		Files:     files,
+	})
+}
@@ -3,13 +3,11 @@ package handlers
 import (
 	"encoding/base64"
 	"fmt"
-	"io/ioutil"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
 	utils "github.com/keptn/go-utils/pkg/lib"
 	"github.com/keptn/keptn/configuration-service/common"
-	"github.com/keptn/keptn/configuration-service/config"
 	"github.com/keptn/keptn/configuration-service/models"
 	"github.com/keptn/keptn/configuration-service/restapi/operations/stage_resource"
 )
@@ -17,53 +15,50 @@ import (
 // GetProjectProjectNameStageStageNameResourceHandlerFunc get list of stage resources
 func GetProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resource.GetProjectProjectNameStageStageNameResourceParams) middleware.Responder {
 	logger := utils.NewLogger("", "", "configuration-service")
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
-
-	if !common.StageExists(params.ProjectName, params.StageName, false) {
+	if err := common.RLockBranch(params.ProjectName, params.StageName, false); err != nil {
 		return stage_resource.NewGetProjectProjectNameStageStageNameResourceNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage does not exist")})
 	}
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
 
-	logger.Debug("Checking out " + params.StageName + " branch")
-	err := common.CheckoutBranch(params.ProjectName, params.StageName, *params.DisableUpstreamSync)
+	files, err := common.ListFiles(params.ProjectName, params.StageName, "")
 	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out %s branch for project %s", params.StageName, params.ProjectName))
 		logger.Error(err.Error())
 		return stage_resource.NewGetProjectProjectNameStageStageNameResourceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve stage resources")})
 	}
-
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	result := common.GetPaginatedResources(projectConfigPath, params.PageSize, params.NextPageKey)
+	result := common.GetPaginatedResources(files, params.PageSize, params.NextPageKey)
 	return stage_resource.NewGetProjectProjectNameStageStageNameResourceOK().WithPayload(result)
 }
 
 // GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc get the specified resource
 func GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(params stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIParams) middleware.Responder {
 	logger := utils.NewLogger("", "", "configuration-service")
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
-
-	if !common.StageExists(params.ProjectName, params.StageName, *params.DisableUpstreamSync) {
+	if err := common.RLockBranch(params.ProjectName, params.StageName, *params.DisableUpstreamSync); err != nil {
 		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
 	}
-
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	resourcePath := projectConfigPath + "/" + params.ResourceURI
-
-	logger.Debug("Checking out " + params.StageName + " branch")
-	err := common.CheckoutBranch(params.ProjectName, params.StageName, *params.DisableUpstreamSync)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out %s branch for project %s", params.StageName, params.ProjectName))
-		logger.Error(err.Error())
-		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch containing stage config")})
-	}
-
-	if !common.FileExists(resourcePath) {
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
+
+	if params.GitCommitID != nil && *params.GitCommitID != "" {
+		logger.Debug("Reading " + params.ResourceURI + " at version " + *params.GitCommitID)
+		dat, err := common.GetFileRevision(params.ProjectName, params.StageName, *params.GitCommitID, params.ResourceURI)
+		if err == common.ErrInvalidVersion {
+			return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIDefault(400).WithPayload(&models.Error{Code: 400, Message: swag.String("Invalid version")})
+		} else if err == common.ErrVersionNotFound {
+			return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage resource not found in this version")})
+		} else if err != nil {
+			logger.Error(err.Error())
+			return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not read file")})
+		}
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIOK().WithPayload(
+			&models.Resource{
+				ResourceURI:     &params.ResourceURI,
+				ResourceContent: base64.StdEncoding.EncodeToString(dat),
+			})
+	}
+
+	dat, err := common.GetFile(params.ProjectName, params.StageName, params.ResourceURI)
+	if err == common.ErrFileNotFound {
 		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage resource not found")})
-	}
-
-	dat, err := ioutil.ReadFile(resourcePath)
-	if err != nil {
+	} else if err != nil {
 		logger.Error(err.Error())
 		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not read file")})
 	}
@@ -76,47 +71,52 @@ func GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(params st
 		})
 }
 
+// GetProjectProjectNameStageStageNameResourceResourceURIVersionsHandlerFunc gets the versions of the specified resource
+func GetProjectProjectNameStageStageNameResourceResourceURIVersionsHandlerFunc(params stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIVersionsParams) middleware.Responder {
+	logger := utils.NewLogger("", "", "configuration-service")
+	if err := common.RLockBranch(params.ProjectName, params.StageName, *params.DisableUpstreamSync); err != nil {
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIVersionsNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage not found")})
+	}
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
+
+	versions, err := common.GetFileHistory(params.ProjectName, params.StageName, params.ResourceURI)
+	if err != nil {
+		logger.Error(err.Error())
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIVersionsDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve resource versions")})
+	}
+	if len(versions) == 0 {
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIVersionsNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage resource not found")})
+	}
+
+	return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIVersionsOK().WithPayload(common.PaginateResourceVersions(versions, params.PageSize, params.NextPageKey))
+}
+
 // PostProjectProjectNameStageStageNameResourceHandlerFunc creates list of new resources in a stage
 func PostProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resource.PostProjectProjectNameStageStageNameResourceParams) middleware.Responder {
 	logger := utils.NewLogger("", "", "configuration-service")
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 
 	if !common.StageExists(params.ProjectName, params.StageName, false) {
 		return stage_resource.NewPostProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage does not exist")})
 	}
 
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-
-	logger.Debug("Creating new resource(s) in: " + projectConfigPath + " in stage " + params.StageName)
-	logger.Debug("Checking out branch: " + params.StageName)
-	err := common.CheckoutBranch(params.ProjectName, params.StageName, false)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out %s branch for project %s", params.StageName, params.ProjectName))
-		logger.Error(err.Error())
-		return stage_resource.NewPostProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch containing stage config")})
-	}
+	logger.Debug("Creating new resource(s) in project " + params.ProjectName + " in stage " + params.StageName)
 
+	changes := common.NewChangeSet()
 	for _, res := range params.Resources.Resources {
-		filePath := projectConfigPath + "/" + *res.ResourceURI
-		logger.Debug("Adding resource: " + filePath)
-		common.WriteBase64EncodedFile(filePath, res.ResourceContent)
+		logger.Debug("Adding resource: " + *res.ResourceURI)
+		changes.WriteBase64EncodedFile(*res.ResourceURI, res.ResourceContent)
 	}
 
-	logger.Debug("Staging Changes")
-	err = common.StageAndCommitAll(params.ProjectName, "Added resources", true)
+	logger.Debug("Committing changes")
+	newVersion, err := common.CommitChanges(params.ProjectName, params.StageName, "Added resources", changes)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Could not commit to %s branch for project %s", params.StageName, params.ProjectName))
 		logger.Error(err.Error())
 		return stage_resource.NewPostProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not commit changes")})
 	}
 	logger.Debug("Successfully added resources")
-
-	newVersion, err := common.GetCurrentVersion(params.ProjectName)
-	if err != nil {
-		logger.Error(err.Error())
-		return stage_resource.NewPostProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not retrieve latest vesion")})
-	}
 	return stage_resource.NewPostProjectProjectNameStageStageNameResourceCreated().WithPayload(&models.Version{
 		Version: newVersion,
 	})
@@ -125,32 +125,22 @@ func PostProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resour
 // PutProjectProjectNameStageStageNameResourceHandlerFunc updates list of stage resources
 func PutProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resource.PutProjectProjectNameStageStageNameResourceParams) middleware.Responder {
 	logger := utils.NewLogger("", "", "configuration-service")
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 
 	if !common.StageExists(params.ProjectName, params.StageName, false) {
 		return stage_resource.NewPutProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage does not exist")})
 	}
 
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-
-	logger.Debug("Creating new resource(s) in: " + projectConfigPath + " in stage " + params.StageName)
-	logger.Debug("Checking out branch: " + params.StageName)
-	err := common.CheckoutBranch(params.ProjectName, params.StageName, false)
-
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out %s branch for project %s", params.StageName, params.ProjectName))
-		logger.Error(err.Error())
-		return stage_resource.NewPutProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
-	}
+	logger.Debug("Creating new resource(s) in project " + params.ProjectName + " in stage " + params.StageName)
 
+	changes := common.NewChangeSet()
 	for _, res := range params.Resources.Resources {
-		filePath := projectConfigPath + "/" + *res.ResourceURI
-		common.WriteBase64EncodedFile(filePath, res.ResourceContent)
+		changes.WriteBase64EncodedFile(*res.ResourceURI, res.ResourceContent)
 	}
 
-	logger.Debug("Staging Changes")
-	err = common.StageAndCommitAll(params.ProjectName, "Updated resources", true)
+	logger.Debug("Committing changes")
+	newVersion, err := common.CommitChanges(params.ProjectName, params.StageName, "Updated resources", changes)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Could not commit to %s branch for project %s", params.StageName, params.ProjectName))
 		logger.Error(err.Error())
@@ -158,12 +148,6 @@ func PutProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resourc
 	}
 	logger.Debug("Successfully updated resources")
 
-	newVersion, err := common.GetCurrentVersion(params.ProjectName)
-	if err != nil {
-		logger.Error(err.Error())
-		return stage_resource.NewPutProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not retrieve latest version")})
-	}
-
 	return stage_resource.NewPutProjectProjectNameStageStageNameResourceCreated().WithPayload(&models.Version{
 		Version: newVersion,
 	})
@@ -172,41 +156,26 @@ func PutProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resourc
 // PutProjectProjectNameStageStageNameResourceResourceURIHandlerFunc updates the specified stage resource
 func PutProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(params stage_resource.PutProjectProjectNameStageStageNameResourceResourceURIParams) middleware.Responder {
 	logger := utils.NewLogger("", "", "configuration-service")
-	common.LockProject(params.ProjectName)
-	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 
 	if !common.StageExists(params.ProjectName, params.StageName, false) {
 		return stage_resource.NewPutProjectProjectNameStageStageNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage does not exist")})
 	}
 
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-
-	logger.Debug("Creating new resource(s) in: " + projectConfigPath + " in stage " + params.StageName)
-	logger.Debug("Checking out branch: " + params.StageName)
-	err := common.CheckoutBranch(params.ProjectName, params.StageName, false)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Could not check out %s branch for project %s", params.StageName, params.ProjectName))
-		logger.Error(err.Error())
-		return stage_resource.NewPutProjectProjectNameStageStageNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
-	}
+	logger.Debug("Creating new resource(s) in project " + params.ProjectName + " in stage " + params.StageName)
 
-	filePath := projectConfigPath + "/" + params.ResourceURI
-	common.WriteBase64EncodedFile(filePath, params.Resource.ResourceContent)
+	changes := common.NewChangeSet()
+	changes.WriteBase64EncodedFile(params.ResourceURI, params.Resource.ResourceContent)
 
-	logger.Debug("Staging Changes")
-	err = common.StageAndCommitAll(params.ProjectName, "Updated resource: "+params.ResourceURI, true)
+	logger.Debug("Committing changes")
+	newVersion, err := common.CommitChanges(params.ProjectName, params.StageName, "Updated resource: "+params.ResourceURI, changes)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Could not commit to %s branch for project %s", params.StageName, params.ProjectName))
 		logger.Error(err.Error())
 		return stage_resource.NewPutProjectProjectNameStageStageNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not commit changes")})
 	}
 	logger.Debug("Successfully updated resource: " + params.ResourceURI)
-
-	newVersion, err := common.GetCurrentVersion(params.ProjectName)
-	if err != nil {
-		logger.Error(err.Error())
-		return stage_resource.NewPutProjectProjectNameStageStageNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not retrieve latest version")})
-	}
 	return stage_resource.NewPutProjectProjectNameStageStageNameResourceResourceURICreated().WithPayload(&models.Version{
 		Version: newVersion,
 	})
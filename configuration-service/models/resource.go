@@ -17,6 +17,9 @@ import (
 // swagger:model Resource
 type Resource struct {
 
+	// latest version
+	LatestVersion *ResourceVersion `json:"latestVersion,omitempty"`
+
 	// Resource content
 	ResourceContent string `json:"resourceContent,omitempty"`
 
@@ -29,6 +32,10 @@ type Resource struct {
 func (m *Resource) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateLatestVersion(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateResourceURI(formats); err != nil {
 		res = append(res, err)
 	}
@@ -39,6 +46,24 @@ func (m *Resource) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Resource) validateLatestVersion(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.LatestVersion) { // not required
+		return nil
+	}
+
+	if m.LatestVersion != nil {
+		if err := m.LatestVersion.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("latestVersion")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Resource) validateResourceURI(formats strfmt.Registry) error {
 
 	if err := validate.Required("resourceURI", "body", m.ResourceURI); err != nil {
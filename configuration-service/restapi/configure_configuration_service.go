@@ -10,13 +10,10 @@ import (
 	"github.com/keptn/keptn/configuration-service/restapi/operations/service_approval"
 	"github.com/keptn/keptn/configuration-service/restapi/operations/services"
 	"net/http"
-	"os"
-	"os/exec"
 	"strings"
 
 	errors "github.com/go-openapi/errors"
 	runtime "github.com/go-openapi/runtime"
-	utils "github.com/keptn/go-utils/pkg/lib"
 	handlers "github.com/keptn/keptn/configuration-service/handlers"
 	"github.com/keptn/keptn/configuration-service/restapi/operations"
 	"github.com/keptn/keptn/configuration-service/restapi/operations/project"
@@ -69,6 +66,7 @@ func configureAPI(api *operations.ConfigurationServiceAPI) http.Handler {
 	api.ProjectResourceGetProjectProjectNameResourceHandler = project_resource.GetProjectProjectNameResourceHandlerFunc(handlers.GetProjectProjectNameResourceHandlerFunc)
 
 	api.ProjectResourceGetProjectProjectNameResourceResourceURIHandler = project_resource.GetProjectProjectNameResourceResourceURIHandlerFunc(handlers.GetProjectProjectNameResourceResourceURIHandlerFunc)
+	api.ProjectResourceGetProjectProjectNameResourceResourceURIVersionsHandler = project_resource.GetProjectProjectNameResourceResourceURIVersionsHandlerFunc(handlers.GetProjectProjectNameResourceResourceURIVersionsHandlerFunc)
 
 	api.ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceHandler = service_default_resource.GetProjectProjectNameServiceServiceNameResourceHandlerFunc(handlers.GetProjectProjectNameServiceServiceNameResourceHandlerFunc)
 
@@ -81,6 +79,7 @@ func configureAPI(api *operations.ConfigurationServiceAPI) http.Handler {
 	api.StageResourceGetProjectProjectNameStageStageNameResourceHandler = stage_resource.GetProjectProjectNameStageStageNameResourceHandlerFunc(handlers.GetProjectProjectNameStageStageNameResourceHandlerFunc)
 
 	api.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHandler = stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(handlers.GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc)
+	api.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIVersionsHandler = stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIVersionsHandlerFunc(handlers.GetProjectProjectNameStageStageNameResourceResourceURIVersionsHandlerFunc)
 
 	api.ServiceGetProjectProjectNameStageStageNameServiceHandler = service.GetProjectProjectNameStageStageNameServiceHandlerFunc(handlers.GetProjectProjectNameStageStageNameServiceHandlerFunc)
 
@@ -89,6 +88,7 @@ func configureAPI(api *operations.ConfigurationServiceAPI) http.Handler {
 	api.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceHandler = service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(handlers.GetProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc)
 
 	api.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler = service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(handlers.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc)
+	api.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandler = service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandlerFunc(handlers.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandlerFunc)
 
 	api.ProjectPostProjectHandler = project.PostProjectHandlerFunc(handlers.PostProjectHandlerFunc)
 
@@ -148,6 +148,8 @@ func configureAPI(api *operations.ConfigurationServiceAPI) http.Handler {
 
 	api.RemediationCloseRemediationsHandler = remediation.CloseRemediationsHandlerFunc(handlers.CloseRemediations)
 
+	api.StageGetStageDiffHandler = stage.GetStageDiffHandlerFunc(handlers.GetStageDiff)
+
 	api.ServerShutdown = func() {}
 
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
@@ -163,23 +165,6 @@ func configureTLS(tlsConfig *tls.Config) {
 // This function can be called multiple times, depending on the number of serving schemes.
 // scheme value will be set accordingly: "http", "https" or "unix"
 func configureServer(s *http.Server, scheme, addr string) {
-	logger := utils.NewLogger("", "", "configuration-service")
-	if os.Getenv("env") == "production" {
-		///////// initialize git ////////////
-		logger.Debug("Configuring git user.email")
-		cmd := exec.Command("git", "config", "--global", "user.email", "keptn@keptn.com")
-		_, err := cmd.Output()
-		if err != nil {
-			logger.Error("Could not configure git user.email: " + err.Error())
-		}
-		logger.Debug("Configuring git user.name")
-		cmd = exec.Command("git", "config", "--global", "user.name", "keptn")
-		_, err = cmd.Output()
-		if err != nil {
-			logger.Error("Could not configure git user.name: " + err.Error())
-		}
-		////////////////////////////////////
-	}
 }
 
 // The middleware configuration is for the handler executors. These do not apply to the swagger.json document.
@@ -212,6 +212,68 @@ func init() {
         }
       ]
     },
+    "/project/{projectName}/diff": {
+      "get": {
+        "tags": [
+          "stage"
+        ],
+        "summary": "Get the differences of the configuration between two stages",
+        "operationId": "getStageDiff",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the stage the diff starts from",
+            "name": "fromStage",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Name of the stage the diff leads to",
+            "name": "toStage",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Limit the diff to the resources of this service",
+            "name": "serviceName",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Limit the diff to this resource path (relative to the service, if a service is given)",
+            "name": "resourcePath",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/StageDiff"
+            }
+          },
+          "404": {
+            "description": "Failed. Project or stage could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        }
+      ]
+    },
     "/project/{projectName}/resource": {
       "get": {
         "tags": [
@@ -324,6 +386,11 @@ func init() {
           "Project Resource"
         ],
         "summary": "Get the specified resource",
+        "parameters": [
+          {
+            "$ref": "#/parameters/gitCommitID"
+          }
+        ],
         "responses": {
           "200": {
             "description": "Success",
@@ -411,6 +478,53 @@ func init() {
         }
       ]
     },
+    "/project/{projectName}/resource/{resourceURI}/versions": {
+      "get": {
+        "tags": [
+          "Project Resource"
+        ],
+        "summary": "Get the versions of the specified resource",
+        "parameters": [
+          {
+            "$ref": "#/parameters/pageSize"
+          },
+          {
+            "$ref": "#/parameters/nextPageKey"
+          },
+          {
+            "$ref": "#/parameters/disableUpstreamSync"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceVersions"
+            }
+          },
+          "404": {
+            "description": "Failed. Project resource could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/resourceURI"
+        }
+      ]
+    },
     "/project/{projectName}/service": {
       "get": {
         "tags": [
@@ -975,6 +1089,9 @@ func init() {
         "parameters": [
           {
             "$ref": "#/parameters/disableUpstreamSync"
+          },
+          {
+            "$ref": "#/parameters/gitCommitID"
           }
         ],
         "responses": {
@@ -1064,6 +1181,56 @@ func init() {
         }
       ]
     },
+    "/project/{projectName}/stage/{stageName}/resource/{resourceURI}/versions": {
+      "get": {
+        "tags": [
+          "Stage Resource"
+        ],
+        "summary": "Get the versions of the specified resource",
+        "parameters": [
+          {
+            "$ref": "#/parameters/pageSize"
+          },
+          {
+            "$ref": "#/parameters/nextPageKey"
+          },
+          {
+            "$ref": "#/parameters/disableUpstreamSync"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceVersions"
+            }
+          },
+          "404": {
+            "description": "Failed. Stage resource could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/stageName"
+        },
+        {
+          "$ref": "#/parameters/resourceURI"
+        }
+      ]
+    },
     "/project/{projectName}/stage/{stageName}/service": {
       "get": {
         "tags": [
@@ -1542,6 +1709,18 @@ func init() {
           },
           {
             "$ref": "#/parameters/disableUpstreamSync"
+          },
+          {
+            "type": "string",
+            "description": "Only return resources whose URI starts with this prefix or matches this glob pattern (e.g. helm/ or *.jmx)",
+            "name": "resourceFilter",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Return the latest version (i.e. commit) that modified each resource",
+            "name": "includeLatestVersion",
+            "in": "query"
           }
         ],
         "responses": {
@@ -1648,6 +1827,9 @@ func init() {
         "parameters": [
           {
             "$ref": "#/parameters/disableUpstreamSync"
+          },
+          {
+            "$ref": "#/parameters/gitCommitID"
           }
         ],
         "responses": {
@@ -1739,6 +1921,59 @@ func init() {
           "$ref": "#/parameters/resourceURI"
         }
       ]
+    },
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/versions": {
+      "get": {
+        "tags": [
+          "Service Resource"
+        ],
+        "summary": "Get the versions of the specified resource",
+        "parameters": [
+          {
+            "$ref": "#/parameters/pageSize"
+          },
+          {
+            "$ref": "#/parameters/nextPageKey"
+          },
+          {
+            "$ref": "#/parameters/disableUpstreamSync"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceVersions"
+            }
+          },
+          "404": {
+            "description": "Failed. Service resource could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/stageName"
+        },
+        {
+          "$ref": "#/parameters/serviceName"
+        },
+        {
+          "$ref": "#/parameters/resourceURI"
+        }
+      ]
     }
   },
   "definitions": {
@@ -1974,6 +2209,23 @@ func init() {
         }
       }
     },
+    "FileDiff": {
+      "type": "object",
+      "properties": {
+        "diff": {
+          "description": "Unified diff of the file",
+          "type": "string"
+        },
+        "path": {
+          "description": "Path of the file relative to the project repository",
+          "type": "string"
+        },
+        "status": {
+          "description": "Kind of change, i.e. added, removed, or changed",
+          "type": "string"
+        }
+      }
+    },
     "InverseServiceStageInfo": {
       "type": "object",
       "properties": {
@@ -2138,6 +2390,9 @@ func init() {
         "resourceURI"
       ],
       "properties": {
+        "latestVersion": {
+          "$ref": "#/definitions/ResourceVersion"
+        },
         "resourceContent": {
           "description": "Resource content",
           "type": "string"
@@ -2148,6 +2403,50 @@ func init() {
         }
       }
     },
+    "ResourceVersion": {
+      "type": "object",
+      "properties": {
+        "author": {
+          "description": "Author of the version",
+          "type": "string"
+        },
+        "message": {
+          "description": "Commit message of the version",
+          "type": "string"
+        },
+        "time": {
+          "description": "Creation time of the version",
+          "type": "string"
+        },
+        "version": {
+          "description": "Version identifier, i.e. the commit ID",
+          "type": "string"
+        }
+      }
+    },
+    "ResourceVersions": {
+      "type": "object",
+      "properties": {
+        "nextPageKey": {
+          "description": "Pointer to next page, base64 encoded",
+          "type": "string"
+        },
+        "pageSize": {
+          "description": "Size of returned page",
+          "type": "number"
+        },
+        "totalCount": {
+          "description": "Total number of versions",
+          "type": "number"
+        },
+        "versions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ResourceVersion"
+          }
+        }
+      }
+    },
     "Resources": {
       "type": "object",
       "properties": {
@@ -2241,6 +2540,25 @@ func init() {
         }
       }
     },
+    "StageDiff": {
+      "type": "object",
+      "properties": {
+        "files": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FileDiff"
+          }
+        },
+        "fromStage": {
+          "description": "Stage the diff starts from",
+          "type": "string"
+        },
+        "toStage": {
+          "description": "Stage the diff leads to",
+          "type": "string"
+        }
+      }
+    },
     "Stages": {
       "type": "object",
       "properties": {
@@ -2289,6 +2607,12 @@ func init() {
       "name": "disableUpstreamSync",
       "in": "query"
     },
+    "gitCommitID": {
+      "type": "string",
+      "description": "The commit ID (i.e. the version) of the resource to return",
+      "name": "gitCommitID",
+      "in": "query"
+    },
     "keptnContext": {
       "type": "string",
       "description": "Keptn Context",
@@ -2610,6 +2934,72 @@ func init() {
         }
       ]
     },
+    "/project/{projectName}/diff": {
+      "get": {
+        "tags": [
+          "stage"
+        ],
+        "summary": "Get the differences of the configuration between two stages",
+        "operationId": "getStageDiff",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the stage the diff starts from",
+            "name": "fromStage",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Name of the stage the diff leads to",
+            "name": "toStage",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Limit the diff to the resources of this service",
+            "name": "serviceName",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Limit the diff to this resource path (relative to the service, if a service is given)",
+            "name": "resourcePath",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/StageDiff"
+            }
+          },
+          "404": {
+            "description": "Failed. Project or stage could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
     "/project/{projectName}/resource": {
       "get": {
         "tags": [
@@ -2763,6 +3153,14 @@ func init() {
           "Project Resource"
         ],
         "summary": "Get the specified resource",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The commit ID (i.e. the version) of the resource to return",
+            "name": "gitCommitID",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "Success",
@@ -2818,19 +3216,94 @@ func init() {
               "$ref": "#/definitions/Error"
             }
           }
-        }
-      },
-      "delete": {
-        "tags": [
-          "Project Resource"
+        }
+      },
+      "delete": {
+        "tags": [
+          "Project Resource"
+        ],
+        "summary": "Delete the specified resource",
+        "responses": {
+          "204": {
+            "description": "Success. Project resource has been deleted. Response does not have a body."
+          },
+          "400": {
+            "description": "Failed. Project resource could not be deleted.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Resource URI",
+          "name": "resourceURI",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "boolean",
+          "default": false,
+          "description": "Disable sync of upstream repo before reading content",
+          "name": "disableUpstreamSync",
+          "in": "query"
+        }
+      ]
+    },
+    "/project/{projectName}/resource/{resourceURI}/versions": {
+      "get": {
+        "tags": [
+          "Project Resource"
+        ],
+        "summary": "Get the versions of the specified resource",
+        "parameters": [
+          {
+            "maximum": 50,
+            "minimum": 1,
+            "type": "integer",
+            "default": 20,
+            "description": "The number of items to return",
+            "name": "pageSize",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Pointer to the next set of items",
+            "name": "nextPageKey",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Disable sync of upstream repo before reading content",
+            "name": "disableUpstreamSync",
+            "in": "query"
+          }
         ],
-        "summary": "Delete the specified resource",
         "responses": {
-          "204": {
-            "description": "Success. Project resource has been deleted. Response does not have a body."
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceVersions"
+            }
           },
-          "400": {
-            "description": "Failed. Project resource could not be deleted.",
+          "404": {
+            "description": "Failed. Project resource could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2857,13 +3330,6 @@ func init() {
           "name": "resourceURI",
           "in": "path",
           "required": true
-        },
-        {
-          "type": "boolean",
-          "default": false,
-          "description": "Disable sync of upstream repo before reading content",
-          "name": "disableUpstreamSync",
-          "in": "query"
         }
       ]
     },
@@ -3601,6 +4067,12 @@ func init() {
             "description": "Disable sync of upstream repo before reading content",
             "name": "disableUpstreamSync",
             "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "The commit ID (i.e. the version) of the resource to return",
+            "name": "gitCommitID",
+            "in": "query"
           }
         ],
         "responses": {
@@ -3707,6 +4179,81 @@ func init() {
         }
       ]
     },
+    "/project/{projectName}/stage/{stageName}/resource/{resourceURI}/versions": {
+      "get": {
+        "tags": [
+          "Stage Resource"
+        ],
+        "summary": "Get the versions of the specified resource",
+        "parameters": [
+          {
+            "maximum": 50,
+            "minimum": 1,
+            "type": "integer",
+            "default": 20,
+            "description": "The number of items to return",
+            "name": "pageSize",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Pointer to the next set of items",
+            "name": "nextPageKey",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Disable sync of upstream repo before reading content",
+            "name": "disableUpstreamSync",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceVersions"
+            }
+          },
+          "404": {
+            "description": "Failed. Stage resource could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the stage",
+          "name": "stageName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Resource URI",
+          "name": "resourceURI",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
     "/project/{projectName}/stage/{stageName}/service": {
       "get": {
         "tags": [
@@ -4337,6 +4884,18 @@ func init() {
             "description": "Disable sync of upstream repo before reading content",
             "name": "disableUpstreamSync",
             "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return resources whose URI starts with this prefix or matches this glob pattern (e.g. helm/ or *.jmx)",
+            "name": "resourceFilter",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Return the latest version (i.e. commit) that modified each resource",
+            "name": "includeLatestVersion",
+            "in": "query"
           }
         ],
         "responses": {
@@ -4483,6 +5042,12 @@ func init() {
             "description": "Disable sync of upstream repo before reading content",
             "name": "disableUpstreamSync",
             "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "The commit ID (i.e. the version) of the resource to return",
+            "name": "gitCommitID",
+            "in": "query"
           }
         ],
         "responses": {
@@ -4595,6 +5160,88 @@ func init() {
           "required": true
         }
       ]
+    },
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/versions": {
+      "get": {
+        "tags": [
+          "Service Resource"
+        ],
+        "summary": "Get the versions of the specified resource",
+        "parameters": [
+          {
+            "maximum": 50,
+            "minimum": 1,
+            "type": "integer",
+            "default": 20,
+            "description": "The number of items to return",
+            "name": "pageSize",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Pointer to the next set of items",
+            "name": "nextPageKey",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Disable sync of upstream repo before reading content",
+            "name": "disableUpstreamSync",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceVersions"
+            }
+          },
+          "404": {
+            "description": "Failed. Service resource could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the stage",
+          "name": "stageName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the service",
+          "name": "serviceName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Resource URI",
+          "name": "resourceURI",
+          "in": "path",
+          "required": true
+        }
+      ]
     }
   },
   "definitions": {
@@ -4830,6 +5477,23 @@ func init() {
         }
       }
     },
+    "FileDiff": {
+      "type": "object",
+      "properties": {
+        "diff": {
+          "description": "Unified diff of the file",
+          "type": "string"
+        },
+        "path": {
+          "description": "Path of the file relative to the project repository",
+          "type": "string"
+        },
+        "status": {
+          "description": "Kind of change, i.e. added, removed, or changed",
+          "type": "string"
+        }
+      }
+    },
     "InverseServiceStageInfo": {
       "type": "object",
       "properties": {
@@ -4994,6 +5658,9 @@ func init() {
         "resourceURI"
       ],
       "properties": {
+        "latestVersion": {
+          "$ref": "#/definitions/ResourceVersion"
+        },
         "resourceContent": {
           "description": "Resource content",
           "type": "string"
@@ -5004,6 +5671,50 @@ func init() {
         }
       }
     },
+    "ResourceVersion": {
+      "type": "object",
+      "properties": {
+        "author": {
+          "description": "Author of the version",
+          "type": "string"
+        },
+        "message": {
+          "description": "Commit message of the version",
+          "type": "string"
+        },
+        "time": {
+          "description": "Creation time of the version",
+          "type": "string"
+        },
+        "version": {
+          "description": "Version identifier, i.e. the commit ID",
+          "type": "string"
+        }
+      }
+    },
+    "ResourceVersions": {
+      "type": "object",
+      "properties": {
+        "nextPageKey": {
+          "description": "Pointer to next page, base64 encoded",
+          "type": "string"
+        },
+        "pageSize": {
+          "description": "Size of returned page",
+          "type": "number"
+        },
+        "totalCount": {
+          "description": "Total number of versions",
+          "type": "number"
+        },
+        "versions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ResourceVersion"
+          }
+        }
+      }
+    },
     "Resources": {
       "type": "object",
       "properties": {
@@ -5097,6 +5808,25 @@ func init() {
         }
       }
     },
+    "StageDiff": {
+      "type": "object",
+      "properties": {
+        "files": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FileDiff"
+          }
+        },
+        "fromStage": {
+          "description": "Stage the diff starts from",
+          "type": "string"
+        },
+        "toStage": {
+          "description": "Stage the diff leads to",
+          "type": "string"
+        }
+      }
+    },
     "Stages": {
       "type": "object",
       "properties": {
@@ -5145,6 +5875,12 @@ func init() {
       "name": "disableUpstreamSync",
       "in": "query"
     },
+    "gitCommitID": {
+      "type": "string",
+      "description": "The commit ID (i.e. the version) of the resource to return",
+      "name": "gitCommitID",
+      "in": "query"
+    },
     "keptnContext": {
       "type": "string",
       "description": "Keptn Context",
@@ -82,6 +82,9 @@ func NewConfigurationServiceAPI(spec *loads.Document) *ConfigurationServiceAPI {
 		ProjectResourceGetProjectProjectNameResourceResourceURIHandler: project_resource.GetProjectProjectNameResourceResourceURIHandlerFunc(func(params project_resource.GetProjectProjectNameResourceResourceURIParams) middleware.Responder {
 			return middleware.NotImplemented("operation ProjectResourceGetProjectProjectNameResourceResourceURI has not yet been implemented")
 		}),
+		ProjectResourceGetProjectProjectNameResourceResourceURIVersionsHandler: project_resource.GetProjectProjectNameResourceResourceURIVersionsHandlerFunc(func(params project_resource.GetProjectProjectNameResourceResourceURIVersionsParams) middleware.Responder {
+			return middleware.NotImplemented("operation ProjectResourceGetProjectProjectNameResourceResourceURIVersions has not yet been implemented")
+		}),
 		ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceHandler: service_default_resource.GetProjectProjectNameServiceServiceNameResourceHandlerFunc(func(params service_default_resource.GetProjectProjectNameServiceServiceNameResourceParams) middleware.Responder {
 			return middleware.NotImplemented("operation ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResource has not yet been implemented")
 		}),
@@ -100,6 +103,9 @@ func NewConfigurationServiceAPI(spec *loads.Document) *ConfigurationServiceAPI {
 		StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHandler: stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(func(params stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIParams) middleware.Responder {
 			return middleware.NotImplemented("operation StageResourceGetProjectProjectNameStageStageNameResourceResourceURI has not yet been implemented")
 		}),
+		StageResourceGetProjectProjectNameStageStageNameResourceResourceURIVersionsHandler: stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIVersionsHandlerFunc(func(params stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIVersionsParams) middleware.Responder {
+			return middleware.NotImplemented("operation StageResourceGetProjectProjectNameStageStageNameResourceResourceURIVersions has not yet been implemented")
+		}),
 		ServiceGetProjectProjectNameStageStageNameServiceHandler: service.GetProjectProjectNameStageStageNameServiceHandlerFunc(func(params service.GetProjectProjectNameStageStageNameServiceParams) middleware.Responder {
 			return middleware.NotImplemented("operation ServiceGetProjectProjectNameStageStageNameService has not yet been implemented")
 		}),
@@ -112,6 +118,9 @@ func NewConfigurationServiceAPI(spec *loads.Document) *ConfigurationServiceAPI {
 		ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler: service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(func(params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
 			return middleware.NotImplemented("operation ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURI has not yet been implemented")
 		}),
+		ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandler: service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandlerFunc(func(params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsParams) middleware.Responder {
+			return middleware.NotImplemented("operation ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersions has not yet been implemented")
+		}),
 		ProjectPostProjectHandler: project.PostProjectHandlerFunc(func(params project.PostProjectParams) middleware.Responder {
 			return middleware.NotImplemented("operation ProjectPostProject has not yet been implemented")
 		}),
@@ -196,6 +205,9 @@ func NewConfigurationServiceAPI(spec *loads.Document) *ConfigurationServiceAPI {
 		ServiceApprovalGetServiceApprovalsHandler: service_approval.GetServiceApprovalsHandlerFunc(func(params service_approval.GetServiceApprovalsParams) middleware.Responder {
 			return middleware.NotImplemented("operation ServiceApprovalGetServiceApprovals has not yet been implemented")
 		}),
+		StageGetStageDiffHandler: stage.GetStageDiffHandlerFunc(func(params stage.GetStageDiffParams) middleware.Responder {
+			return middleware.NotImplemented("operation StageGetStageDiff has not yet been implemented")
+		}),
 		EventHandleEventHandler: event.HandleEventHandlerFunc(func(params event.HandleEventParams) middleware.Responder {
 			return middleware.NotImplemented("operation EventHandleEvent has not yet been implemented")
 		}),
@@ -252,6 +264,8 @@ type ConfigurationServiceAPI struct {
 	ProjectResourceGetProjectProjectNameResourceHandler project_resource.GetProjectProjectNameResourceHandler
 	// ProjectResourceGetProjectProjectNameResourceResourceURIHandler sets the operation handler for the get project project name resource resource URI operation
 	ProjectResourceGetProjectProjectNameResourceResourceURIHandler project_resource.GetProjectProjectNameResourceResourceURIHandler
+	// ProjectResourceGetProjectProjectNameResourceResourceURIVersionsHandler sets the operation handler for the get project project name resource resource URI versions operation
+	ProjectResourceGetProjectProjectNameResourceResourceURIVersionsHandler project_resource.GetProjectProjectNameResourceResourceURIVersionsHandler
 	// ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceHandler sets the operation handler for the get project project name service service name resource operation
 	ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceHandler service_default_resource.GetProjectProjectNameServiceServiceNameResourceHandler
 	// ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceResourceURIHandler sets the operation handler for the get project project name service service name resource resource URI operation
@@ -264,6 +278,8 @@ type ConfigurationServiceAPI struct {
 	StageResourceGetProjectProjectNameStageStageNameResourceHandler stage_resource.GetProjectProjectNameStageStageNameResourceHandler
 	// StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHandler sets the operation handler for the get project project name stage stage name resource resource URI operation
 	StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHandler stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHandler
+	// StageResourceGetProjectProjectNameStageStageNameResourceResourceURIVersionsHandler sets the operation handler for the get project project name stage stage name resource resource URI versions operation
+	StageResourceGetProjectProjectNameStageStageNameResourceResourceURIVersionsHandler stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIVersionsHandler
 	// ServiceGetProjectProjectNameStageStageNameServiceHandler sets the operation handler for the get project project name stage stage name service operation
 	ServiceGetProjectProjectNameStageStageNameServiceHandler service.GetProjectProjectNameStageStageNameServiceHandler
 	// ServiceGetProjectProjectNameStageStageNameServiceServiceNameHandler sets the operation handler for the get project project name stage stage name service service name operation
@@ -272,6 +288,8 @@ type ConfigurationServiceAPI struct {
 	ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceHandler service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceHandler
 	// ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler sets the operation handler for the get project project name stage stage name service service name resource resource URI operation
 	ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler
+	// ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandler sets the operation handler for the get project project name stage stage name service service name resource resource URI versions operation
+	ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandler service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandler
 	// ProjectPostProjectHandler sets the operation handler for the post project operation
 	ProjectPostProjectHandler project.PostProjectHandler
 	// ProjectResourcePostProjectProjectNameResourceHandler sets the operation handler for the post project project name resource operation
@@ -328,6 +346,8 @@ type ConfigurationServiceAPI struct {
 	ServiceApprovalGetServiceApprovalHandler service_approval.GetServiceApprovalHandler
 	// ServiceApprovalGetServiceApprovalsHandler sets the operation handler for the get service approvals operation
 	ServiceApprovalGetServiceApprovalsHandler service_approval.GetServiceApprovalsHandler
+	// StageGetStageDiffHandler sets the operation handler for the get stage diff operation
+	StageGetStageDiffHandler stage.GetStageDiffHandler
 	// EventHandleEventHandler sets the operation handler for the handle event operation
 	EventHandleEventHandler event.HandleEventHandler
 
@@ -437,6 +457,10 @@ func (o *ConfigurationServiceAPI) Validate() error {
 		unregistered = append(unregistered, "project_resource.GetProjectProjectNameResourceResourceURIHandler")
 	}
 
+	if o.ProjectResourceGetProjectProjectNameResourceResourceURIVersionsHandler == nil {
+		unregistered = append(unregistered, "project_resource.GetProjectProjectNameResourceResourceURIVersionsHandler")
+	}
+
 	if o.ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceHandler == nil {
 		unregistered = append(unregistered, "service_default_resource.GetProjectProjectNameServiceServiceNameResourceHandler")
 	}
@@ -461,6 +485,10 @@ func (o *ConfigurationServiceAPI) Validate() error {
 		unregistered = append(unregistered, "stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHandler")
 	}
 
+	if o.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIVersionsHandler == nil {
+		unregistered = append(unregistered, "stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIVersionsHandler")
+	}
+
 	if o.ServiceGetProjectProjectNameStageStageNameServiceHandler == nil {
 		unregistered = append(unregistered, "service.GetProjectProjectNameStageStageNameServiceHandler")
 	}
@@ -477,6 +505,10 @@ func (o *ConfigurationServiceAPI) Validate() error {
 		unregistered = append(unregistered, "service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler")
 	}
 
+	if o.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandler == nil {
+		unregistered = append(unregistered, "service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandler")
+	}
+
 	if o.ProjectPostProjectHandler == nil {
 		unregistered = append(unregistered, "project.PostProjectHandler")
 	}
@@ -589,6 +621,10 @@ func (o *ConfigurationServiceAPI) Validate() error {
 		unregistered = append(unregistered, "service_approval.GetServiceApprovalsHandler")
 	}
 
+	if o.StageGetStageDiffHandler == nil {
+		unregistered = append(unregistered, "stage.GetStageDiffHandler")
+	}
+
 	if o.EventHandleEventHandler == nil {
 		unregistered = append(unregistered, "event.HandleEventHandler")
 	}
@@ -749,6 +785,11 @@ func (o *ConfigurationServiceAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/project/{projectName}/resource/{resourceURI}"] = project_resource.NewGetProjectProjectNameResourceResourceURI(o.context, o.ProjectResourceGetProjectProjectNameResourceResourceURIHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/project/{projectName}/resource/{resourceURI}/versions"] = project_resource.NewGetProjectProjectNameResourceResourceURIVersions(o.context, o.ProjectResourceGetProjectProjectNameResourceResourceURIVersionsHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -779,6 +820,11 @@ func (o *ConfigurationServiceAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/project/{projectName}/stage/{stageName}/resource/{resourceURI}"] = stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURI(o.context, o.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/project/{projectName}/stage/{stageName}/resource/{resourceURI}/versions"] = stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIVersions(o.context, o.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIVersionsHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -799,6 +845,11 @@ func (o *ConfigurationServiceAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}"] = service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURI(o.context, o.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/versions"] = service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersions(o.context, o.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIVersionsHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -939,6 +990,11 @@ func (o *ConfigurationServiceAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/project/{projectName}/stage/{stageName}/service/{serviceName}/approval"] = service_approval.NewGetServiceApprovals(o.context, o.ServiceApprovalGetServiceApprovalsHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/project/{projectName}/diff"] = stage.NewGetStageDiff(o.context, o.StageGetStageDiffHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
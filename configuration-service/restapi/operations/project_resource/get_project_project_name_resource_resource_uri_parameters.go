@@ -45,6 +45,10 @@ type GetProjectProjectNameResourceResourceURIParams struct {
 	  Default: false
 	*/
 	DisableUpstreamSync *bool
+	/*The commit ID (i.e. the version) of the resource to return
+	  In: query
+	*/
+	GitCommitID *string
 	/*Name of the project
 	  Required: true
 	  In: path
@@ -73,6 +77,11 @@ func (o *GetProjectProjectNameResourceResourceURIParams) BindRequest(r *http.Req
 		res = append(res, err)
 	}
 
+	qGitCommitID, qhkGitCommitID, _ := qs.GetOK("gitCommitID")
+	if err := o.bindGitCommitID(qGitCommitID, qhkGitCommitID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rProjectName, rhkProjectName, _ := route.Params.GetOK("projectName")
 	if err := o.bindProjectName(rProjectName, rhkProjectName, route.Formats); err != nil {
 		res = append(res, err)
@@ -112,6 +121,24 @@ func (o *GetProjectProjectNameResourceResourceURIParams) bindDisableUpstreamSync
 	return nil
 }
 
+// bindGitCommitID binds and validates parameter GitCommitID from query.
+func (o *GetProjectProjectNameResourceResourceURIParams) bindGitCommitID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.GitCommitID = &raw
+
+	return nil
+}
+
 // bindProjectName binds and validates parameter ProjectName from path.
 func (o *GetProjectProjectNameResourceResourceURIParams) bindProjectName(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
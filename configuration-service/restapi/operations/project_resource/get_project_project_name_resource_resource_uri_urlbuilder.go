@@ -20,6 +20,7 @@ type GetProjectProjectNameResourceResourceURIURL struct {
 	ResourceURI string
 
 	DisableUpstreamSync *bool
+	GitCommitID         *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -77,6 +78,14 @@ func (o *GetProjectProjectNameResourceResourceURIURL) Build() (*url.URL, error)
 		qs.Set("disableUpstreamSync", disableUpstreamSyncQ)
 	}
 
+	var gitCommitIDQ string
+	if o.GitCommitID != nil {
+		gitCommitIDQ = *o.GitCommitID
+	}
+	if gitCommitIDQ != "" {
+		qs.Set("gitCommitID", gitCommitIDQ)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
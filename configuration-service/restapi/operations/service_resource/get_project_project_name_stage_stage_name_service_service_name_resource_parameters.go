@@ -50,6 +50,10 @@ type GetProjectProjectNameStageStageNameServiceServiceNameResourceParams struct
 	  Default: false
 	*/
 	DisableUpstreamSync *bool
+	/*Return the latest version (i.e. commit) that modified each resource
+	  In: query
+	*/
+	IncludeLatestVersion *bool
 	/*Pointer to the next set of items
 	  In: query
 	*/
@@ -66,6 +70,10 @@ type GetProjectProjectNameStageStageNameServiceServiceNameResourceParams struct
 	  In: path
 	*/
 	ProjectName string
+	/*Only return resources whose URI starts with this prefix or matches this glob pattern (e.g. helm/ or *.jmx)
+	  In: query
+	*/
+	ResourceFilter *string
 	/*Name of the service
 	  Required: true
 	  In: path
@@ -94,6 +102,11 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) Bi
 		res = append(res, err)
 	}
 
+	qIncludeLatestVersion, qhkIncludeLatestVersion, _ := qs.GetOK("includeLatestVersion")
+	if err := o.bindIncludeLatestVersion(qIncludeLatestVersion, qhkIncludeLatestVersion, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qNextPageKey, qhkNextPageKey, _ := qs.GetOK("nextPageKey")
 	if err := o.bindNextPageKey(qNextPageKey, qhkNextPageKey, route.Formats); err != nil {
 		res = append(res, err)
@@ -109,6 +122,11 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) Bi
 		res = append(res, err)
 	}
 
+	qResourceFilter, qhkResourceFilter, _ := qs.GetOK("resourceFilter")
+	if err := o.bindResourceFilter(qResourceFilter, qhkResourceFilter, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rServiceName, rhkServiceName, _ := route.Params.GetOK("serviceName")
 	if err := o.bindServiceName(rServiceName, rhkServiceName, route.Formats); err != nil {
 		res = append(res, err)
@@ -148,6 +166,28 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) bi
 	return nil
 }
 
+// bindIncludeLatestVersion binds and validates parameter IncludeLatestVersion from query.
+func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) bindIncludeLatestVersion(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("includeLatestVersion", "query", "bool", raw)
+	}
+	o.IncludeLatestVersion = &value
+
+	return nil
+}
+
 // bindNextPageKey binds and validates parameter NextPageKey from query.
 func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) bindNextPageKey(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -222,6 +262,24 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) bi
 	return nil
 }
 
+// bindResourceFilter binds and validates parameter ResourceFilter from query.
+func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) bindResourceFilter(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.ResourceFilter = &raw
+
+	return nil
+}
+
 // bindServiceName binds and validates parameter ServiceName from path.
 func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) bindServiceName(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
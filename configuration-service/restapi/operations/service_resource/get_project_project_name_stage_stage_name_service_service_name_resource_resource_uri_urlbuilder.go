@@ -22,6 +22,7 @@ type GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIURL
 	StageName   string
 
 	DisableUpstreamSync *bool
+	GitCommitID         *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -93,6 +94,14 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceUR
 		qs.Set("disableUpstreamSync", disableUpstreamSyncQ)
 	}
 
+	var gitCommitIDQ string
+	if o.GitCommitID != nil {
+		gitCommitIDQ = *o.GitCommitID
+	}
+	if gitCommitIDQ != "" {
+		qs.Set("gitCommitID", gitCommitIDQ)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
@@ -20,9 +20,11 @@ type GetProjectProjectNameStageStageNameServiceServiceNameResourceURL struct {
 	ServiceName string
 	StageName   string
 
-	DisableUpstreamSync *bool
-	NextPageKey         *string
-	PageSize            *int64
+	DisableUpstreamSync  *bool
+	IncludeLatestVersion *bool
+	NextPageKey          *string
+	PageSize             *int64
+	ResourceFilter       *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -87,6 +89,14 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceURL) Build
 		qs.Set("disableUpstreamSync", disableUpstreamSyncQ)
 	}
 
+	var includeLatestVersionQ string
+	if o.IncludeLatestVersion != nil {
+		includeLatestVersionQ = swag.FormatBool(*o.IncludeLatestVersion)
+	}
+	if includeLatestVersionQ != "" {
+		qs.Set("includeLatestVersion", includeLatestVersionQ)
+	}
+
 	var nextPageKeyQ string
 	if o.NextPageKey != nil {
 		nextPageKeyQ = *o.NextPageKey
@@ -103,6 +113,14 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceURL) Build
 		qs.Set("pageSize", pageSizeQ)
 	}
 
+	var resourceFilterQ string
+	if o.ResourceFilter != nil {
+		resourceFilterQ = *o.ResourceFilter
+	}
+	if resourceFilterQ != "" {
+		qs.Set("resourceFilter", resourceFilterQ)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
@@ -21,6 +21,7 @@ type GetProjectProjectNameStageStageNameResourceResourceURIURL struct {
 	StageName   string
 
 	DisableUpstreamSync *bool
+	GitCommitID         *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -85,6 +86,14 @@ func (o *GetProjectProjectNameStageStageNameResourceResourceURIURL) Build() (*ur
 		qs.Set("disableUpstreamSync", disableUpstreamSyncQ)
 	}
 
+	var gitCommitIDQ string
+	if o.GitCommitID != nil {
+		gitCommitIDQ = *o.GitCommitID
+	}
+	if gitCommitIDQ != "" {
+		qs.Set("gitCommitID", gitCommitIDQ)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
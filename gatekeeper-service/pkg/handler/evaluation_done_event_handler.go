@@ -38,8 +38,22 @@ func (e *EvaluationDoneEventHandler) Handle(event cloudevents.Event, keptnHandle
 		e.keptn.Logger.Error(err.Error())
 		return
 	}
+	digest, err := getDeployedImageDigest(os.Getenv(datastore), keptnHandler.KeptnContext, data.Project, data.Stage, data.Service)
+	if err != nil {
+		e.keptn.Logger.Error(fmt.Sprintf("failed to retrieve the digest of image %s, the image is promoted by its tag: %v", image, err))
+	}
+	image = pinImage(image, digest)
+
+	var rollout *ProgressiveRollout
+	if _, ok := data.Labels[canaryWeightLabel]; ok {
+		rollout, err = getProgressiveRollout(data.Project, data.Stage, os.Getenv(configService))
+		if err != nil {
+			e.keptn.Logger.Error(err.Error())
+			return
+		}
+	}
 
-	outgoingEvents := e.handleEvaluationDoneEvent(*data, keptnHandler.KeptnContext, image, *shipyard)
+	outgoingEvents := e.handleEvaluationDoneEvent(*data, keptnHandler.KeptnContext, image, *shipyard, rollout)
 	sendEvents(keptnHandler, outgoingEvents, e.keptn.Logger)
 }
 
@@ -63,7 +77,7 @@ func (EvaluationDoneEventHandler) getImage(project string, currentStage string,
 }
 
 func (e *EvaluationDoneEventHandler) handleEvaluationDoneEvent(inputEvent keptnevents.EvaluationDoneEventData, shkeptncontext string, image string,
-	shipyard keptnevents.Shipyard) []cloudevents.Event {
+	shipyard keptnevents.Shipyard, rollout *ProgressiveRollout) []cloudevents.Event {
 
 	nextStage := e.getNextStage(shipyard, inputEvent.Stage)
 
@@ -88,6 +102,17 @@ func (e *EvaluationDoneEventHandler) handleEvaluationDoneEvent(inputEvent keptne
 		return nil
 	}
 
+	if rollout != nil && (inputEvent.Result == PassResult || inputEvent.Result == WarningResult) {
+		if nextWeight, ok := rollout.getNextStep(inputEvent.Labels); ok {
+			e.keptn.Logger.Info(fmt.Sprintf("Service %s in project %s and stage %s has passed the evaluation with canary weight %s. "+
+				"Continue progressive rollout with canary weight %d", inputEvent.Service, inputEvent.Project, inputEvent.Stage,
+				inputEvent.Labels[canaryWeightLabel], nextWeight))
+			return []cloudevents.Event{*e.getConfigurationChangeEventForCanaryWeight(inputEvent, shkeptncontext, nextWeight)}
+		}
+	}
+	// The progressive rollout is completed or discarded, hence, its canary weight must not be passed on
+	inputEvent.Labels = removeCanaryWeightLabel(inputEvent.Labels)
+
 	outgoingEvents := make([]cloudevents.Event, 0)
 	if canaryAction := e.getCanaryAction(inputEvent, shkeptncontext); canaryAction != nil {
 		outgoingEvents = append(outgoingEvents, *canaryAction)
@@ -166,15 +191,25 @@ func (e *EvaluationDoneEventHandler) getConfigurationChangeEventForCanaryAction(
 	return getCloudEvent(configChangedEvent, keptnevents.ConfigurationChangeEventType, shkeptncontext, "")
 }
 
+func (e *EvaluationDoneEventHandler) getConfigurationChangeEventForCanaryWeight(inputEvent keptnevents.EvaluationDoneEventData, shkeptncontext string,
+	canaryWeight int32) *cloudevents.Event {
+
+	canary := keptnevents.Canary{Action: keptnevents.Set, Value: canaryWeight}
+	configChangedEvent := keptnevents.ConfigurationChangeEventData{
+		Project: inputEvent.Project,
+		Service: inputEvent.Service,
+		Stage:   inputEvent.Stage,
+		Canary:  &canary,
+		Labels:  inputEvent.Labels,
+	}
+
+	return getCloudEvent(configChangedEvent, keptnevents.ConfigurationChangeEventType, shkeptncontext, "")
+}
+
 func (e *EvaluationDoneEventHandler) getApprovalTriggeredEvent(inputEvent keptnevents.EvaluationDoneEventData,
 	nextStage string, shkeptncontext, image string) *cloudevents.Event {
 
-	splitImage := strings.Split(image, ":")
-	imageName := splitImage[0]
-	tag := ""
-	if len(splitImage) == 2 {
-		tag = splitImage[1]
-	}
+	imageName, tag := splitImage(image)
 
 	approvalTriggeredEvent := keptnevents.ApprovalTriggeredEventData{
 		Project:            inputEvent.Project,
@@ -13,6 +13,7 @@ var evaluationDoneTests = []struct {
 	name        string
 	image       string
 	shipyard    keptnevents.Shipyard
+	rollout     *ProgressiveRollout
 	inputEvent  keptnevents.EvaluationDoneEventData
 	outputEvent []cloudevents.Event
 }{
@@ -62,6 +63,37 @@ var evaluationDoneTests = []struct {
 		inputEvent:  getEvaluationDoneTestData(true),
 		outputEvent: nil,
 	},
+	{
+		name:       "pass-progressive-rollout-next-step",
+		image:      "docker.io/keptnexamples/carts:0.11.1",
+		shipyard:   getShipyardWithoutApproval(),
+		rollout:    getProgressiveRolloutTestData(),
+		inputEvent: getProgressiveEvaluationDoneTestData(true, "25"),
+		outputEvent: []cloudevents.Event{
+			getConfigurationChangeTestEventForCanaryWeight(50, "25"),
+		},
+	},
+	{
+		name:       "pass-progressive-rollout-last-step",
+		image:      "docker.io/keptnexamples/carts:0.11.1",
+		shipyard:   getShipyardWithoutApproval(),
+		rollout:    getProgressiveRolloutTestData(),
+		inputEvent: getProgressiveEvaluationDoneTestData(true, "100"),
+		outputEvent: []cloudevents.Event{
+			getConfigurationChangeTestEventForCanaryAction(keptnevents.Promote),
+			getConfigurationChangeTestEvent("docker.io/keptnexamples/carts:0.11.1", "production"),
+		},
+	},
+	{
+		name:       "fail-progressive-rollout",
+		image:      "docker.io/keptnexamples/carts:0.11.1",
+		shipyard:   getShipyardWithoutApproval(),
+		rollout:    getProgressiveRolloutTestData(),
+		inputEvent: getProgressiveEvaluationDoneTestData(false, "25"),
+		outputEvent: []cloudevents.Event{
+			getConfigurationChangeTestEventForCanaryAction(keptnevents.Discard),
+		},
+	},
 }
 
 func TestHandleEvaluationDoneEvent(t *testing.T) {
@@ -75,7 +107,7 @@ func TestHandleEvaluationDoneEvent(t *testing.T) {
 			ce.Data = dataBytes
 			keptnHandler, _ := keptnevents.NewKeptn(&ce, keptnevents.KeptnOpts{})
 			e := NewEvaluationDoneEventHandler(keptnHandler)
-			res := e.handleEvaluationDoneEvent(tt.inputEvent, shkeptncontext, tt.image, tt.shipyard)
+			res := e.handleEvaluationDoneEvent(tt.inputEvent, shkeptncontext, tt.image, tt.shipyard, tt.rollout)
 			if len(res) != len(tt.outputEvent) {
 				t.Errorf("got %d output event, want %v output events for %s",
 					len(res), len(tt.outputEvent), tt.name)
@@ -127,6 +159,36 @@ func getEvaluationDoneTestData(pass bool) keptnevents.EvaluationDoneEventData {
 	}
 }
 
+func getProgressiveRolloutTestData() *ProgressiveRollout {
+	return &ProgressiveRollout{Steps: []int32{10, 25, 50, 100}}
+}
+
+func getProgressiveEvaluationDoneTestData(pass bool, canaryWeight string) keptnevents.EvaluationDoneEventData {
+
+	data := getEvaluationDoneTestData(pass)
+	data.Labels[canaryWeightLabel] = canaryWeight
+	return data
+}
+
+func getConfigurationChangeTestEventForCanaryWeight(canaryWeight int32, currentCanaryWeight string) cloudevents.Event {
+
+	configurationChangeEvent := keptnevents.ConfigurationChangeEventData{
+		Project: "sockshop",
+		Service: "carts",
+		Stage:   "hardening",
+		Canary: &keptnevents.Canary{
+			Action: keptnevents.Set,
+			Value:  canaryWeight,
+		},
+		Labels: map[string]string{
+			"l1":              "lValue",
+			canaryWeightLabel: currentCanaryWeight,
+		},
+	}
+
+	return *getCloudEvent(configurationChangeEvent, keptnevents.ConfigurationChangeEventType, shkeptncontext, "")
+}
+
 func getConfigurationChangeTestEventForCanaryAction(action keptnevents.CanaryAction) cloudevents.Event {
 
 	configurationChangeEvent := keptnevents.ConfigurationChangeEventData{
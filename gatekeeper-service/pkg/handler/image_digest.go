@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+)
+
+// deploymentFinishedEventData contains the image digest reported by the helm-service in deployment-finished events
+type deploymentFinishedEventData struct {
+	Image       string `json:"image"`
+	Tag         string `json:"tag"`
+	ImageDigest string `json:"imageDigest,omitempty"`
+}
+
+// getDeployedImageDigest returns the image digest reported by the latest deployment-finished event of the service in the
+// stage within the keptnContext. An empty digest is returned if the deployment did not report one.
+func getDeployedImageDigest(datastoreURL string, shkeptncontext string, project string, stage string, service string) (string, error) {
+
+	eventHandler := configutils.NewEventHandler(datastoreURL)
+	events, errObj := eventHandler.GetEvents(&configutils.EventFilter{
+		Project:      project,
+		Stage:        stage,
+		Service:      service,
+		EventType:    keptnevents.DeploymentFinishedEventType,
+		KeptnContext: shkeptncontext,
+	})
+	if errObj != nil {
+		msg := "unknown error"
+		if errObj.Message != nil {
+			msg = *errObj.Message
+		}
+		return "", errors.New("failed to retrieve deployment-finished events: " + msg)
+	}
+
+	digest := ""
+	var latest time.Time
+	for _, event := range events {
+		if !latest.IsZero() && time.Time(event.Time).Before(latest) {
+			continue
+		}
+		dataBytes, err := json.Marshal(event.Data)
+		if err != nil {
+			return "", err
+		}
+		data := &deploymentFinishedEventData{}
+		if err := json.Unmarshal(dataBytes, data); err != nil {
+			return "", fmt.Errorf("failed to parse deployment-finished event %s: %v", event.ID, err)
+		}
+		digest = data.ImageDigest
+		latest = time.Time(event.Time)
+	}
+	return digest, nil
+}
+
+// pinImage appends the digest to the image, so that the next stage deploys exactly the evaluated image
+// even if its tag is moved. Images which already contain a digest are returned unchanged.
+func pinImage(image string, digest string) string {
+	if digest == "" || strings.Contains(image, "@") {
+		return image
+	}
+	return image + "@" + digest
+}
+
+// splitImage splits an image of the form name[:tag][@digest] into its name and its tag, which keeps the digest.
+// An image without tag is returned as name including its digest.
+func splitImage(image string) (string, string) {
+	nameEnd := len(image)
+	if i := strings.Index(image, "@"); i >= 0 {
+		nameEnd = i
+	}
+	if i := strings.LastIndex(image[:nameEnd], ":"); i > strings.LastIndex(image[:nameEnd], "/") {
+		return image[:i], image[i+1:]
+	}
+	return image, ""
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetDeployedImageDigest(t *testing.T) {
+
+	var query string
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			w.Header().Add("Content-Type", "application/json")
+			w.Write([]byte(`{"events": [
+				{"time": "2020-06-01T10:00:00.000Z", "data": {"image": "docker.io/keptnexamples/carts", "tag": "0.11.1", "imageDigest": "sha256:1111"}},
+				{"time": "2020-06-01T10:05:00.000Z", "data": {"image": "docker.io/keptnexamples/carts", "tag": "0.11.1", "imageDigest": "sha256:2222"}}
+			]}`))
+		}),
+	)
+	defer ts.Close()
+
+	digest, err := getDeployedImageDigest(ts.URL, shkeptncontext, "sockshop", "staging", "carts")
+	if err != nil {
+		t.Fatalf("getDeployedImageDigest() error = %v", err)
+	}
+	if digest != "sha256:2222" {
+		t.Errorf("getDeployedImageDigest() got = %s, want sha256:2222", digest)
+	}
+	for _, param := range []string{"keptnContext=" + shkeptncontext, "stage=staging", "type=sh.keptn.events.deployment-finished"} {
+		if !strings.Contains(query, param) {
+			t.Errorf("query %s does not contain %s", query, param)
+		}
+	}
+}
+
+func TestPinImage(t *testing.T) {
+	tests := []struct {
+		image  string
+		digest string
+		want   string
+	}{
+		{"docker.io/keptnexamples/carts:0.11.1", "sha256:2222", "docker.io/keptnexamples/carts:0.11.1@sha256:2222"},
+		{"docker.io/keptnexamples/carts:0.11.1", "", "docker.io/keptnexamples/carts:0.11.1"},
+		{"docker.io/keptnexamples/carts:0.11.1@sha256:1111", "sha256:2222", "docker.io/keptnexamples/carts:0.11.1@sha256:1111"},
+	}
+	for _, tt := range tests {
+		if got := pinImage(tt.image, tt.digest); got != tt.want {
+			t.Errorf("pinImage() got = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestSplitImage(t *testing.T) {
+	tests := []struct {
+		image string
+		name  string
+		tag   string
+	}{
+		{"docker.io/keptnexamples/carts:0.11.1", "docker.io/keptnexamples/carts", "0.11.1"},
+		{"localhost:5000/keptnexamples/carts", "localhost:5000/keptnexamples/carts", ""},
+		{"docker.io/keptnexamples/carts:0.11.1@sha256:2222", "docker.io/keptnexamples/carts", "0.11.1@sha256:2222"},
+		{"docker.io/keptnexamples/carts@sha256:2222", "docker.io/keptnexamples/carts@sha256:2222", ""},
+	}
+	for _, tt := range tests {
+		name, tag := splitImage(tt.image)
+		if name != tt.name || tag != tt.tag {
+			t.Errorf("splitImage() got = %s, %s, want %s, %s", name, tag, tt.name, tt.tag)
+		}
+	}
+}
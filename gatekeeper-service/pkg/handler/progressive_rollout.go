@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ghodss/yaml"
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+)
+
+// progressiveRolloutURI is the stage resource defining the progressive rollout of blue_green_service stages
+const progressiveRolloutURI = "progressive_rollout.yaml"
+
+// canaryWeightLabel is the label set by the helm-service which carries the canary weight of the current rollout step
+const canaryWeightLabel = "canary-weight"
+
+// ProgressiveRollout describes the traffic weights which are applied step by step on the canary
+type ProgressiveRollout struct {
+	Steps []int32 `json:"steps"`
+}
+
+// getProgressiveRollout returns the progressive rollout defined for the stage or nil if none is defined
+func getProgressiveRollout(project string, stage string, configServiceURL string) (*ProgressiveRollout, error) {
+
+	rHandler := configutils.NewResourceHandler(configServiceURL)
+	resource, err := rHandler.GetStageResource(project, stage, progressiveRolloutURI)
+	if err == configutils.ResourceNotFoundError {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve %s of stage %s in project %s: %v", progressiveRolloutURI, stage, project, err)
+	}
+
+	rollout := &ProgressiveRollout{}
+	if err := yaml.Unmarshal([]byte(resource.ResourceContent), rollout); err != nil {
+		return nil, fmt.Errorf("failed to parse %s of stage %s in project %s: %v", progressiveRolloutURI, stage, project, err)
+	}
+	if err := rollout.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s of stage %s in project %s: %v", progressiveRolloutURI, stage, project, err)
+	}
+	return rollout, nil
+}
+
+// validate checks the steps the same way as the helm-service, which applies them on the canary
+func (r *ProgressiveRollout) validate() error {
+	if len(r.Steps) == 0 {
+		return errors.New("no steps defined")
+	}
+	var previous int32
+	for _, step := range r.Steps {
+		if step <= previous || step > 100 {
+			return fmt.Errorf("step %d has to be greater than %d and at most 100", step, previous)
+		}
+		previous = step
+	}
+	if previous != 100 {
+		return errors.New("last step has to be 100")
+	}
+	return nil
+}
+
+// getNextStep returns the canary weight following the one contained in the labels.
+// The second return value is false if the rollout has already reached its last step.
+func (r *ProgressiveRollout) getNextStep(labels map[string]string) (int32, bool) {
+
+	current, err := strconv.Atoi(labels[canaryWeightLabel])
+	if err != nil {
+		return 0, false
+	}
+	for _, step := range r.Steps {
+		if step > int32(current) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// removeCanaryWeightLabel returns a copy of the labels without the canary weight of the progressive rollout
+func removeCanaryWeightLabel(labels map[string]string) map[string]string {
+
+	if _, ok := labels[canaryWeightLabel]; !ok {
+		return labels
+	}
+	res := make(map[string]string)
+	for k, v := range labels {
+		if k != canaryWeightLabel {
+			res[k] = v
+		}
+	}
+	return res
+}
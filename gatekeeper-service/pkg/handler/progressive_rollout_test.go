@@ -0,0 +1,28 @@
+package handler
+
+import "testing"
+
+func TestValidateProgressiveRollout(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		steps   []int32
+		wantErr bool
+	}{
+		{name: "valid", steps: []int32{10, 25, 50, 100}},
+		{name: "single step", steps: []int32{100}},
+		{name: "no steps", steps: nil, wantErr: true},
+		{name: "not ascending", steps: []int32{10, 50, 25, 100}, wantErr: true},
+		{name: "above 100", steps: []int32{10, 120}, wantErr: true},
+		{name: "not ending with 100", steps: []int32{10, 25, 50}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rollout := ProgressiveRollout{Steps: tt.steps}
+			if err := rollout.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -26,10 +26,12 @@ import (
 	cloudevents "github.com/cloudevents/sdk-go"
 	keptn "github.com/keptn/go-utils/pkg/lib"
 	"github.com/keptn/keptn/helm-service/controller/helm"
+	"github.com/keptn/keptn/helm-service/controller/mesh"
 )
 
-// ActionTriggeredHandler handles sh.keptn.events.action.triggered events for scaling
+// ActionTriggeredHandler handles sh.keptn.events.action.triggered events for scaling and patching values
 type ActionTriggeredHandler struct {
+	mesh             mesh.Mesh
 	keptnHandler     *keptn.Keptn
 	helmExecutor     helm.HelmExecutor
 	configServiceURL string
@@ -38,15 +40,33 @@ type ActionTriggeredHandler struct {
 // ActionScaling is the identifier for the scaling action
 const ActionScaling = "scaling"
 
+// ActionPatchValues is the identifier for the action patching the values of the user chart
+const ActionPatchValues = "patch-values"
+
 // NewActionTriggeredHandler creates a new ActionTriggeredHandler
-func NewActionTriggeredHandler(keptnHandler *keptn.Keptn,
+func NewActionTriggeredHandler(mesh mesh.Mesh, keptnHandler *keptn.Keptn,
 	configServiceURL string) *ActionTriggeredHandler {
 	helmExecutor := helm.NewHelmV3Executor(keptnHandler.Logger)
-	return &ActionTriggeredHandler{keptnHandler: keptnHandler, helmExecutor: helmExecutor,
+	return &ActionTriggeredHandler{mesh: mesh, keptnHandler: keptnHandler, helmExecutor: helmExecutor,
 		configServiceURL: configServiceURL}
 }
 
-// HandleEvent takes the sh.keptn.events.action.triggered event and performs the scaling action
+// Enqueue adds the action to the queue of the service, hence it is not executed concurrently with a configuration change
+func (a *ActionTriggeredHandler) Enqueue(queue *ServiceQueue, ce cloudevents.Event, loggingDone chan bool) error {
+
+	actionTriggeredEvent := keptn.ActionTriggeredEventData{}
+	if err := ce.DataAs(&actionTriggeredEvent); err != nil {
+		errMsg := "action.triggered event not well-formed: " + err.Error()
+		a.keptnHandler.Logger.Error(errMsg)
+		loggingDone <- true
+		return errors.New(errMsg)
+	}
+	queue.Enqueue(actionTriggeredEvent.Project, actionTriggeredEvent.Stage, actionTriggeredEvent.Service,
+		func() { a.HandleEvent(ce, loggingDone) }, nil)
+	return nil
+}
+
+// HandleEvent takes the sh.keptn.events.action.triggered event and performs the requested action
 func (a *ActionTriggeredHandler) HandleEvent(ce cloudevents.Event, loggingDone chan bool) error {
 
 	defer func() { loggingDone <- true }()
@@ -59,28 +79,41 @@ func (a *ActionTriggeredHandler) HandleEvent(ce cloudevents.Event, loggingDone c
 		return errors.New(errMsg)
 	}
 
-	if actionTriggeredEvent.Action.Action == ActionScaling {
-		// Send action.started event
-		if sendErr := a.sendEvent(ce, keptn.ActionStartedEventType, a.getActionStartedEvent(actionTriggeredEvent)); sendErr != nil {
-			a.keptnHandler.Logger.Error(sendErr.Error())
-			return errors.New(sendErr.Error())
-		}
+	var handleAction func(keptn.ActionTriggeredEventData, helm.StageTarget) keptn.ActionFinishedEventData
+	switch actionTriggeredEvent.Action.Action {
+	case ActionScaling:
+		handleAction = a.handleScaling
+	case ActionPatchValues:
+		handleAction = a.handlePatchValues
+	default:
+		a.keptnHandler.Logger.Info("Received unhandled action: " + actionTriggeredEvent.Action.Action + ". Exiting")
+		return nil
+	}
 
-		resp := a.handleScaling(actionTriggeredEvent)
-		if resp.Action.Status == keptn.ActionStatusErrored {
-			a.keptnHandler.Logger.Error(fmt.Sprintf("action %s failed with result %s", actionTriggeredEvent.Action.Action, resp.Action.Result))
-		} else {
-			a.keptnHandler.Logger.Info(fmt.Sprintf("Finished action with status %s and result %s", resp.Action.Status, resp.Action.Result))
-		}
+	namespaces, err := loadNamespaceConfig(actionTriggeredEvent.Project, a.configServiceURL)
+	if err != nil {
+		a.keptnHandler.Logger.Error(err.Error())
+		return err
+	}
+	stageTarget := helm.NewStageTarget(actionTriggeredEvent.Project, actionTriggeredEvent.Stage, namespaces)
 
-		// Send action.finished event
-		if sendErr := a.sendEvent(ce, keptn.ActionFinishedEventType, resp); sendErr != nil {
-			a.keptnHandler.Logger.Error(sendErr.Error())
-			return errors.New(sendErr.Error())
-		}
+	// Send action.started event
+	if sendErr := a.sendEvent(ce, keptn.ActionStartedEventType, a.getActionStartedEvent(actionTriggeredEvent)); sendErr != nil {
+		a.keptnHandler.Logger.Error(sendErr.Error())
+		return errors.New(sendErr.Error())
+	}
+
+	resp := handleAction(actionTriggeredEvent, stageTarget)
+	if resp.Action.Status == keptn.ActionStatusErrored {
+		a.keptnHandler.Logger.Error(fmt.Sprintf("action %s failed with result %s", actionTriggeredEvent.Action.Action, resp.Action.Result))
 	} else {
-		a.keptnHandler.Logger.Info("Received unhandled action: " + actionTriggeredEvent.Action.Action + ". Exiting")
-		return nil
+		a.keptnHandler.Logger.Info(fmt.Sprintf("Finished action with status %s and result %s", resp.Action.Status, resp.Action.Result))
+	}
+
+	// Send action.finished event
+	if sendErr := a.sendEvent(ce, keptn.ActionFinishedEventType, resp); sendErr != nil {
+		a.keptnHandler.Logger.Error(sendErr.Error())
+		return errors.New(sendErr.Error())
 	}
 
 	return nil
@@ -111,7 +144,7 @@ func (a *ActionTriggeredHandler) getActionStartedEvent(actionTriggeredEvent kept
 	}
 }
 
-func (a *ActionTriggeredHandler) handleScaling(actionTriggeredEvent keptn.ActionTriggeredEventData) keptn.ActionFinishedEventData {
+func (a *ActionTriggeredHandler) handleScaling(actionTriggeredEvent keptn.ActionTriggeredEventData, stageTarget helm.StageTarget) keptn.ActionFinishedEventData {
 
 	value, ok := actionTriggeredEvent.Action.Value.(string)
 	if !ok {
@@ -146,7 +179,7 @@ func (a *ActionTriggeredHandler) handleScaling(actionTriggeredEvent keptn.Action
 
 	// Upgrade chart
 	a.keptnHandler.Logger.Info(fmt.Sprintf("Start upgrading chart %s of stage %s", helmChartName, actionTriggeredEvent.Stage))
-	if err := a.upgradeChart(ch, actionTriggeredEvent, deploymentStrategy); err != nil {
+	if err := a.upgradeChart(ch, actionTriggeredEvent, stageTarget, deploymentStrategy); err != nil {
 		return a.getActionFinishedEvent(keptn.ActionResultType(err.Error()), keptn.ActionStatusErrored, actionTriggeredEvent)
 	}
 	a.keptnHandler.Logger.Info(fmt.Sprintf("Finished upgrading chart %s of stage %s", helmChartName, actionTriggeredEvent.Stage))
@@ -165,13 +198,113 @@ func (a *ActionTriggeredHandler) handleScaling(actionTriggeredEvent keptn.Action
 	return a.getActionFinishedEvent(keptn.ActionResultPass, keptn.ActionStatusSucceeded, actionTriggeredEvent)
 }
 
-func (a *ActionTriggeredHandler) upgradeChart(ch *chart.Chart, action keptn.ActionTriggeredEventData, strategy keptn.DeploymentStrategy) error {
+func (a *ActionTriggeredHandler) handlePatchValues(actionTriggeredEvent keptn.ActionTriggeredEventData, stageTarget helm.StageTarget) keptn.ActionFinishedEventData {
+
+	patch, ok := actionTriggeredEvent.Action.Value.(map[string]interface{})
+	if !ok {
+		return a.getActionFinishedEvent("could not parse action.value to a map of values",
+			keptn.ActionStatusErrored, actionTriggeredEvent)
+	}
+
+	// Get deployment strategy from the generated chart
+	genChart, err := keptnutils.GetChart(actionTriggeredEvent.Project, actionTriggeredEvent.Service, actionTriggeredEvent.Stage,
+		helm.GetChartName(actionTriggeredEvent.Service, true), a.configServiceURL)
+	if err != nil {
+		return a.getActionFinishedEvent(keptn.ActionResultType(err.Error()), keptn.ActionStatusErrored, actionTriggeredEvent)
+	}
+	deploymentStrategy, err := getDeploymentStrategyOfService(genChart)
+	if err != nil {
+		return a.getActionFinishedEvent(keptn.ActionResultType(err.Error()), keptn.ActionStatusErrored, actionTriggeredEvent)
+	}
+
+	// Get user chart
+	helmChartName := helm.GetChartName(actionTriggeredEvent.Service, false)
+	a.keptnHandler.Logger.Info(fmt.Sprintf("Retrieve chart %s of stage %s", helmChartName, actionTriggeredEvent.Stage))
+
+	ch, err := keptnutils.GetChart(actionTriggeredEvent.Project, actionTriggeredEvent.Service, actionTriggeredEvent.Stage, helmChartName, a.configServiceURL)
+	if err != nil {
+		return a.getActionFinishedEvent(keptn.ActionResultType(err.Error()), keptn.ActionStatusErrored, actionTriggeredEvent)
+	}
+
+	// Edit chart
+	a.keptnHandler.Logger.Info(fmt.Sprintf("Patch values of chart %s of stage %s", helmChartName, actionTriggeredEvent.Stage))
+	if ch.Values == nil {
+		ch.Values = make(map[string]interface{})
+	}
+	mergeValues(ch.Values, patch)
+
+	// Upgrade chart
+	a.keptnHandler.Logger.Info(fmt.Sprintf("Start upgrading chart %s of stage %s", helmChartName, actionTriggeredEvent.Stage))
+	if err := a.upgradeChart(ch, actionTriggeredEvent, stageTarget, deploymentStrategy); err != nil {
+		return a.getActionFinishedEvent(keptn.ActionResultType(err.Error()), keptn.ActionStatusErrored, actionTriggeredEvent)
+	}
+
+	// Store chart
+	a.keptnHandler.Logger.Info(fmt.Sprintf("Store chart %s of stage %s", helmChartName, actionTriggeredEvent.Stage))
+	chartData, err := keptnutils.PackageChart(ch)
+	if err != nil {
+		return a.getActionFinishedEvent(keptn.ActionResultType(err.Error()), keptn.ActionStatusErrored, actionTriggeredEvent)
+	}
+	if err := keptnutils.StoreChart(actionTriggeredEvent.Project, actionTriggeredEvent.Service, actionTriggeredEvent.Stage,
+		helmChartName, chartData, a.configServiceURL); err != nil {
+		return a.getActionFinishedEvent(keptn.ActionResultType(err.Error()), keptn.ActionStatusErrored, actionTriggeredEvent)
+	}
+
+	if deploymentStrategy == keptn.Duplicate {
+		// The user chart serves the canary, hence, the patched values have to be promoted to the primary
+		a.keptnHandler.Logger.Info(fmt.Sprintf("Promote patched chart %s of stage %s", helmChartName, actionTriggeredEvent.Stage))
+		configChanger := NewConfigurationChanger(a.mesh, a.keptnHandler, a.configServiceURL)
+		configChanger.helmExecutor = a.helmExecutor
+		e := &keptn.ConfigurationChangeEventData{
+			Project: actionTriggeredEvent.Project,
+			Service: actionTriggeredEvent.Service,
+			Stage:   actionTriggeredEvent.Stage,
+			Canary:  &keptn.Canary{Action: keptn.Promote},
+		}
+		if err := configChanger.changeCanary(e, stageTarget, deploymentStrategy); err != nil {
+			return a.getActionFinishedEvent(keptn.ActionResultType(err.Error()), keptn.ActionStatusErrored, actionTriggeredEvent)
+		}
+	}
+	a.keptnHandler.Logger.Info(fmt.Sprintf("Finished upgrading chart %s of stage %s", helmChartName, actionTriggeredEvent.Stage))
+
+	return a.getActionFinishedEvent(keptn.ActionResultPass, keptn.ActionStatusSucceeded, actionTriggeredEvent)
+}
+
+// mergeValues deep-merges the patch into the provided values. Nested maps are merged recursively,
+// all other values of the patch overwrite the existing ones.
+func mergeValues(values map[string]interface{}, patch map[string]interface{}) {
+	for k, v := range patch {
+		patchMap, isPatchMap := v.(map[string]interface{})
+		valuesMap, isValuesMap := values[k].(map[string]interface{})
+		if isPatchMap && isValuesMap {
+			mergeValues(valuesMap, patchMap)
+		} else {
+			values[k] = v
+		}
+	}
+}
+
+func (a *ActionTriggeredHandler) upgradeChart(ch *chart.Chart, action keptn.ActionTriggeredEventData, stageTarget helm.StageTarget,
+	strategy keptn.DeploymentStrategy) error {
 	generated := strings.HasSuffix(ch.Name(), "-generated")
+	opts, err := getUpgradeOptions(action.Project, action.Stage, a.configServiceURL)
+	if err != nil {
+		return err
+	}
+	vals, err := getChartValues(action.Project, action.Stage, action.Service,
+		getDeploymentName(strategy, generated), generated, a.configServiceURL)
+	if err != nil {
+		return err
+	}
+	if !generated {
+		if opts.PostRenderer, err = getUserChartPostRenderer(a.mesh, action.Project, action.Stage,
+			action.Service, strategy, a.configServiceURL); err != nil {
+			return err
+		}
+	}
 	return a.helmExecutor.UpgradeChart(ch,
 		helm.GetReleaseName(action.Project, action.Stage, action.Service, generated),
-		action.Project+"-"+action.Stage,
-		getKeptnValues(action.Project, action.Stage, action.Service,
-			getDeploymentName(strategy, generated)))
+		stageTarget, vals, opts)
 }
 
 // increaseReplicaCount increases the replica count in the deployments by the provided replicaIncrement
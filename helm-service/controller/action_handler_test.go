@@ -13,6 +13,7 @@ import (
 	"github.com/keptn/go-utils/pkg/api/models"
 	keptnevents "github.com/keptn/go-utils/pkg/lib"
 	"github.com/keptn/keptn/helm-service/controller/helm"
+	"github.com/keptn/keptn/helm-service/controller/mesh"
 	keptnutils "github.com/keptn/kubernetes-utils/pkg"
 
 	"helm.sh/helm/v3/pkg/chart"
@@ -168,6 +169,10 @@ func mockChartResourceEndpoints() *httptest.Server {
 				}
 				data, _ := json.Marshal(resp)
 				w.Write(data)
+			} else {
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(404)
+				w.Write([]byte(`{"code": 404, "message": "Resource not found"}`))
 			}
 		}),
 	)
@@ -249,15 +254,246 @@ func TestHandleScaling(t *testing.T) {
 			keptnHandler, _ := keptnevents.NewKeptn(&ce, keptnevents.KeptnOpts{})
 
 			a := &ActionTriggeredHandler{
+				mesh:             mesh.NewIstioMesh(),
 				helmExecutor:     helm.NewHelmMockExecutor(),
 				keptnHandler:     keptnHandler,
 				configServiceURL: ts.URL,
 			}
 
-			resp := a.handleScaling(tt.actionTriggeredEvent)
+			resp := a.handleScaling(tt.actionTriggeredEvent, helm.NewStageTarget("sockshop", "production", nil))
 			if !reflect.DeepEqual(resp, tt.wanted) {
 				t.Error("unexpected action.finished response")
 			}
 		})
 	}
 }
+
+func TestMergeValues(t *testing.T) {
+
+	values := map[string]interface{}{
+		"image":    "docker.io/keptnexamples/carts:0.8.1",
+		"replicas": 1,
+		"featureX": map[string]interface{}{
+			"enabled": true,
+			"timeout": 10,
+		},
+	}
+	patch := map[string]interface{}{
+		"featureX": map[string]interface{}{
+			"enabled": false,
+		},
+		"circuitBreaker": map[string]interface{}{
+			"open": true,
+		},
+	}
+	expected := map[string]interface{}{
+		"image":    "docker.io/keptnexamples/carts:0.8.1",
+		"replicas": 1,
+		"featureX": map[string]interface{}{
+			"enabled": false,
+			"timeout": 10,
+		},
+		"circuitBreaker": map[string]interface{}{
+			"open": true,
+		},
+	}
+
+	mergeValues(values, patch)
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("merged values %v do not match expected values %v", values, expected)
+	}
+}
+
+func mockPatchValuesEndpoints(storedCharts map[string]*chart.Chart) *httptest.Server {
+
+	return httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			if r.Method == http.MethodGet &&
+				strings.Contains(r.RequestURI, "/v1/project/sockshop/stage/dev/service/carts/resource/helm%2Fcarts") {
+				defer r.Body.Close()
+
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(200)
+
+				var ch chart.Chart
+				if strings.Contains(r.RequestURI, "carts-generated.tgz") {
+					ch = chart.Chart{
+						Metadata: &chart.Metadata{
+							Name:       "carts-generated",
+							Version:    "0.1.0",
+							Keywords:   []string{"deployment_strategy=" + keptnevents.Direct.String()},
+							APIVersion: "v2",
+						},
+					}
+				} else {
+					ch = chart.Chart{
+						Metadata: &chart.Metadata{
+							Name:       "carts",
+							Version:    "0.1.0",
+							APIVersion: "v2",
+						},
+						Templates: []*chart.File{
+							{
+								Name: "templates/deployment.yaml",
+								Data: []byte(helm.GeneratedPrimaryDeployment),
+							},
+						},
+						Values: map[string]interface{}{
+							"featureX": map[string]interface{}{
+								"enabled": true,
+							},
+						},
+						Raw: []*chart.File{
+							{
+								Name: "values.yaml",
+								Data: []byte("featureX:\n  enabled: true\n"),
+							},
+						},
+					}
+				}
+				chPackage, _ := keptnutils.PackageChart(&ch)
+
+				resp := models.Resource{
+					ResourceContent: base64.StdEncoding.EncodeToString(chPackage),
+				}
+				data, _ := json.Marshal(resp)
+				w.Write(data)
+			} else if r.Method == http.MethodGet && r.URL.Path == "/v1/project/sockshop/stage/dev/service/carts/resource/" {
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(200)
+				w.Write([]byte(`{"resources": []}`))
+			} else if r.Method == http.MethodPost &&
+				strings.Contains(r.RequestURI, "v1/project/sockshop/stage/dev/service/carts/resource") {
+				defer r.Body.Close()
+
+				resources := models.Resources{}
+				_ = json.NewDecoder(r.Body).Decode(&resources)
+				for _, resource := range resources.Resources {
+					chPackage, _ := base64.StdEncoding.DecodeString(resource.ResourceContent)
+					ch, err := keptnutils.LoadChart(chPackage)
+					if err == nil {
+						storedCharts[*resource.ResourceURI] = ch
+					}
+				}
+
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(200)
+
+				resp := models.Version{
+					Version: "123-456",
+				}
+				data, _ := json.Marshal(resp)
+				w.Write(data)
+			} else {
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(404)
+				w.Write([]byte(`{"code": 404, "message": "Resource not found"}`))
+			}
+		}),
+	)
+}
+
+func TestHandlePatchValues(t *testing.T) {
+
+	tests := []struct {
+		name                 string
+		actionTriggeredEvent keptnevents.ActionTriggeredEventData
+		wanted               keptnevents.ActionFinishedEventData
+		wantedValues         map[string]interface{}
+	}{
+		{
+			name: "validAction",
+			actionTriggeredEvent: keptnevents.ActionTriggeredEventData{
+				Project: "sockshop",
+				Service: "carts",
+				Stage:   "dev",
+				Action: keptnevents.ActionInfo{
+					Name:        "my-toggle-action",
+					Action:      "patch-values",
+					Description: "this is a unit test",
+					Value: map[string]interface{}{
+						"featureX": map[string]interface{}{
+							"enabled": false,
+						},
+					},
+				},
+			},
+			wanted: keptnevents.ActionFinishedEventData{
+				Project: "sockshop",
+				Service: "carts",
+				Stage:   "dev",
+				Action: keptnevents.ActionResult{
+					Result: "pass",
+					Status: keptnevents.ActionStatusSucceeded,
+				},
+			},
+			wantedValues: map[string]interface{}{
+				"featureX": map[string]interface{}{
+					"enabled": false,
+				},
+			},
+		},
+		{
+			name: "invalidAction",
+			actionTriggeredEvent: keptnevents.ActionTriggeredEventData{
+				Project: "sockshop",
+				Service: "carts",
+				Stage:   "dev",
+				Action: keptnevents.ActionInfo{
+					Name:        "my-toggle-action",
+					Action:      "patch-values",
+					Description: "this is a unit test",
+					Value:       "featureX.enabled=false",
+				},
+			},
+			wanted: keptnevents.ActionFinishedEventData{
+				Project: "sockshop",
+				Service: "carts",
+				Stage:   "dev",
+				Action: keptnevents.ActionResult{
+					Result: "could not parse action.value to a map of values",
+					Status: keptnevents.ActionStatusErrored,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storedCharts := make(map[string]*chart.Chart)
+			ts := mockPatchValuesEndpoints(storedCharts)
+			defer ts.Close()
+
+			ce := cloudevents.New("0.2")
+			dataBytes, err := json.Marshal(tt.actionTriggeredEvent)
+			if err != nil {
+				t.Error(err)
+			}
+			ce.Data = dataBytes
+
+			keptnHandler, _ := keptnevents.NewKeptn(&ce, keptnevents.KeptnOpts{})
+
+			a := &ActionTriggeredHandler{
+				mesh:             mesh.NewIstioMesh(),
+				helmExecutor:     helm.NewHelmMockExecutor(),
+				keptnHandler:     keptnHandler,
+				configServiceURL: ts.URL,
+			}
+
+			resp := a.handlePatchValues(tt.actionTriggeredEvent, helm.NewStageTarget("sockshop", "production", nil))
+			if !reflect.DeepEqual(resp, tt.wanted) {
+				t.Errorf("unexpected action.finished response: %v", resp)
+			}
+			if tt.wantedValues != nil {
+				storedChart, ok := storedCharts["helm/carts.tgz"]
+				if !ok {
+					t.Fatal("patched chart was not stored")
+				}
+				if !reflect.DeepEqual(storedChart.Values, tt.wantedValues) {
+					t.Errorf("stored values %v do not match expected values %v", storedChart.Values, tt.wantedValues)
+				}
+			}
+		})
+	}
+}
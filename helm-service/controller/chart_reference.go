@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+	keptnutils "github.com/keptn/kubernetes-utils/pkg"
+
+	"github.com/keptn/keptn/helm-service/controller/chartrepo"
+	"github.com/keptn/keptn/helm-service/controller/helm"
+)
+
+// chartReferenceChange is an optional field of the configuration change event, which replaces the chart
+// provided by the user with a chart of a chart repository
+type chartReferenceChange struct {
+	ChartRef *chartrepo.Reference `json:"chartRef,omitempty"`
+}
+
+// getChartCacheDir returns the directory the pulled charts are cached in
+func getChartCacheDir() string {
+	if dir := os.Getenv("CHART_CACHE_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "helm-service", "charts")
+}
+
+// changeChartReference pulls the referenced chart and stores it as chart of the service in the stage together with
+// the resolved reference. If the repository or the chart name is missing, it is taken from the stored reference.
+func (c *ConfigurationChanger) changeChartReference(e *keptnevents.ConfigurationChangeEventData,
+	ref chartrepo.Reference) error {
+
+	stored, err := chartrepo.GetReference(e.Project, e.Stage, e.Service, c.configServiceURL)
+	if err != nil {
+		return err
+	}
+	chartrepo.Complete(&ref, stored)
+
+	c.keptnHandler.Logger.Info(fmt.Sprintf("Pulling chart %s in version %s from chart repository %s for service %s in stage %s",
+		ref.Name, ref.Version, ref.RepoURL, e.Service, e.Stage))
+	chartData, resolved, err := c.chartPuller.Pull(ref)
+	if err != nil {
+		return err
+	}
+	if _, err := keptnutils.LoadChart(chartData); err != nil {
+		return fmt.Errorf("error when loading chart %s in version %s: %v", ref.Name, resolved.ResolvedVersion, err)
+	}
+
+	if err := keptnutils.StoreChart(e.Project, e.Service, e.Stage, helm.GetChartName(e.Service, false),
+		chartData, c.configServiceURL); err != nil {
+		return err
+	}
+	if err := chartrepo.StoreReference(e.Project, e.Stage, e.Service, resolved, c.configServiceURL); err != nil {
+		return err
+	}
+	c.keptnHandler.Logger.Info(fmt.Sprintf("Stored chart %s in version %s for service %s in stage %s",
+		resolved.Name, resolved.ResolvedVersion, e.Service, e.Stage))
+	return nil
+}
@@ -0,0 +1,166 @@
+package chartrepo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+// Reference references a chart in a Helm chart repository
+type Reference struct {
+	// RepoURL is the URL of the chart repository. Supported schemes are http, https and file.
+	RepoURL string `json:"repoURL"`
+	// Name is the name of the chart in the repository
+	Name string `json:"name"`
+	// Version is the version or a semantic version constraint of the chart. The latest stable version is used if empty.
+	Version string `json:"version,omitempty"`
+	// ResolvedVersion is the version of the chart which was pulled
+	ResolvedVersion string `json:"resolvedVersion,omitempty"`
+	// Digest is the SHA256 digest of the pulled chart archive
+	Digest string `json:"digest,omitempty"`
+}
+
+// Validate checks whether the reference contains the repository and the chart name
+func (r Reference) Validate() error {
+	if r.RepoURL == "" {
+		return errors.New("chart reference does not contain a repository URL")
+	}
+	if r.Name == "" {
+		return errors.New("chart reference does not contain a chart name")
+	}
+	return nil
+}
+
+// Puller pulls charts from chart repositories and caches the pulled chart archives
+type Puller struct {
+	cacheDir string
+}
+
+// NewPuller creates a new Puller caching the chart archives in the provided directory
+func NewPuller(cacheDir string) *Puller {
+	return &Puller{cacheDir: cacheDir}
+}
+
+// Pull resolves the version of the referenced chart using the index of the repository and returns the chart archive.
+// The returned reference contains the resolved version and the digest of the archive.
+func (p *Puller) Pull(ref Reference) ([]byte, *Reference, error) {
+
+	if err := ref.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	indexData, err := fetch(ref.RepoURL, "index.yaml")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error when fetching index of chart repository %s: %v", ref.RepoURL, err)
+	}
+	index, err := loadIndex(indexData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error when loading index of chart repository %s: %v", ref.RepoURL, err)
+	}
+	chartVersion, err := index.Get(ref.Name, ref.Version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error when resolving chart %s in version %s: %v", ref.Name, ref.Version, err)
+	}
+	if len(chartVersion.URLs) == 0 {
+		return nil, nil, fmt.Errorf("chart %s in version %s has no downloadable archive", ref.Name, chartVersion.Version)
+	}
+
+	resolved := ref
+	resolved.ResolvedVersion = chartVersion.Version
+
+	cachePath := p.getCachePath(resolved)
+	if data, err := ioutil.ReadFile(cachePath); err == nil && matchesDigest(data, chartVersion.Digest) {
+		resolved.Digest = digest(data)
+		return data, &resolved, nil
+	}
+
+	data, err := fetch(ref.RepoURL, chartVersion.URLs[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("error when fetching chart %s in version %s: %v", ref.Name, chartVersion.Version, err)
+	}
+	if !matchesDigest(data, chartVersion.Digest) {
+		return nil, nil, fmt.Errorf("digest of chart %s in version %s does not match the index of the repository",
+			ref.Name, chartVersion.Version)
+	}
+	resolved.Digest = digest(data)
+
+	if err := p.cache(cachePath, data); err != nil {
+		return nil, nil, fmt.Errorf("error when caching chart %s in version %s: %v", ref.Name, chartVersion.Version, err)
+	}
+	return data, &resolved, nil
+}
+
+// getCachePath returns the path of the cached archive. Charts are cached per repository because
+// different repositories can contain different charts with the same name and version.
+func (p *Puller) getCachePath(ref Reference) string {
+	repoHash := sha256.Sum256([]byte(ref.RepoURL))
+	return filepath.Join(p.cacheDir, hex.EncodeToString(repoHash[:8]), ref.Name+"-"+ref.ResolvedVersion+".tgz")
+}
+
+func (p *Puller) cache(cachePath string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(cachePath, data, 0644)
+}
+
+// fetch reads the file referenced relative to the repository
+func fetch(repoURL string, ref string) ([]byte, error) {
+
+	href, err := repo.ResolveReferenceURL(repoURL, ref)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "file":
+		return ioutil.ReadFile(u.Path)
+	case "http", "https":
+		g, err := getter.NewHTTPGetter()
+		if err != nil {
+			return nil, err
+		}
+		buf, err := g.Get(href)
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported scheme %s of chart repository", u.Scheme)
+	}
+}
+
+func loadIndex(data []byte) (*repo.IndexFile, error) {
+	index := &repo.IndexFile{}
+	if err := yaml.Unmarshal(data, index); err != nil {
+		return nil, err
+	}
+	if index.APIVersion == "" {
+		return nil, repo.ErrNoAPIVersion
+	}
+	index.SortEntries()
+	return index, nil
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// matchesDigest checks the archive against the digest of the index. Indices without digests are accepted.
+func matchesDigest(data []byte, expected string) bool {
+	return expected == "" || digest(data) == expected
+}
@@ -0,0 +1,127 @@
+package chartrepo
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+// createRepository creates a chart repository in a temporary directory containing the provided versions of the chart carts
+func createRepository(t *testing.T, baseURL func(dir string) string, versions ...string) string {
+
+	dir, err := ioutil.TempDir("", "chartrepo")
+	assert.NoError(t, err)
+
+	for _, version := range versions {
+		ch := &chart.Chart{
+			Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "carts", Version: version},
+		}
+		_, err := chartutil.Save(ch, dir)
+		assert.NoError(t, err)
+	}
+
+	index, err := repo.IndexDirectory(dir, baseURL(dir))
+	assert.NoError(t, err)
+	assert.NoError(t, index.WriteFile(filepath.Join(dir, "index.yaml"), 0644))
+	return dir
+}
+
+func fileURL(dir string) string {
+	return "file://" + dir
+}
+
+func TestPullFromFileRepository(t *testing.T) {
+
+	dir := createRepository(t, fileURL, "0.1.0", "0.2.0", "1.0.0-rc1")
+	defer os.RemoveAll(dir)
+	cacheDir, err := ioutil.TempDir("", "chartcache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	tests := []struct {
+		name            string
+		version         string
+		expectedVersion string
+		expectError     bool
+	}{
+		{name: "latest stable version", version: "", expectedVersion: "0.2.0"},
+		{name: "exact version", version: "0.1.0", expectedVersion: "0.1.0"},
+		{name: "version constraint", version: "~0.1", expectedVersion: "0.1.0"},
+		{name: "unknown version", version: "2.0.0", expectError: true},
+	}
+
+	puller := NewPuller(cacheDir)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, ref, err := puller.Pull(Reference{RepoURL: fileURL(dir), Name: "carts", Version: tt.version})
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedVersion, ref.ResolvedVersion)
+			assert.Equal(t, tt.version, ref.Version)
+			assert.Equal(t, digest(data), ref.Digest)
+
+			ch, err := loader.LoadArchive(bytes.NewReader(data))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedVersion, ch.Metadata.Version)
+		})
+	}
+}
+
+func TestPullUsesCache(t *testing.T) {
+
+	var dir string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.FileServer(http.Dir(dir)).ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	dir = createRepository(t, func(string) string { return server.URL }, "0.1.0")
+	defer os.RemoveAll(dir)
+	cacheDir, err := ioutil.TempDir("", "chartcache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	puller := NewPuller(cacheDir)
+	data, ref, err := puller.Pull(Reference{RepoURL: server.URL, Name: "carts"})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.1.0", ref.ResolvedVersion)
+
+	// The archive is removed from the repository, hence it can only be served from the cache
+	assert.NoError(t, os.Remove(filepath.Join(dir, "carts-0.1.0.tgz")))
+	cachedData, cachedRef, err := puller.Pull(Reference{RepoURL: server.URL, Name: "carts"})
+	assert.NoError(t, err)
+	assert.Equal(t, data, cachedData)
+	assert.Equal(t, ref, cachedRef)
+}
+
+func TestPullRejectsInvalidReference(t *testing.T) {
+
+	puller := NewPuller(os.TempDir())
+	_, _, err := puller.Pull(Reference{Name: "carts"})
+	assert.Error(t, err)
+	_, _, err = puller.Pull(Reference{RepoURL: "oci://registry.example.com/charts", Name: "carts"})
+	assert.Error(t, err)
+}
+
+func TestComplete(t *testing.T) {
+
+	ref := Reference{Version: "0.2.0"}
+	Complete(&ref, &Reference{RepoURL: "https://charts.example.com", Name: "carts", Version: "0.1.0", ResolvedVersion: "0.1.0"})
+	assert.Equal(t, Reference{RepoURL: "https://charts.example.com", Name: "carts", Version: "0.2.0"}, ref)
+
+	Complete(&ref, nil)
+	assert.Equal(t, Reference{RepoURL: "https://charts.example.com", Name: "carts", Version: "0.2.0"}, ref)
+}
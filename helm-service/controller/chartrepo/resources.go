@@ -0,0 +1,62 @@
+package chartrepo
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/keptn/go-utils/pkg/api/models"
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+)
+
+// ReferenceURI is the resource of the service containing the chart reference deployed in a stage
+const ReferenceURI = "chart-reference.yaml"
+
+// StoreReference stores the resolved chart reference as resource of the service in the stage
+func StoreReference(project string, stage string, service string, ref *Reference, configServiceURL string) error {
+
+	data, err := yaml.Marshal(ref)
+	if err != nil {
+		return err
+	}
+	uri := ReferenceURI
+	resource := models.Resource{ResourceURI: &uri, ResourceContent: string(data)}
+
+	rHandler := configutils.NewResourceHandler(configServiceURL)
+	if _, err := rHandler.CreateServiceResources(project, stage, service, []*models.Resource{&resource}); err != nil {
+		return fmt.Errorf("error when storing %s of service %s in stage %s: %v", ReferenceURI, service, stage, err)
+	}
+	return nil
+}
+
+// GetReference returns the chart reference of the service in the stage.
+// If the service was not onboarded from a chart repository, nil is returned.
+func GetReference(project string, stage string, service string, configServiceURL string) (*Reference, error) {
+
+	rHandler := configutils.NewResourceHandler(configServiceURL)
+	resource, err := rHandler.GetServiceResource(project, stage, service, ReferenceURI)
+	if err == configutils.ResourceNotFoundError {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error when reading %s of service %s in stage %s: %v", ReferenceURI, service, stage, err)
+	}
+
+	ref := &Reference{}
+	if err := yaml.Unmarshal([]byte(resource.ResourceContent), ref); err != nil {
+		return nil, fmt.Errorf("error when parsing %s of service %s in stage %s: %v", ReferenceURI, service, stage, err)
+	}
+	return ref, nil
+}
+
+// Complete fills the repository and the chart name of the reference with the stored reference,
+// which allows upgrading a service by providing the version only
+func Complete(ref *Reference, stored *Reference) {
+	if stored == nil {
+		return
+	}
+	if ref.RepoURL == "" {
+		ref.RepoURL = stored.RepoURL
+	}
+	if ref.Name == "" {
+		ref.Name = stored.Name
+	}
+}
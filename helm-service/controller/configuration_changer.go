@@ -4,12 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	cloudevents "github.com/cloudevents/sdk-go"
 	"github.com/ghodss/yaml"
 	keptnevents "github.com/keptn/go-utils/pkg/lib"
+	"github.com/keptn/keptn/helm-service/controller/chartrepo"
 	"github.com/keptn/keptn/helm-service/controller/helm"
+	"github.com/keptn/keptn/helm-service/controller/kustomize"
 	"github.com/keptn/keptn/helm-service/controller/mesh"
 	keptnutils "github.com/keptn/kubernetes-utils/pkg"
 	"helm.sh/helm/v3/pkg/chart"
@@ -22,6 +25,7 @@ type ConfigurationChanger struct {
 	keptnHandler          *keptnevents.Keptn
 	helmExecutor          helm.HelmExecutor
 	configServiceURL      string
+	chartPuller           *chartrepo.Puller
 }
 
 // NewConfigurationChanger creates a new ConfigurationChanger
@@ -34,9 +38,100 @@ func NewConfigurationChanger(mesh mesh.Mesh, keptnHandler *keptnevents.Keptn, co
 		keptnHandler:          keptnHandler,
 		helmExecutor:          helmExecutor,
 		configServiceURL:      configServiceURL,
+		chartPuller:           chartrepo.NewPuller(getChartCacheDir()),
 	}
 }
 
+// EnqueueConfigurationChange adds the configuration change to the queue of the service. A pending deployment of a
+// new artifact is superseded by the deployment of a newer artifact and reported as skipped.
+// A configuration change without a stage is queued for resolving its stage from the shipyard first, hence
+// the receiver does not wait for the configuration-service.
+func (c *ConfigurationChanger) EnqueueConfigurationChange(queue *ServiceQueue, ce cloudevents.Event, loggingDone chan bool) error {
+
+	e := &keptnevents.ConfigurationChangeEventData{}
+	if err := ce.DataAs(e); err != nil {
+		c.keptnHandler.Logger.Error(fmt.Sprintf("Got Data Error: %s", err.Error()))
+		loggingDone <- true
+		return err
+	}
+
+	refChange := &chartReferenceChange{}
+	if err := ce.DataAs(refChange); err != nil {
+		c.keptnHandler.Logger.Error(fmt.Sprintf("Got Data Error: %s", err.Error()))
+		loggingDone <- true
+		return err
+	}
+	supersedable := len(e.ValuesCanary) > 0 || refChange.ChartRef != nil
+
+	if os.Getenv("PRE_WORKFLOW_ENGINE") == "true" && e.Stage == "" {
+		resolve := func() {
+			stage, err := getFirstStage(c.keptnHandler)
+			if err != nil {
+				c.keptnHandler.Logger.Error(fmt.Sprintf("Error when reading shipyard: %s", err.Error()))
+				loggingDone <- true
+				return
+			}
+			e.Stage = stage
+			c.enqueueForStage(queue, ce, e, supersedable, loggingDone)
+		}
+		queue.Enqueue(e.Project, e.Stage, e.Service, resolve, nil)
+		return nil
+	}
+	c.enqueueForStage(queue, ce, e, supersedable, loggingDone)
+	return nil
+}
+
+// enqueueForStage adds the configuration change to the queue of the service in the stage of the change
+func (c *ConfigurationChanger) enqueueForStage(queue *ServiceQueue, ce cloudevents.Event,
+	e *keptnevents.ConfigurationChangeEventData, supersedable bool, loggingDone chan bool) {
+
+	run := func() { c.ChangeAndApplyConfiguration(ce, loggingDone) }
+	var skip func()
+	if supersedable {
+		skip = func() { c.skipConfigurationChange(ce, e, loggingDone) }
+	}
+	queue.Enqueue(e.Project, e.Stage, e.Service, run, skip)
+}
+
+// skipConfigurationChange reports the superseded configuration change as skipped
+func (c *ConfigurationChanger) skipConfigurationChange(ce cloudevents.Event, e *keptnevents.ConfigurationChangeEventData,
+	loggingDone chan bool) error {
+
+	defer func() { loggingDone <- true }()
+
+	c.keptnHandler.Logger.Info(fmt.Sprintf("Skip configuration change for service %s in stage %s of project %s "+
+		"because it was superseded by a newer one", e.Service, e.Stage, e.Project))
+	if os.Getenv("PRE_WORKFLOW_ENGINE") != "true" {
+		return nil
+	}
+
+	keptnHandler, err := keptnevents.NewKeptn(&ce, keptnevents.KeptnOpts{})
+	if err != nil {
+		c.keptnHandler.Logger.Error("Could not initialize keptn handler: " + err.Error())
+		return err
+	}
+	keptnHandler.KeptnBase.Stage = e.Stage
+
+	namespaces, err := loadNamespaceConfig(e.Project, c.configServiceURL)
+	if err != nil {
+		c.keptnHandler.Logger.Error(err.Error())
+		return err
+	}
+	stageTarget := helm.NewStageTarget(e.Project, e.Stage, namespaces)
+
+	genChart, err := c.getGeneratedChart(e)
+	if err != nil {
+		c.keptnHandler.Logger.Error(err.Error())
+		return err
+	}
+	deploymentStrategy, err := getDeploymentStrategyOfService(genChart)
+	if err != nil {
+		c.keptnHandler.Logger.Error(err.Error())
+		return err
+	}
+	return c.sendDeploymentFinishedEvent(keptnHandler, e, stageTarget, deploymentStrategy, "", errDeploymentSuperseded)
+}
+
 // ChangeAndApplyConfiguration changes the configuration and applies it in the cluster
 func (c *ConfigurationChanger) ChangeAndApplyConfiguration(ce cloudevents.Event, loggingDone chan bool) error {
 
@@ -63,6 +158,13 @@ func (c *ConfigurationChanger) ChangeAndApplyConfiguration(ce cloudevents.Event,
 		e.Stage = stage
 	}
 
+	namespaces, err := loadNamespaceConfig(e.Project, c.configServiceURL)
+	if err != nil {
+		c.keptnHandler.Logger.Error(err.Error())
+		return err
+	}
+	stageTarget := helm.NewStageTarget(e.Project, e.Stage, namespaces)
+
 	genChart, err := c.getGeneratedChart(e)
 	if err != nil {
 		c.keptnHandler.Logger.Error(err.Error())
@@ -74,12 +176,49 @@ func (c *ConfigurationChanger) ChangeAndApplyConfiguration(ce cloudevents.Event,
 		return err
 	}
 
-	if len(e.ValuesCanary) > 0 {
-		err := c.applyValuesCanary(e, genChart, deploymentStrategy)
+	options := &configurationChangeOptions{}
+	if err := ce.DataAs(options); err != nil {
+		c.keptnHandler.Logger.Error(fmt.Sprintf("Got Data Error: %s", err.Error()))
+		return err
+	}
+	diffURI := ""
+	if options.Diff || options.DiffOnly {
+		diff, err := c.previewConfigurationChange(e, stageTarget, deploymentStrategy)
 		if err != nil {
 			c.keptnHandler.Logger.Error(err.Error())
 			return err
 		}
+		if err := c.storeDeploymentDiff(e, diff); err != nil {
+			c.keptnHandler.Logger.Error(err.Error())
+			return err
+		}
+		diffURI = deploymentDiffURI
+		if options.DiffOnly {
+			c.keptnHandler.Logger.Info(fmt.Sprintf("Stored diff of configuration change for service %s in stage %s of project %s without applying it",
+				e.Service, e.Stage, e.Project))
+			return nil
+		}
+	}
+
+	refChange := &chartReferenceChange{}
+	if err := ce.DataAs(refChange); err != nil {
+		c.keptnHandler.Logger.Error(fmt.Sprintf("Got Data Error: %s", err.Error()))
+		return err
+	}
+	if refChange.ChartRef != nil {
+		if err := c.changeChartReference(e, *refChange.ChartRef); err != nil {
+			c.keptnHandler.Logger.Error(err.Error())
+			return c.sendFailedDeploymentFinishedEvent(keptnHandler, e, stageTarget, deploymentStrategy, diffURI, err)
+		}
+	}
+
+	// A changed chart reference is deployed like changed values
+	if len(e.ValuesCanary) > 0 || refChange.ChartRef != nil {
+		err := c.applyValuesCanary(e, stageTarget, genChart, deploymentStrategy)
+		if err != nil {
+			c.keptnHandler.Logger.Error(err.Error())
+			return c.sendFailedDeploymentFinishedEvent(keptnHandler, e, stageTarget, deploymentStrategy, diffURI, err)
+		}
 	}
 
 	if len(e.FileChangesUserChart) > 0 {
@@ -88,9 +227,9 @@ func (c *ConfigurationChanger) ChangeAndApplyConfiguration(ce cloudevents.Event,
 			c.keptnHandler.Logger.Error(err.Error())
 			return err
 		}
-		if err := c.upgradeChart(ch, *e, deploymentStrategy); err != nil {
+		if err := c.upgradeChart(ch, *e, stageTarget, deploymentStrategy); err != nil {
 			c.keptnHandler.Logger.Error(err.Error())
-			return err
+			return c.sendFailedDeploymentFinishedEvent(keptnHandler, e, stageTarget, deploymentStrategy, diffURI, err)
 		}
 	}
 
@@ -100,14 +239,14 @@ func (c *ConfigurationChanger) ChangeAndApplyConfiguration(ce cloudevents.Event,
 			c.keptnHandler.Logger.Error(err.Error())
 			return err
 		}
-		if err := c.upgradeChart(ch, *e, deploymentStrategy); err != nil {
+		if err := c.upgradeChart(ch, *e, stageTarget, deploymentStrategy); err != nil {
 			c.keptnHandler.Logger.Error(err.Error())
-			return err
+			return c.sendFailedDeploymentFinishedEvent(keptnHandler, e, stageTarget, deploymentStrategy, diffURI, err)
 		}
 	}
 
 	if len(e.FileChangesUmbrellaChart) > 0 {
-		if err := c.updateUmbrellaChart(e); err != nil {
+		if err := c.updateUmbrellaChart(e, stageTarget); err != nil {
 			c.keptnHandler.Logger.Error(err.Error())
 		}
 	}
@@ -120,7 +259,14 @@ func (c *ConfigurationChanger) ChangeAndApplyConfiguration(ce cloudevents.Event,
 		if deploymentStrategy == keptnevents.Duplicate {
 			c.keptnHandler.Logger.Debug(fmt.Sprintf("Apply canary action %s for service %s in stage %s of project %s", e.Canary.Action, e.Service, e.Stage, e.Project))
 
-			if err := c.changeCanary(e, deploymentStrategy); err != nil {
+			if e.Canary.Action == keptnevents.Set {
+				if err := c.applyProgressiveRollout(e); err != nil {
+					c.keptnHandler.Logger.Error(err.Error())
+					return err
+				}
+			}
+
+			if err := c.changeCanary(e, stageTarget, deploymentStrategy); err != nil {
 				c.keptnHandler.Logger.Error(err.Error())
 				return err
 			}
@@ -135,42 +281,108 @@ func (c *ConfigurationChanger) ChangeAndApplyConfiguration(ce cloudevents.Event,
 		}
 	}
 
+	// A new artifact or user chart has to pass the readiness checks before tests are executed against it
+	if len(e.ValuesCanary) > 0 || refChange.ChartRef != nil || len(e.FileChangesUserChart) > 0 {
+		if err := c.runReadinessChecks(keptnHandler, e, stageTarget, deploymentStrategy); err != nil {
+			c.keptnHandler.Logger.Error(err.Error())
+			return c.sendFailedDeploymentFinishedEvent(keptnHandler, e, stageTarget, deploymentStrategy, diffURI, err)
+		}
+	}
+
 	// Send deployment finished event
 	// Note that this condition also stops the keptn-flow if an artifact is discarded
 	if os.Getenv("PRE_WORKFLOW_ENGINE") == "true" &&
 		!(e.Canary != nil && (e.Canary.Action == keptnevents.Discard || e.Canary.Action == keptnevents.Promote)) {
+		return c.sendDeploymentFinishedEvent(keptnHandler, e, stageTarget, deploymentStrategy, diffURI, nil)
+	}
 
-		testStrategy, err := getTestStrategy(keptnHandler, e.Stage)
-		if err != nil {
-			c.keptnHandler.Logger.Error(err.Error())
-			return err
-		}
+	return nil
+}
 
-		image := ""
-		tag := ""
-		labels := e.Labels
+// sendFailedDeploymentFinishedEvent reports the failed deployment so that no tests are executed against it.
+// The deployment error is returned in any case.
+func (c *ConfigurationChanger) sendFailedDeploymentFinishedEvent(keptnHandler *keptnevents.Keptn,
+	e *keptnevents.ConfigurationChangeEventData, stageTarget helm.StageTarget, deploymentStrategy keptnevents.DeploymentStrategy, diffURI string, deploymentErr error) error {
 
-		for k, v := range e.ValuesCanary {
-			if k == "image" {
-				splittedImage := strings.Split(v.(string), ":")
-				if len(splittedImage) > 0 {
-					image = splittedImage[0]
-					tag = splittedImage[1]
-				}
-			}
-		}
-		var shkeptncontext string
-		ce.Context.ExtensionAs("shkeptncontext", &shkeptncontext)
-		if err := sendDeploymentFinishedEvent(keptnHandler, testStrategy, deploymentStrategy, image, tag, labels, mesh.GetIngressHostnameSuffix(), mesh.GetIngressProtocol(), mesh.GetIngressPort()); err != nil {
-			c.keptnHandler.Logger.Error(fmt.Sprintf("Cannot send deployment finished event: %s", err.Error()))
-			return err
+	if os.Getenv("PRE_WORKFLOW_ENGINE") == "true" {
+		c.sendDeploymentFinishedEvent(keptnHandler, e, stageTarget, deploymentStrategy, diffURI, deploymentErr)
+	}
+	return deploymentErr
+}
+
+func (c *ConfigurationChanger) sendDeploymentFinishedEvent(keptnHandler *keptnevents.Keptn,
+	e *keptnevents.ConfigurationChangeEventData, stageTarget helm.StageTarget, deploymentStrategy keptnevents.DeploymentStrategy, diffURI string, deploymentErr error) error {
+
+	testStrategy, err := getTestStrategy(keptnHandler, e.Stage)
+	if err != nil {
+		c.keptnHandler.Logger.Error(err.Error())
+		return err
+	}
+
+	image := ""
+	tag := ""
+	imageDigest := ""
+	labels := e.Labels
+
+	if imageRef, ok := e.ValuesCanary["image"].(string); ok {
+		image, tag, imageDigest = splitImage(imageRef)
+		if imageDigest == "" && deploymentErr == nil {
+			imageDigest = c.getImageDigest(e, stageTarget, imageRef)
 		}
 	}
+	if err := sendDeploymentFinishedEvent(keptnHandler, stageTarget.Namespace, testStrategy, deploymentStrategy, image, tag, imageDigest, labels,
+		mesh.GetIngressHostnameSuffix(), mesh.GetIngressProtocol(), mesh.GetIngressPort(), diffURI, deploymentErr); err != nil {
+		c.keptnHandler.Logger.Error(fmt.Sprintf("Cannot send deployment finished event: %s", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// getImageDigest returns the digest of the image run by the pods of the user chart, which contains the deployed
+// artifact for all deployment strategies. The deployment is reported without digest if it cannot be resolved.
+func (c *ConfigurationChanger) getImageDigest(e *keptnevents.ConfigurationChangeEventData, stageTarget helm.StageTarget, image string) string {
+
+	digests, err := c.helmExecutor.GetImageDigests(helm.GetReleaseName(e.Project, e.Stage, e.Service, false), stageTarget)
+	if err != nil {
+		c.keptnHandler.Logger.Error(fmt.Sprintf("Error when resolving the digest of image %s: %v", image, err))
+		return ""
+	}
+	if digest, ok := digests[image]; ok {
+		return digest
+	}
+	c.keptnHandler.Logger.Info(fmt.Sprintf("No digest of image %s found in the pods of service %s", image, e.Service))
+	return ""
+}
+
+// applyProgressiveRollout starts with the first step of the progressive rollout if the stage defines one and
+// a new artifact is deployed. The canary weight of the current step is added to the labels, which allows the
+// gatekeeper-service to continue with the next step after a successful evaluation.
+func (c *ConfigurationChanger) applyProgressiveRollout(e *keptnevents.ConfigurationChangeEventData) error {
+
+	rollout, err := getProgressiveRollout(e.Project, e.Stage, c.configServiceURL)
+	if err != nil {
+		return err
+	}
+	if rollout == nil {
+		return nil
+	}
+
+	if len(e.ValuesCanary) > 0 {
+		e.Canary.Value = rollout.Steps[0]
+	}
+	c.keptnHandler.Logger.Info(fmt.Sprintf("Progressive rollout of service %s in stage %s of project %s continues with canary weight %d",
+		e.Service, e.Stage, e.Project, e.Canary.Value))
 
+	labels := make(map[string]string)
+	for k, v := range e.Labels {
+		labels[k] = v
+	}
+	labels[canaryWeightLabel] = strconv.Itoa(int(e.Canary.Value))
+	e.Labels = labels
 	return nil
 }
 
-func (c *ConfigurationChanger) updateUmbrellaChart(e *keptnevents.ConfigurationChangeEventData) error {
+func (c *ConfigurationChanger) updateUmbrellaChart(e *keptnevents.ConfigurationChangeEventData, stageTarget helm.StageTarget) error {
 
 	umbrellaChartHandler := helm.NewUmbrellaChartHandler(c.configServiceURL)
 
@@ -182,35 +394,37 @@ func (c *ConfigurationChanger) updateUmbrellaChart(e *keptnevents.ConfigurationC
 	if err != nil {
 		return fmt.Errorf("error when getting umbrella chart: %s", err)
 	}
-	if err := c.helmExecutor.UpgradeChart(ch, helm.GetUmbrellaReleaseName(e.Project, e.Stage),
-		helm.GetUmbrellaNamespace(e.Project, e.Stage), nil); err != nil {
+	opts, err := getUpgradeOptions(e.Project, e.Stage, c.configServiceURL)
+	if err != nil {
+		return err
+	}
+	if err := c.helmExecutor.UpgradeChart(ch, helm.GetUmbrellaReleaseName(e.Project, e.Stage), stageTarget, nil, opts); err != nil {
 		return fmt.Errorf("error when applying umbrella chart in stage %s: %s", e.Stage, err.Error())
 	}
 	return nil
 }
 
-func (c *ConfigurationChanger) applyValuesCanary(e *keptnevents.ConfigurationChangeEventData,
+func (c *ConfigurationChanger) applyValuesCanary(e *keptnevents.ConfigurationChangeEventData, stageTarget helm.StageTarget,
 	genChart *chart.Chart, deploymentStrategy keptnevents.DeploymentStrategy) error {
 	ch, err := c.updateChart(e, false, changeValue)
 	if err != nil {
 		return err
 	}
-	if err := c.upgradeChart(ch, *e, deploymentStrategy); err != nil {
+	if err := c.upgradeChart(ch, *e, stageTarget, deploymentStrategy); err != nil {
 		return err
 	}
 	onboarder := NewOnboarder(c.mesh, c.keptnHandler, c.configServiceURL)
 	if onboarder.IsGeneratedChartEmpty(genChart) {
-		userChartManifest, err := c.helmExecutor.GetManifest(helm.GetReleaseName(e.Project, e.Stage, e.Service, false),
-			e.Project+"-"+e.Stage)
+		userChartManifest, err := c.helmExecutor.GetManifest(helm.GetReleaseName(e.Project, e.Stage, e.Service, false), stageTarget)
 		if err != nil {
 			return err
 		}
-		genChart, err = onboarder.OnboardGeneratedService(userChartManifest, e.Project, e.Stage, e.Service, deploymentStrategy)
+		genChart, err = onboarder.OnboardGeneratedService(userChartManifest, e.Project, e.Stage, stageTarget.Namespace, e.Service, deploymentStrategy)
 		if err != nil {
 			return err
 		}
 		if deploymentStrategy == keptnevents.Direct {
-			if err := c.upgradeChart(genChart, *e, deploymentStrategy); err != nil {
+			if err := c.upgradeChart(genChart, *e, stageTarget, deploymentStrategy); err != nil {
 				return err
 			}
 		}
@@ -297,7 +511,7 @@ func (c *ConfigurationChanger) updateChart(e *keptnevents.ConfigurationChangeEve
 	helmChartName := helm.GetChartName(e.Service, generated)
 	c.keptnHandler.Logger.Info(fmt.Sprintf("Start updating chart %s of stage %s", helmChartName, e.Stage))
 	// Read chart
-	chart, err := keptnutils.GetChart(e.Project, e.Service, e.Stage, helmChartName, c.configServiceURL)
+	chart, err := c.getChart(e, generated)
 	if err != nil {
 		return nil, err
 	}
@@ -320,6 +534,30 @@ func (c *ConfigurationChanger) updateChart(e *keptnevents.ConfigurationChangeEve
 	return chart, nil
 }
 
+// getChart reads the chart of the service. A chart generated from a kustomization is rebuilt from the
+// kustomization stored in the stage, hence changes of the base or the overlay are applied with the next deployment.
+// Without a kustomization in the stage, the chart generated at onboarding is used.
+func (c *ConfigurationChanger) getChart(e *keptnevents.ConfigurationChangeEventData, generated bool) (*chart.Chart, error) {
+
+	ch, err := keptnutils.GetChart(e.Project, e.Service, e.Stage, helm.GetChartName(e.Service, generated), c.configServiceURL)
+	if err != nil || generated || !kustomize.IsKustomizeChart(ch) {
+		return ch, err
+	}
+
+	files, err := kustomize.GetStageResources(e.Project, e.Stage, e.Service, c.configServiceURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return ch, nil
+	}
+	manifest, err := kustomize.Build(files, kustomize.GetBuildPath(files, e.Stage))
+	if err != nil {
+		return nil, err
+	}
+	return kustomize.GenerateChart(e.Service, manifest, ch.Values)
+}
+
 func changeValue(e *keptnevents.ConfigurationChangeEventData, chart *chart.Chart) error {
 
 	// Change values
@@ -355,26 +593,52 @@ func (c *ConfigurationChanger) setCanaryWeight(e *keptnevents.ConfigurationChang
 }
 
 func (c *ConfigurationChanger) upgradeChart(ch *chart.Chart, configChange keptnevents.ConfigurationChangeEventData,
-	strategy keptnevents.DeploymentStrategy) error {
+	stageTarget helm.StageTarget, strategy keptnevents.DeploymentStrategy) error {
 	generated := strings.HasSuffix(ch.Name(), "-generated")
+	opts, err := getUpgradeOptions(configChange.Project, configChange.Stage, c.configServiceURL)
+	if err != nil {
+		return err
+	}
+	vals, err := getChartValues(configChange.Project, configChange.Stage, configChange.Service,
+		getDeploymentName(strategy, generated), generated, c.configServiceURL)
+	if err != nil {
+		return err
+	}
+	if !generated {
+		if opts.PostRenderer, err = getUserChartPostRenderer(c.mesh, configChange.Project, configChange.Stage,
+			configChange.Service, strategy, c.configServiceURL); err != nil {
+			return err
+		}
+	}
 	return c.helmExecutor.UpgradeChart(ch,
 		helm.GetReleaseName(configChange.Project, configChange.Stage, configChange.Service, generated),
-		configChange.Project+"-"+configChange.Stage,
-		getKeptnValues(configChange.Project, configChange.Stage, configChange.Service,
-			getDeploymentName(strategy, generated)))
+		stageTarget, vals, opts)
 }
 
 func (c *ConfigurationChanger) upgradeChartWithReplicas(ch *chart.Chart, configChange keptnevents.ConfigurationChangeEventData,
-	strategy keptnevents.DeploymentStrategy, replicas int) error {
+	stageTarget helm.StageTarget, strategy keptnevents.DeploymentStrategy, replicas int) error {
 	generated := strings.HasSuffix(ch.Name(), "-generated")
+	opts, err := getUpgradeOptions(configChange.Project, configChange.Stage, c.configServiceURL)
+	if err != nil {
+		return err
+	}
+	vals, err := getChartValues(configChange.Project, configChange.Stage, configChange.Service,
+		getDeploymentName(strategy, generated), generated, c.configServiceURL)
+	if err != nil {
+		return err
+	}
+	if !generated {
+		if opts.PostRenderer, err = getUserChartPostRenderer(c.mesh, configChange.Project, configChange.Stage,
+			configChange.Service, strategy, c.configServiceURL); err != nil {
+			return err
+		}
+	}
 	return c.helmExecutor.UpgradeChart(ch,
 		helm.GetReleaseName(configChange.Project, configChange.Stage, configChange.Service, generated),
-		configChange.Project+"-"+configChange.Stage,
-		addReplicas(getKeptnValues(configChange.Project, configChange.Stage, configChange.Service,
-			getDeploymentName(strategy, generated)), replicas))
+		stageTarget, addReplicas(vals, replicas), opts)
 }
 
-func (c *ConfigurationChanger) changeCanary(e *keptnevents.ConfigurationChangeEventData,
+func (c *ConfigurationChanger) changeCanary(e *keptnevents.ConfigurationChangeEventData, stageTarget helm.StageTarget,
 	deploymentStrategy keptnevents.DeploymentStrategy) error {
 
 	switch e.Canary.Action {
@@ -383,14 +647,17 @@ func (c *ConfigurationChanger) changeCanary(e *keptnevents.ConfigurationChangeEv
 		if err != nil {
 			return err
 		}
-		if err := c.upgradeChart(ch, *e, deploymentStrategy); err != nil {
+		if err := c.upgradeChart(ch, *e, stageTarget, deploymentStrategy); err != nil {
+			return err
+		}
+		if err := c.switchServiceSelectors(e, stageTarget, deploymentStrategy, 0); err != nil {
 			return err
 		}
 		userChart, err := keptnutils.GetChart(e.Project, e.Service, e.Stage, helm.GetChartName(e.Service, false), c.configServiceURL)
 		if err != nil {
 			return err
 		}
-		if err := c.upgradeChartWithReplicas(userChart, *e, deploymentStrategy, 0); err != nil {
+		if err := c.upgradeChartWithReplicas(userChart, *e, stageTarget, deploymentStrategy, 0); err != nil {
 			return err
 		}
 
@@ -399,18 +666,20 @@ func (c *ConfigurationChanger) changeCanary(e *keptnevents.ConfigurationChangeEv
 		if err != nil {
 			return err
 		}
-		if err := c.upgradeChart(ch, *e, deploymentStrategy); err != nil {
+		if err := c.upgradeChart(ch, *e, stageTarget, deploymentStrategy); err != nil {
+			return err
+		}
+		if err := c.switchServiceSelectors(e, stageTarget, deploymentStrategy, 100); err != nil {
 			return err
 		}
 
 		chartGenerator := helm.NewGeneratedChartHandler(c.mesh, c.keptnHandler.Logger)
-		userChartManifest, err := c.helmExecutor.GetManifest(helm.GetReleaseName(e.Project, e.Stage, e.Service, false),
-			e.Project+"-"+e.Stage)
+		userChartManifest, err := c.helmExecutor.GetManifest(helm.GetReleaseName(e.Project, e.Stage, e.Service, false), stageTarget)
 		if err != nil {
 			c.keptnHandler.Logger.Error(err.Error())
 			return err
 		}
-		genChart, err := chartGenerator.GenerateDuplicateManagedChart(userChartManifest, e.Project, e.Stage, e.Service)
+		genChart, err := chartGenerator.GenerateDuplicateManagedChart(userChartManifest, stageTarget.Namespace, e.Service)
 		if err != nil {
 			c.keptnHandler.Logger.Error(err.Error())
 			return err
@@ -425,7 +694,7 @@ func (c *ConfigurationChanger) changeCanary(e *keptnevents.ConfigurationChangeEv
 		if err := keptnutils.StoreChart(e.Project, e.Service, e.Stage, helm.GetChartName(e.Service, true), genChartData, c.configServiceURL); err != nil {
 			return err
 		}
-		if err := c.upgradeChart(genChart, *e, deploymentStrategy); err != nil {
+		if err := c.upgradeChart(genChart, *e, stageTarget, deploymentStrategy); err != nil {
 			return err
 		}
 
@@ -433,14 +702,17 @@ func (c *ConfigurationChanger) changeCanary(e *keptnevents.ConfigurationChangeEv
 		if err != nil {
 			return err
 		}
-		if err := c.upgradeChart(genChart, *e, deploymentStrategy); err != nil {
+		if err := c.upgradeChart(genChart, *e, stageTarget, deploymentStrategy); err != nil {
+			return err
+		}
+		if err := c.switchServiceSelectors(e, stageTarget, deploymentStrategy, 0); err != nil {
 			return err
 		}
 		userChart, err := keptnutils.GetChart(e.Project, e.Service, e.Stage, helm.GetChartName(e.Service, false), c.configServiceURL)
 		if err != nil {
 			return err
 		}
-		if err := c.upgradeChartWithReplicas(userChart, *e, deploymentStrategy, 0); err != nil {
+		if err := c.upgradeChartWithReplicas(userChart, *e, stageTarget, deploymentStrategy, 0); err != nil {
 			return err
 		}
 
@@ -449,7 +721,10 @@ func (c *ConfigurationChanger) changeCanary(e *keptnevents.ConfigurationChangeEv
 		if err != nil {
 			return err
 		}
-		if err := c.upgradeChart(ch, *e, deploymentStrategy); err != nil {
+		if err := c.upgradeChart(ch, *e, stageTarget, deploymentStrategy); err != nil {
+			return err
+		}
+		if err := c.switchServiceSelectors(e, stageTarget, deploymentStrategy, e.Canary.Value); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/keptn/go-utils/pkg/api/models"
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+	"github.com/keptn/keptn/helm-service/controller/helm"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+// deploymentDiffURI is the service resource containing the diff of the last previewed configuration change
+const deploymentDiffURI = "deployment-diff.yaml"
+
+// configurationChangeOptions are optional fields of the configuration change event controlling the diff preview
+type configurationChangeOptions struct {
+	// Diff stores the diff of the configuration change before it is applied
+	Diff bool `json:"diff"`
+	// DiffOnly stores the diff of the configuration change without applying it
+	DiffOnly bool `json:"diffOnly"`
+}
+
+// DeploymentDiff describes the changes of all releases affected by a configuration change
+type DeploymentDiff struct {
+	Releases []helm.ManifestDiff `json:"releases"`
+}
+
+// previewConfigurationChange renders the charts changed by the configuration change and compares them with the
+// deployed releases. Neither the charts nor the releases are modified. Canary, umbrella chart and chart reference
+// changes are not previewed.
+func (c *ConfigurationChanger) previewConfigurationChange(e *keptnevents.ConfigurationChangeEventData, stageTarget helm.StageTarget,
+	deploymentStrategy keptnevents.DeploymentStrategy) (*DeploymentDiff, error) {
+
+	// applyFileChanges consumes the file changes, hence the preview works on copies of them
+	preview := *e
+	preview.FileChangesUserChart = copyFileChanges(e.FileChangesUserChart)
+	preview.FileChangesGeneratedChart = copyFileChanges(e.FileChangesGeneratedChart)
+
+	diff := &DeploymentDiff{Releases: []helm.ManifestDiff{}}
+	if len(e.ValuesCanary) > 0 || len(e.FileChangesUserChart) > 0 {
+		releaseDiff, err := c.previewChart(&preview, stageTarget, false, deploymentStrategy, func(e *keptnevents.ConfigurationChangeEventData, ch *chart.Chart) error {
+			if err := changeValue(e, ch); err != nil {
+				return err
+			}
+			return changeUserChart(e, ch)
+		})
+		if err != nil {
+			return nil, err
+		}
+		diff.Releases = append(diff.Releases, releaseDiff)
+	}
+
+	if len(e.FileChangesGeneratedChart) > 0 {
+		releaseDiff, err := c.previewChart(&preview, stageTarget, true, deploymentStrategy, changeGeneratedChart)
+		if err != nil {
+			return nil, err
+		}
+		diff.Releases = append(diff.Releases, releaseDiff)
+	}
+	return diff, nil
+}
+
+func (c *ConfigurationChanger) previewChart(e *keptnevents.ConfigurationChangeEventData, stageTarget helm.StageTarget, generated bool,
+	deploymentStrategy keptnevents.DeploymentStrategy,
+	editChart func(*keptnevents.ConfigurationChangeEventData, *chart.Chart) error) (helm.ManifestDiff, error) {
+
+	helmChartName := helm.GetChartName(e.Service, generated)
+	releaseName := helm.GetReleaseName(e.Project, e.Stage, e.Service, generated)
+
+	ch, err := c.getChart(e, generated)
+	if err != nil {
+		return helm.ManifestDiff{}, err
+	}
+	if err := editChart(e, ch); err != nil {
+		return helm.ManifestDiff{}, err
+	}
+
+	vals, err := getChartValues(e.Project, e.Stage, e.Service, getDeploymentName(deploymentStrategy, generated),
+		generated, c.configServiceURL)
+	if err != nil {
+		return helm.ManifestDiff{}, err
+	}
+	desiredManifest, err := c.helmExecutor.RenderChart(ch, releaseName, stageTarget, vals)
+	if err != nil {
+		return helm.ManifestDiff{}, err
+	}
+
+	currentManifest, err := c.helmExecutor.GetManifest(releaseName, stageTarget)
+	if err != nil {
+		// The release is not deployed yet, hence all objects are added
+		c.keptnHandler.Logger.Debug(fmt.Sprintf("Compare chart %s with empty manifest: %s", helmChartName, err.Error()))
+		currentManifest = ""
+	}
+	return helm.DiffManifests(releaseName, currentManifest, desiredManifest), nil
+}
+
+// storeDeploymentDiff stores the diff as resource of the service
+func (c *ConfigurationChanger) storeDeploymentDiff(e *keptnevents.ConfigurationChangeEventData, diff *DeploymentDiff) error {
+
+	data, err := yaml.Marshal(diff)
+	if err != nil {
+		return err
+	}
+	uri := deploymentDiffURI
+	resource := models.Resource{ResourceURI: &uri, ResourceContent: string(data)}
+
+	rHandler := configutils.NewResourceHandler(c.configServiceURL)
+	if _, err := rHandler.CreateServiceResources(e.Project, e.Stage, e.Service, []*models.Resource{&resource}); err != nil {
+		return fmt.Errorf("error when storing %s of service %s: %v", deploymentDiffURI, e.Service, err)
+	}
+	return nil
+}
+
+func copyFileChanges(fileChanges map[string]string) map[string]string {
+	if fileChanges == nil {
+		return nil
+	}
+	res := make(map[string]string)
+	for k, v := range fileChanges {
+		res[k] = v
+	}
+	return res
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	"github.com/ghodss/yaml"
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+	"github.com/keptn/keptn/helm-service/controller/helm"
+	keptnutils "github.com/keptn/kubernetes-utils/pkg"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+const diffUserService = `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: carts
+spec:
+  type: ClusterIP
+  ports:
+  - name: http
+    port: 80
+    protocol: TCP
+    targetPort: 8080
+  selector:
+    app: carts
+`
+
+func mockDeploymentDiffEndpoints(storedResources map[string]string) *httptest.Server {
+
+	return httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+
+			if r.Method == http.MethodGet &&
+				strings.Contains(r.RequestURI, "/v1/project/sockshop/stage/dev/service/carts/resource/helm%2Fcarts") {
+				ch := chart.Chart{
+					Metadata: &chart.Metadata{
+						Name:       "carts",
+						Version:    "0.1.0",
+						APIVersion: "v2",
+					},
+					Templates: []*chart.File{{Name: "templates/service.yaml", Data: []byte(diffUserService)}},
+					Values:    map[string]interface{}{"image": "docker.io/keptnexamples/carts:0.10.1"},
+					Raw:       []*chart.File{{Name: "values.yaml", Data: []byte("image: docker.io/keptnexamples/carts:0.10.1\n")}},
+				}
+				if strings.Contains(r.RequestURI, "carts-generated.tgz") {
+					ch = chart.Chart{
+						Metadata: &chart.Metadata{
+							Name:       "carts-generated",
+							Version:    "0.1.0",
+							Keywords:   []string{"deployment_strategy=" + keptnevents.Direct.String()},
+							APIVersion: "v2",
+						},
+					}
+				}
+				chPackage, _ := keptnutils.PackageChart(&ch)
+				resp := models.Resource{
+					ResourceContent: base64.StdEncoding.EncodeToString(chPackage),
+				}
+				data, _ := json.Marshal(resp)
+				w.WriteHeader(200)
+				w.Write(data)
+				return
+			}
+
+			if r.Method == http.MethodGet && r.URL.Path == "/v1/project/sockshop/stage/dev/service/carts/resource/" {
+				w.WriteHeader(200)
+				w.Write([]byte(`{"resources": []}`))
+				return
+			}
+
+			if r.Method == http.MethodPost &&
+				strings.Contains(r.RequestURI, "/v1/project/sockshop/stage/dev/service/carts/resource") {
+				defer r.Body.Close()
+				resources := models.Resources{}
+				_ = json.NewDecoder(r.Body).Decode(&resources)
+				for _, resource := range resources.Resources {
+					content, _ := base64.StdEncoding.DecodeString(resource.ResourceContent)
+					storedResources[*resource.ResourceURI] = string(content)
+				}
+				data, _ := json.Marshal(models.Version{Version: "123-456"})
+				w.WriteHeader(200)
+				w.Write(data)
+				return
+			}
+
+			w.WriteHeader(404)
+			w.Write([]byte(`{"code": 404, "message": "Resource not found"}`))
+		}),
+	)
+}
+
+func TestChangeAndApplyConfigurationDiffOnly(t *testing.T) {
+
+	storedResources := make(map[string]string)
+	ts := mockDeploymentDiffEndpoints(storedResources)
+	defer ts.Close()
+
+	data := struct {
+		keptnevents.ConfigurationChangeEventData
+		configurationChangeOptions
+	}{
+		ConfigurationChangeEventData: keptnevents.ConfigurationChangeEventData{
+			Project:      "sockshop",
+			Service:      "carts",
+			Stage:        "dev",
+			ValuesCanary: map[string]interface{}{"image": "docker.io/keptnexamples/carts:0.11.1"},
+		},
+		configurationChangeOptions: configurationChangeOptions{DiffOnly: true},
+	}
+	dataBytes, err := json.Marshal(data)
+	assert.Nil(t, err)
+
+	ce := cloudevents.New("0.2")
+	ce.Data = dataBytes
+	ce.DataEncoded = true
+	keptnHandler, _ := keptnevents.NewKeptn(&ce, keptnevents.KeptnOpts{})
+	c := &ConfigurationChanger{
+		keptnHandler:     keptnHandler,
+		helmExecutor:     helm.NewHelmMockExecutor(),
+		configServiceURL: ts.URL,
+	}
+
+	loggingDone := make(chan bool, 1)
+	err = c.ChangeAndApplyConfiguration(ce, loggingDone)
+	assert.Nil(t, err)
+
+	// the chart is not changed, only the diff is stored
+	assert.Equal(t, 1, len(storedResources))
+	diff := DeploymentDiff{}
+	err = yaml.Unmarshal([]byte(storedResources[deploymentDiffURI]), &diff)
+	assert.Nil(t, err)
+	assert.Equal(t, DeploymentDiff{
+		Releases: []helm.ManifestDiff{
+			{
+				Release: "sockshop-dev-carts",
+				Objects: []helm.ObjectDiff{
+					{Kind: "Service", Name: "carts", Change: helm.ObjectChanged, Fields: []string{"spec.type"}},
+				},
+			},
+		},
+	}, diff)
+}
@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	configmodels "github.com/keptn/go-utils/pkg/api/models"
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+
+	"github.com/keptn/keptn/helm-service/controller/helm"
+)
+
+// deploymentResultPass is the result of a deployment-finished event which does not report a result
+const deploymentResultPass = "pass"
+
+// DeploymentHistoryEntry describes a deployment of a service in a stage.
+// Entries without keptnContext describe release revisions which were not created by keptn,
+// e.g. manual upgrades or rollbacks.
+type DeploymentHistoryEntry struct {
+	KeptnContext       string `json:"keptnContext,omitempty"`
+	Image              string `json:"image,omitempty"`
+	Tag                string `json:"tag,omitempty"`
+	ImageDigest        string `json:"imageDigest,omitempty"`
+	DeploymentStrategy string `json:"deploymentStrategy,omitempty"`
+	// Time is the time of the deployment-finished event, or the time of the revision for entries without keptnContext
+	Time          time.Time `json:"time"`
+	Result        string    `json:"result"`
+	ResultDetails string    `json:"resultDetails,omitempty"`
+	// Revisions contains the revisions of the user and generated releases created by the deployment
+	Revisions []helm.ReleaseRevision `json:"revisions"`
+}
+
+// DeploymentHistory is the deployment history of a service in a stage, starting with the latest deployment
+type DeploymentHistory struct {
+	Deployments []DeploymentHistoryEntry `json:"deployments"`
+}
+
+// DeploymentHistoryHandler serves the deployment history of services assembled from the
+// Helm release storage and the deployment-finished events stored in mongodb-datastore
+type DeploymentHistoryHandler struct {
+	helmExecutor     helm.HelmExecutor
+	configServiceURL string
+	datastoreURL     string
+}
+
+// NewDeploymentHistoryHandler creates a new DeploymentHistoryHandler
+func NewDeploymentHistoryHandler(configServiceURL string, datastoreURL string) *DeploymentHistoryHandler {
+	return &DeploymentHistoryHandler{
+		helmExecutor:     helm.NewHelmV3Executor(keptnevents.NewLogger("", "", "helm-service")),
+		configServiceURL: configServiceURL,
+		datastoreURL:     datastoreURL,
+	}
+}
+
+// ServeHTTP handles GET /v1/project/{project}/stage/{stage}/service/{service}/deployment
+func (h *DeploymentHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	w.Header().Set("Content-Type", "application/json")
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) != 8 || segments[0] != "v1" || segments[1] != "project" || segments[3] != "stage" ||
+		segments[5] != "service" || segments[7] != "deployment" {
+		writeHistoryError(w, http.StatusNotFound, "path "+r.URL.Path+" not found")
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeHistoryError(w, http.StatusMethodNotAllowed, "method "+r.Method+" not allowed")
+		return
+	}
+
+	history, err := h.GetDeploymentHistory(segments[2], segments[4], segments[6])
+	if err != nil {
+		writeHistoryError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(history)
+}
+
+func writeHistoryError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(configmodels.Error{Code: int64(code), Message: &message})
+}
+
+// GetDeploymentHistory returns the deployment history of the service in the stage
+func (h *DeploymentHistoryHandler) GetDeploymentHistory(project string, stage string, service string) (*DeploymentHistory, error) {
+
+	namespaces, err := loadNamespaceConfig(project, h.configServiceURL)
+	if err != nil {
+		return nil, err
+	}
+	stageTarget := helm.NewStageTarget(project, stage, namespaces)
+	revisions := []helm.ReleaseRevision{}
+	for _, generated := range []bool{false, true} {
+		releaseRevisions, err := h.helmExecutor.GetHistory(helm.GetReleaseName(project, stage, service, generated), stageTarget)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, releaseRevisions...)
+	}
+
+	eventHandler := configutils.NewEventHandler(h.datastoreURL)
+	events, errObj := eventHandler.GetEvents(&configutils.EventFilter{
+		Project:   project,
+		Stage:     stage,
+		Service:   service,
+		EventType: keptnevents.DeploymentFinishedEventType,
+	})
+	if errObj != nil {
+		msg := "unknown error"
+		if errObj.Message != nil {
+			msg = *errObj.Message
+		}
+		return nil, errors.New("error when reading the deployment-finished events: " + msg)
+	}
+
+	entries, err := getDeploymentEntries(events)
+	if err != nil {
+		return nil, err
+	}
+	return &DeploymentHistory{Deployments: assignRevisions(entries, revisions)}, nil
+}
+
+// getDeploymentEntries converts the deployment-finished events to history entries, starting with the oldest one
+func getDeploymentEntries(events []*configmodels.KeptnContextExtendedCE) ([]DeploymentHistoryEntry, error) {
+
+	entries := []DeploymentHistoryEntry{}
+	for _, event := range events {
+		dataBytes, err := json.Marshal(event.Data)
+		if err != nil {
+			return nil, err
+		}
+		data := &deploymentFinishedEventData{}
+		if err := json.Unmarshal(dataBytes, data); err != nil {
+			return nil, fmt.Errorf("error when parsing deployment-finished event %s: %v", event.ID, err)
+		}
+		entry := DeploymentHistoryEntry{
+			KeptnContext:       event.Shkeptncontext,
+			Image:              data.Image,
+			Tag:                data.Tag,
+			ImageDigest:        data.ImageDigest,
+			DeploymentStrategy: data.DeploymentStrategy,
+			Time:               time.Time(event.Time),
+			Result:             data.Result,
+			ResultDetails:      data.ResultDetails,
+			Revisions:          []helm.ReleaseRevision{},
+		}
+		if entry.Result == "" {
+			entry.Result = deploymentResultPass
+		}
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries, nil
+}
+
+// assignRevisions assigns each revision to the first deployment finished after the revision was deployed,
+// as the deployment-finished event is sent after the releases are upgraded. Skipped deployments do not create
+// revisions. The remaining revisions become entries on their own. The entries are returned starting with the latest one.
+func assignRevisions(entries []DeploymentHistoryEntry, revisions []helm.ReleaseRevision) []DeploymentHistoryEntry {
+
+	for _, revision := range revisions {
+		assigned := false
+		for i := range entries {
+			if entries[i].KeptnContext == "" || entries[i].Result == deploymentResultSkipped ||
+				entries[i].Time.Before(revision.Deployed) {
+				continue
+			}
+			entries[i].Revisions = append(entries[i].Revisions, revision)
+			assigned = true
+			break
+		}
+		if !assigned {
+			entry := DeploymentHistoryEntry{
+				Time:      revision.Deployed,
+				Result:    revision.Status,
+				Revisions: []helm.ReleaseRevision{revision},
+			}
+			if len(revision.Images) > 0 {
+				entry.Image, entry.Tag, entry.ImageDigest = splitImage(revision.Images[0])
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	for _, entry := range entries {
+		sort.Slice(entry.Revisions, func(i, j int) bool {
+			return entry.Revisions[i].Deployed.Before(entry.Revisions[j].Deployed)
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.After(entries[j].Time)
+	})
+	return entries
+}
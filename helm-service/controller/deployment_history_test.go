@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn/keptn/helm-service/controller/helm"
+)
+
+func TestGetDeploymentHistory(t *testing.T) {
+
+	base := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	var query string
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			if r.URL.Path != "/event" {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(models.Error{Code: 404})
+				return
+			}
+			query = r.URL.RawQuery
+			events := models.Events{Events: []*models.KeptnContextExtendedCE{
+				{
+					Shkeptncontext: "ctx-1",
+					Time:           strfmt.DateTime(base.Add(1 * time.Minute)),
+					Data:           map[string]interface{}{"image": "docker.io/keptnexamples/carts", "tag": "0.10.1", "deploymentstrategy": "direct"},
+				},
+				{
+					Shkeptncontext: "ctx-3",
+					Time:           strfmt.DateTime(base.Add(20 * time.Minute)),
+					Data:           map[string]interface{}{"image": "docker.io/keptnexamples/carts", "tag": "0.10.3", "result": "fail"},
+				},
+				{
+					Shkeptncontext: "ctx-2",
+					Time:           strfmt.DateTime(base.Add(10 * time.Minute)),
+					Data:           map[string]interface{}{"image": "docker.io/keptnexamples/carts", "tag": "0.10.2", "result": "skipped"},
+				},
+			}}
+			json.NewEncoder(w).Encode(events)
+		}),
+	)
+	defer ts.Close()
+
+	helmExecutor := helm.NewHelmMockExecutor()
+	helmExecutor.Revisions["sockshop-dev/sockshop-dev-carts"] = []helm.ReleaseRevision{
+		{Release: "sockshop-dev-carts", Revision: 1, Status: "superseded", Deployed: base},
+		{Release: "sockshop-dev-carts", Revision: 2, Status: "failed", Deployed: base.Add(19 * time.Minute)},
+		{Release: "sockshop-dev-carts", Revision: 3, Status: "deployed", Deployed: base.Add(30 * time.Minute),
+			Images: []string{"docker.io/keptnexamples/carts:0.10.1"}},
+	}
+	helmExecutor.Revisions["sockshop-dev/sockshop-dev-carts-generated"] = []helm.ReleaseRevision{
+		{Release: "sockshop-dev-carts-generated", Revision: 1, Status: "deployed", Deployed: base.Add(30 * time.Second)},
+	}
+
+	h := &DeploymentHistoryHandler{helmExecutor: helmExecutor, configServiceURL: ts.URL, datastoreURL: ts.URL}
+	history, err := h.GetDeploymentHistory("sockshop", "dev", "carts")
+	assert.NoError(t, err)
+	assert.Contains(t, query, "type=sh.keptn.events.deployment-finished")
+	assert.Contains(t, query, "stage=dev")
+
+	assert.Equal(t, 4, len(history.Deployments))
+
+	manual := history.Deployments[0]
+	assert.Equal(t, "", manual.KeptnContext)
+	assert.Equal(t, "docker.io/keptnexamples/carts", manual.Image)
+	assert.Equal(t, "0.10.1", manual.Tag)
+	assert.Equal(t, "deployed", manual.Result)
+	assert.Equal(t, 1, len(manual.Revisions))
+
+	failed := history.Deployments[1]
+	assert.Equal(t, "ctx-3", failed.KeptnContext)
+	assert.Equal(t, "fail", failed.Result)
+	assert.Equal(t, 1, len(failed.Revisions))
+	assert.Equal(t, 2, failed.Revisions[0].Revision)
+
+	skipped := history.Deployments[2]
+	assert.Equal(t, "ctx-2", skipped.KeptnContext)
+	assert.Equal(t, "skipped", skipped.Result)
+	assert.Equal(t, 0, len(skipped.Revisions))
+
+	first := history.Deployments[3]
+	assert.Equal(t, "ctx-1", first.KeptnContext)
+	assert.Equal(t, "0.10.1", first.Tag)
+	assert.Equal(t, "direct", first.DeploymentStrategy)
+	assert.Equal(t, "pass", first.Result)
+	assert.Equal(t, 2, len(first.Revisions))
+	assert.Equal(t, "sockshop-dev-carts", first.Revisions[0].Release)
+	assert.Equal(t, "sockshop-dev-carts-generated", first.Revisions[1].Release)
+}
+
+func TestDeploymentHistoryHandler_ServeHTTP(t *testing.T) {
+
+	h := &DeploymentHistoryHandler{helmExecutor: helm.NewHelmMockExecutor()}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/project/sockshop/stage/dev/service", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/project/sockshop/stage/dev/service/carts/deployment", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestSplitImage(t *testing.T) {
+	image, tag, digest := splitImage("localhost:5000/keptnexamples/carts:0.10.1")
+	assert.Equal(t, "localhost:5000/keptnexamples/carts", image)
+	assert.Equal(t, "0.10.1", tag)
+	assert.Equal(t, "", digest)
+
+	image, tag, digest = splitImage("localhost:5000/keptnexamples/carts")
+	assert.Equal(t, "localhost:5000/keptnexamples/carts", image)
+	assert.Equal(t, "", tag)
+
+	image, tag, digest = splitImage("docker.io/keptnexamples/carts:0.10.1@sha256:2f8a")
+	assert.Equal(t, "docker.io/keptnexamples/carts", image)
+	assert.Equal(t, "0.10.1", tag)
+	assert.Equal(t, "sha256:2f8a", digest)
+}
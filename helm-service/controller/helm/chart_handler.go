@@ -5,9 +5,10 @@ func GetUmbrellaReleaseName(project string, stage string) string {
 	return project + "-" + stage
 }
 
-// GetUmbrellaNamespace returns the namespace in which the umbrella chart (e.g. containing the gateway) is applied
-func GetUmbrellaNamespace(project string, stage string) string {
-	return project + "-" + stage
+// GetUmbrellaNamespace returns the namespace in which the umbrella chart (e.g. containing the gateway) is applied.
+// The namespace is defined by the namespace config of the project and defaults to PROJECT-STAGE if the config is nil.
+func GetUmbrellaNamespace(project string, stage string, config *NamespaceConfig) string {
+	return config.GetStageNamespace(project, stage).Namespace
 }
 
 // GetChartName returns the name of the chart
@@ -0,0 +1,222 @@
+package helm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	keptnutils "github.com/keptn/kubernetes-utils/pkg"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// ClusterTargetStageLabel is the label of a secret which maps the stage to a target cluster
+	ClusterTargetStageLabel = "keptn.sh/stage"
+	// ClusterTargetProjectLabel is the optional label of a cluster target secret, which restricts it to one project
+	ClusterTargetProjectLabel = "keptn.sh/project"
+
+	// kubeconfigKey is the secret key of a kubeconfig, whose current context is used
+	kubeconfigKey = "kubeconfig"
+	// serverKey, tokenKey and caKey are the secret keys for accessing the cluster with a ServiceAccount token
+	serverKey = "server"
+	tokenKey  = "token"
+	caKey     = "ca.crt"
+
+	// clusterTargetsTTL is the time the cluster target secrets are cached before they are listed again
+	clusterTargetsTTL = 30 * time.Second
+)
+
+// StageTarget is the namespace a stage of a project is deployed to. The cluster of the namespace is defined by the
+// cluster target of the project and the stage.
+type StageTarget struct {
+	Project   string
+	Stage     string
+	Namespace string
+}
+
+// NewStageTarget resolves the namespace of the stage by the namespace config of the project
+func NewStageTarget(project string, stage string, config *NamespaceConfig) StageTarget {
+	return StageTarget{Project: project, Stage: stage, Namespace: GetUmbrellaNamespace(project, stage, config)}
+}
+
+// ClusterTarget maps the stage of a project to the cluster the stage is deployed to.
+// A target without project applies to the stage of all projects.
+type ClusterTarget struct {
+	Project string
+	Stage   string
+	Config  *rest.Config
+}
+
+// matches returns whether the target applies to the stage of the project
+func (t ClusterTarget) matches(project string, stage string) bool {
+	return t.Stage == stage && (t.Project == "" || t.Project == project)
+}
+
+// clusterTargetCache caches the cluster targets, which are read for every Helm operation
+type clusterTargetCache struct {
+	mutex   sync.Mutex
+	targets []ClusterTarget
+	loaded  time.Time
+}
+
+var clusterTargets = &clusterTargetCache{}
+
+// get returns the cached cluster targets or lists them again if they are older than clusterTargetsTTL
+func (c *clusterTargetCache) get(clientset kubernetes.Interface, namespace string, now time.Time) ([]ClusterTarget, error) {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.targets != nil && now.Sub(c.loaded) < clusterTargetsTTL {
+		return c.targets, nil
+	}
+	targets, err := LoadClusterTargets(clientset, namespace)
+	if err != nil {
+		return nil, err
+	}
+	c.targets = targets
+	c.loaded = now
+	return targets, nil
+}
+
+// getLocalRestConfig returns the config of the cluster helm-service runs in
+func getLocalRestConfig() (config *rest.Config, err error) {
+
+	if getInClusterConfig() {
+		config, err = rest.InClusterConfig()
+	} else {
+		kubeconfig := filepath.Join(
+			keptnutils.UserHomeDir(), ".kube", "config",
+		)
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	return
+}
+
+// GetPodNamespace returns the namespace helm-service runs in, which contains the cluster target secrets
+func GetPodNamespace() string {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+	return "keptn"
+}
+
+// LoadClusterTargets reads the cluster targets from the secrets labeled with ClusterTargetStageLabel
+func LoadClusterTargets(clientset kubernetes.Interface, namespace string) ([]ClusterTarget, error) {
+
+	secrets, err := clientset.CoreV1().Secrets(namespace).List(metav1.ListOptions{LabelSelector: ClusterTargetStageLabel})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing cluster target secrets in namespace %s: %v", namespace, err)
+	}
+	targets := []ClusterTarget{}
+	for i := range secrets.Items {
+		target, err := parseClusterTarget(&secrets.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, *target)
+	}
+	return targets, nil
+}
+
+func parseClusterTarget(secret *corev1.Secret) (*ClusterTarget, error) {
+
+	target := &ClusterTarget{
+		Project: secret.Labels[ClusterTargetProjectLabel],
+		Stage:   secret.Labels[ClusterTargetStageLabel],
+	}
+	if target.Stage == "" {
+		return nil, fmt.Errorf("cluster target secret %s does not specify a stage", secret.Name)
+	}
+
+	if kubeconfig, ok := secret.Data[kubeconfigKey]; ok {
+		config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("error when parsing the kubeconfig of cluster target secret %s: %v", secret.Name, err)
+		}
+		target.Config = config
+		return target, nil
+	}
+
+	server, token := string(secret.Data[serverKey]), string(secret.Data[tokenKey])
+	if server == "" || token == "" {
+		return nil, fmt.Errorf("cluster target secret %s requires either a %s or a %s and a %s",
+			secret.Name, kubeconfigKey, serverKey, tokenKey)
+	}
+	target.Config = &rest.Config{
+		Host:            server,
+		BearerToken:     token,
+		TLSClientConfig: rest.TLSClientConfig{CAData: secret.Data[caKey]},
+	}
+	return target, nil
+}
+
+// FindClusterTarget returns the target cluster of the stage of the project or nil if the stage is deployed to the
+// cluster helm-service runs in. A target of the project takes precedence over a target of all projects.
+func FindClusterTarget(targets []ClusterTarget, project string, stage string) (*ClusterTarget, error) {
+
+	var found *ClusterTarget
+	for i := range targets {
+		target := &targets[i]
+		if !target.matches(project, stage) {
+			continue
+		}
+		if found == nil || (found.Project == "" && target.Project != "") {
+			found = target
+		} else if found.Project == target.Project {
+			return nil, fmt.Errorf("multiple cluster targets are defined for stage %s of project %s", stage, project)
+		}
+	}
+	return found, nil
+}
+
+// GetRestConfig returns the config of the cluster the stage is deployed to
+func GetRestConfig(target StageTarget) (*rest.Config, error) {
+
+	config, err := getLocalRestConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := clusterTargets.get(clientset, GetPodNamespace(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	clusterTarget, err := FindClusterTarget(targets, target.Project, target.Stage)
+	if err != nil {
+		return nil, err
+	}
+	if clusterTarget == nil {
+		return config, nil
+	}
+	return clusterTarget.Config, nil
+}
+
+// GetLocalClientset returns the clientset of the cluster helm-service runs in
+func GetLocalClientset() (*kubernetes.Clientset, error) {
+
+	config, err := getLocalRestConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+// GetClientset returns the clientset of the cluster the stage is deployed to
+func GetClientset(target StageTarget) (*kubernetes.Clientset, error) {
+
+	config, err := GetRestConfig(target)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
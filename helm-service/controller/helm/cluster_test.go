@@ -0,0 +1,140 @@
+package helm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const targetKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: production
+  cluster:
+    server: https://production.example.com
+    certificate-authority-data: Y2EtZGF0YQ==
+contexts:
+- name: production
+  context:
+    cluster: production
+    user: helm-service
+current-context: production
+users:
+- name: helm-service
+  user:
+    token: kubeconfig-token
+`
+
+func newClusterTargetSecret(name string, labels map[string]string, data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "keptn", Labels: labels},
+		Data:       map[string][]byte{},
+	}
+	for k, v := range data {
+		secret.Data[k] = []byte(v)
+	}
+	return secret
+}
+
+func TestLoadClusterTargets(t *testing.T) {
+
+	clientset := fake.NewSimpleClientset(
+		newClusterTargetSecret("production", map[string]string{ClusterTargetStageLabel: "production"},
+			map[string]string{kubeconfigKey: targetKubeconfig}),
+		newClusterTargetSecret("sockshop-staging", map[string]string{ClusterTargetStageLabel: "staging", ClusterTargetProjectLabel: "sockshop"},
+			map[string]string{serverKey: "https://staging.example.com", tokenKey: "sa-token", caKey: "ca-data"}),
+		newClusterTargetSecret("unrelated", nil, map[string]string{tokenKey: "other"}),
+	)
+
+	targets, err := LoadClusterTargets(clientset, "keptn")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(targets))
+
+	target, err := FindClusterTarget(targets, "sockshop", "production")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://production.example.com", target.Config.Host)
+	assert.Equal(t, "kubeconfig-token", target.Config.BearerToken)
+	assert.Equal(t, []byte("ca-data"), target.Config.CAData)
+
+	target, err = FindClusterTarget(targets, "sockshop", "staging")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://staging.example.com", target.Config.Host)
+	assert.Equal(t, "sa-token", target.Config.BearerToken)
+	assert.Equal(t, []byte("ca-data"), target.Config.CAData)
+
+	target, err = FindClusterTarget(targets, "carts", "staging")
+	assert.NoError(t, err)
+	assert.Nil(t, target)
+}
+
+func TestLoadClusterTargetsRejectsIncompleteSecret(t *testing.T) {
+
+	clientset := fake.NewSimpleClientset(
+		newClusterTargetSecret("production", map[string]string{ClusterTargetStageLabel: "production"},
+			map[string]string{serverKey: "https://production.example.com"}),
+	)
+	_, err := LoadClusterTargets(clientset, "keptn")
+	assert.Error(t, err)
+}
+
+func TestFindClusterTarget(t *testing.T) {
+
+	targets := []ClusterTarget{
+		{Stage: "production"},
+		{Stage: "eu-production"},
+		{Project: "sockshop", Stage: "production"},
+	}
+
+	tests := []struct {
+		name    string
+		project string
+		stage   string
+		want    *ClusterTarget
+	}{
+		{name: "target of the project", project: "sockshop", stage: "production", want: &targets[2]},
+		{name: "target of all projects", project: "carts", stage: "production", want: &targets[0]},
+		{name: "exact stage of all projects", project: "carts", stage: "eu-production", want: &targets[1]},
+		{name: "no target", project: "sockshop", stage: "dev", want: nil},
+		{name: "no suffix match", project: "sockshop", stage: "pre-production", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := FindClusterTarget(targets, tt.project, tt.stage)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, target)
+		})
+	}
+
+	_, err := FindClusterTarget(append(targets, ClusterTarget{Stage: "production"}), "carts", "production")
+	assert.Error(t, err)
+}
+
+func TestClusterTargetCache(t *testing.T) {
+
+	clientset := fake.NewSimpleClientset(
+		newClusterTargetSecret("production", map[string]string{ClusterTargetStageLabel: "production"},
+			map[string]string{kubeconfigKey: targetKubeconfig}),
+	)
+	cache := &clusterTargetCache{}
+	now := time.Now()
+
+	targets, err := cache.get(clientset, "keptn", now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(targets))
+
+	_, err = clientset.CoreV1().Secrets("keptn").Create(newClusterTargetSecret("staging",
+		map[string]string{ClusterTargetStageLabel: "staging"}, map[string]string{kubeconfigKey: targetKubeconfig}))
+	assert.NoError(t, err)
+
+	targets, err = cache.get(clientset, "keptn", now.Add(clusterTargetsTTL/2))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(targets))
+
+	targets, err = cache.get(clientset, "keptn", now.Add(clusterTargetsTTL))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(targets))
+}
@@ -25,8 +25,8 @@ func NewGeneratedChartHandler(mesh mesh.Mesh, logger keptnevents.LoggerInterface
 }
 
 // GenerateDuplicateManagedChart generates a duplicated chart which is managed by keptn and used for
-// b/g and canary releases
-func (c *GeneratedChartHandler) GenerateDuplicateManagedChart(helmManifest string, project string, stageName string, service string) (*chart.Chart, error) {
+// b/g and canary releases in the namespace of the stage
+func (c *GeneratedChartHandler) GenerateDuplicateManagedChart(helmManifest string, namespace string, service string) (*chart.Chart, error) {
 	meta := &chart.Metadata{
 		APIVersion: "v2",
 		Name:       service + "-generated",
@@ -37,9 +37,15 @@ func (c *GeneratedChartHandler) GenerateDuplicateManagedChart(helmManifest strin
 
 	svcs := GetServices(helmManifest)
 	depls := GetDeployments(helmManifest)
+	statefulSets := GetStatefulSets(helmManifest)
+
+	// A daemonset runs on every node, hence it cannot be duplicated for a canary
+	if daemonSets := GetDaemonSets(helmManifest); len(daemonSets) > 0 {
+		return nil, fmt.Errorf("DaemonSet %s cannot be deployed with deployment strategy %s", daemonSets[0].Name, keptnevents.Duplicate.String())
+	}
 
 	for _, svc := range svcs {
-		templates, err := c.generateServices(svc, project, stageName)
+		templates, err := c.generateServices(svc, namespace)
 		if err != nil {
 			return nil, err
 		}
@@ -54,6 +60,14 @@ func (c *GeneratedChartHandler) GenerateDuplicateManagedChart(helmManifest strin
 		ch.Templates = append(ch.Templates, template)
 	}
 
+	for _, sts := range statefulSets {
+		template, err := c.generateStatefulSet(sts, svcs)
+		if err != nil {
+			return nil, err
+		}
+		ch.Templates = append(ch.Templates, template)
+	}
+
 	return &ch, nil
 }
 
@@ -78,14 +92,22 @@ func resetDeployment(depl *appsv1.Deployment) {
 	depl.Status = appsv1.DeploymentStatus{}
 }
 
-func (*GeneratedChartHandler) getNamespace(project string, stage string) string {
-	return project + "-" + stage
+func resetStatefulSet(sts *appsv1.StatefulSet) {
+	sts.Kind = "StatefulSet"
+	sts.APIVersion = "apps/v1"
+	sts.Namespace = ""
+	sts.ResourceVersion = ""
+	sts.Status = appsv1.StatefulSetStatus{}
 }
 
-func (c *GeneratedChartHandler) generateServices(svc *corev1.Service, project string, stageName string) ([]*chart.File, error) {
+func (c *GeneratedChartHandler) generateServices(svc *corev1.Service, namespace string) ([]*chart.File, error) {
 
 	templates := make([]*chart.File, 0, 0)
 
+	// The services of the user chart select the primary pods if they are switched by their selectors
+	svc = svc.DeepCopy()
+	removePrimarySuffix(svc.Spec.Selector)
+
 	serviceCanary := svc.DeepCopy()
 	serviceCanary.Name = serviceCanary.Name + "-canary"
 
@@ -97,16 +119,6 @@ func (c *GeneratedChartHandler) generateServices(svc *corev1.Service, project st
 	}
 	templates = append(templates, &chart.File{Name: "templates/" + serviceCanary.Name + "-service" + ".yaml", Data: data})
 
-	// Generate destination rule for canary service
-	c.logger.Info("Generating destination rule for canary service " + serviceCanary.Name)
-	hostCanary := serviceCanary.Name + "." + c.getNamespace(project, stageName) + ".svc.cluster.local"
-	destinationRuleCanary, err := c.mesh.GenerateDestinationRule(serviceCanary.Name, hostCanary)
-	if err != nil {
-		c.logger.Error("Error while generating destination rule for canary service " + serviceCanary.Name + ": " + err.Error())
-		return nil, err
-	}
-	templates = append(templates, &chart.File{Name: "templates/" + serviceCanary.Name + c.mesh.GetDestinationRuleSuffix(), Data: destinationRuleCanary})
-
 	servicePrimary := svc.DeepCopy()
 	servicePrimary.Name = servicePrimary.Name + "-primary"
 	if _, ok := servicePrimary.Spec.Selector["app"]; ok {
@@ -122,24 +134,43 @@ func (c *GeneratedChartHandler) generateServices(svc *corev1.Service, project st
 	}
 	templates = append(templates, &chart.File{Name: "templates/" + servicePrimary.Name + "-service" + ".yaml", Data: data})
 
+	// Meshes not splitting the traffic do not route to the generated services
+	if !c.mesh.SplitsTraffic() {
+		return templates, nil
+	}
+
+	// Generate destination rule for canary service
+	c.logger.Info("Generating destination rule for canary service " + serviceCanary.Name)
+	hostCanary := serviceCanary.Name + "." + namespace + ".svc.cluster.local"
+	destinationRuleCanary, err := c.mesh.GenerateDestinationRule(serviceCanary.Name, hostCanary)
+	if err != nil {
+		c.logger.Error("Error while generating destination rule for canary service " + serviceCanary.Name + ": " + err.Error())
+		return nil, err
+	}
+	if destinationRuleCanary != nil {
+		templates = append(templates, &chart.File{Name: "templates/" + serviceCanary.Name + c.mesh.GetDestinationRuleSuffix(), Data: destinationRuleCanary})
+	}
+
 	// Generate destination rule for primary service
 	c.logger.Info("Generating destination rule for primary service " + svc.Name)
-	hostPrimary := servicePrimary.Name + "." + c.getNamespace(project, stageName) + ".svc.cluster.local"
+	hostPrimary := servicePrimary.Name + "." + namespace + ".svc.cluster.local"
 	destinationRulePrimary, err := c.mesh.GenerateDestinationRule(servicePrimary.Name, hostPrimary)
 	if err != nil {
 		c.logger.Error("Error while generating destination rule for primary service " + svc.Name + ": " + err.Error())
 		return nil, err
 	}
-	templates = append(templates, &chart.File{Name: "templates/" + servicePrimary.Name + c.mesh.GetDestinationRuleSuffix(), Data: destinationRulePrimary})
+	if destinationRulePrimary != nil {
+		templates = append(templates, &chart.File{Name: "templates/" + servicePrimary.Name + c.mesh.GetDestinationRuleSuffix(), Data: destinationRulePrimary})
+	}
 
 	// Generate virtual service
 	gws := []string{mesh.GetIngressGateway(), "mesh"}
 	hosts := []string{
-		svc.Name + "." + c.getNamespace(project, stageName) + "." + mesh.GetIngressHostnameSuffix(), // service_name.dev.123.45.67.89.xip.io
+		svc.Name + "." + namespace + "." + mesh.GetIngressHostnameSuffix(), // service_name.dev.123.45.67.89.xip.io
 		svc.Name, // service-name
 	}
-	destCanary := mesh.HTTPRouteDestination{Host: hostCanary, Weight: 0}
-	destPrimary := mesh.HTTPRouteDestination{Host: hostPrimary, Weight: 100}
+	destCanary := mesh.HTTPRouteDestination{Host: hostCanary, Weight: 0, Port: getServicePort(svc)}
+	destPrimary := mesh.HTTPRouteDestination{Host: hostPrimary, Weight: 100, Port: getServicePort(svc)}
 	httpRouteDestinations := []mesh.HTTPRouteDestination{destCanary, destPrimary}
 
 	c.logger.Info("Generating VirtualService for service " + svc.Name + ". URL = " + mesh.GetIngressProtocol() + "://" + svc.Name + "." + mesh.GetIngressHostnameSuffix() + ":" + mesh.GetIngressPort())
@@ -149,27 +180,37 @@ func (c *GeneratedChartHandler) generateServices(svc *corev1.Service, project st
 		return nil, err
 	}
 
-	templates = append(templates, &chart.File{Name: "templates/" + svc.Name + c.mesh.GetVirtualServiceSuffix(), Data: vs})
+	if vs != nil {
+		templates = append(templates, &chart.File{Name: "templates/" + svc.Name + c.mesh.GetVirtualServiceSuffix(), Data: vs})
+	}
 
 	return templates, nil
 }
 
+// getServicePort returns the first port of the service
+func getServicePort(svc *corev1.Service) int32 {
+	if len(svc.Spec.Ports) > 0 {
+		return svc.Spec.Ports[0].Port
+	}
+	return 0
+}
+
+// addPrimarySuffix adds the suffix -primary to the app labels used to select the pods
+func addPrimarySuffix(labels map[string]string) {
+	if _, ok := labels["app"]; ok {
+		labels["app"] = labels["app"] + "-primary"
+	}
+	if _, ok := labels["app.kubernetes.io/name"]; ok {
+		labels["app.kubernetes.io/name"] = labels["app.kubernetes.io/name"] + "-primary"
+	}
+}
+
 func (c *GeneratedChartHandler) generateDeployment(depl *appsv1.Deployment) (*chart.File, error) {
 	primaryDeployment := depl.DeepCopy()
 
 	primaryDeployment.Name = primaryDeployment.Name + "-primary"
-	if _, ok := primaryDeployment.Spec.Selector.MatchLabels["app"]; ok {
-		primaryDeployment.Spec.Selector.MatchLabels["app"] = primaryDeployment.Spec.Selector.MatchLabels["app"] + "-primary"
-	}
-	if _, ok := primaryDeployment.Spec.Selector.MatchLabels["app.kubernetes.io/name"]; ok {
-		primaryDeployment.Spec.Selector.MatchLabels["app.kubernetes.io/name"] = primaryDeployment.Spec.Selector.MatchLabels["app.kubernetes.io/name"] + "-primary"
-	}
-	if _, ok := primaryDeployment.Spec.Template.ObjectMeta.Labels["app"]; ok {
-		primaryDeployment.Spec.Template.ObjectMeta.Labels["app"] = primaryDeployment.Spec.Template.ObjectMeta.Labels["app"] + "-primary"
-	}
-	if _, ok := primaryDeployment.Spec.Template.ObjectMeta.Labels["app.kubernetes.io/name"]; ok {
-		primaryDeployment.Spec.Template.ObjectMeta.Labels["app.kubernetes.io/name"] = primaryDeployment.Spec.Template.ObjectMeta.Labels["app.kubernetes.io/name"] + "-primary"
-	}
+	addPrimarySuffix(primaryDeployment.Spec.Selector.MatchLabels)
+	addPrimarySuffix(primaryDeployment.Spec.Template.ObjectMeta.Labels)
 	resetDeployment(primaryDeployment)
 	data, err := yaml.Marshal(primaryDeployment)
 	if err != nil {
@@ -180,8 +221,30 @@ func (c *GeneratedChartHandler) generateDeployment(depl *appsv1.Deployment) (*ch
 	return &chart.File{Name: "templates/" + primaryDeployment.Name + "-deployment" + ".yaml", Data: []byte(yamlString)}, nil
 }
 
-// GenerateMeshChart generates a chart containing the required mesh setup
-func (c *GeneratedChartHandler) GenerateMeshChart(helmManifest string, project string, stageName string,
+func (c *GeneratedChartHandler) generateStatefulSet(sts *appsv1.StatefulSet, svcs []*corev1.Service) (*chart.File, error) {
+	primaryStatefulSet := sts.DeepCopy()
+
+	primaryStatefulSet.Name = primaryStatefulSet.Name + "-primary"
+	addPrimarySuffix(primaryStatefulSet.Spec.Selector.MatchLabels)
+	addPrimarySuffix(primaryStatefulSet.Spec.Template.ObjectMeta.Labels)
+	// The governing service of the primary statefulset is the generated primary service
+	for _, svc := range svcs {
+		if svc.Name == primaryStatefulSet.Spec.ServiceName {
+			primaryStatefulSet.Spec.ServiceName = svc.Name + "-primary"
+		}
+	}
+	resetStatefulSet(primaryStatefulSet)
+	data, err := yaml.Marshal(primaryStatefulSet)
+	if err != nil {
+		return nil, err
+	}
+	// Set the keptn_deployment to primary
+	yamlString := strings.ReplaceAll(string(data), "keptn_deployment=canary", "keptn_deployment=primary")
+	return &chart.File{Name: "templates/" + primaryStatefulSet.Name + "-statefulset" + ".yaml", Data: []byte(yamlString)}, nil
+}
+
+// GenerateMeshChart generates a chart containing the required mesh setup in the namespace of the stage
+func (c *GeneratedChartHandler) GenerateMeshChart(helmManifest string, namespace string,
 	service string) (*chart.Chart, error) {
 
 	meta := &chart.Metadata{
@@ -192,17 +255,21 @@ func (c *GeneratedChartHandler) GenerateMeshChart(helmManifest string, project s
 	}
 	ch := chart.Chart{Metadata: meta}
 
+	if !c.mesh.SplitsTraffic() {
+		return &ch, nil
+	}
+
 	svcs := GetServices(helmManifest)
 
 	for _, svc := range svcs {
 		// Generate virtual service for external access
 		gws := []string{mesh.GetIngressGateway(), "mesh"}
 		hosts := []string{
-			svc.Name + "." + c.getNamespace(project, stageName) + "." + mesh.GetIngressHostnameSuffix(),
+			svc.Name + "." + namespace + "." + mesh.GetIngressHostnameSuffix(),
 			svc.Name,
 		}
-		host := svc.Name + "." + c.getNamespace(project, stageName) + ".svc.cluster.local"
-		dest := mesh.HTTPRouteDestination{Host: host}
+		host := svc.Name + "." + namespace + ".svc.cluster.local"
+		dest := mesh.HTTPRouteDestination{Host: host, Port: getServicePort(svc)}
 		httpRouteDestinations := []mesh.HTTPRouteDestination{dest}
 
 		vs, err := c.mesh.GenerateVirtualService(svc.Name, gws, hosts, httpRouteDestinations)
@@ -210,15 +277,19 @@ func (c *GeneratedChartHandler) GenerateMeshChart(helmManifest string, project s
 			return nil, err
 		}
 
-		vsTemplate := chart.File{Name: "templates/" + svc.Name + c.mesh.GetVirtualServiceSuffix(), Data: vs}
-		ch.Templates = append(ch.Templates, &vsTemplate)
+		if vs != nil {
+			vsTemplate := chart.File{Name: "templates/" + svc.Name + c.mesh.GetVirtualServiceSuffix(), Data: vs}
+			ch.Templates = append(ch.Templates, &vsTemplate)
+		}
 
 		dr, err := c.mesh.GenerateDestinationRule(svc.Name, host)
 		if err != nil {
 			return nil, err
 		}
-		drTemplate := chart.File{Name: "templates/" + svc.Name + c.mesh.GetDestinationRuleSuffix(), Data: dr}
-		ch.Templates = append(ch.Templates, &drTemplate)
+		if dr != nil {
+			drTemplate := chart.File{Name: "templates/" + svc.Name + c.mesh.GetDestinationRuleSuffix(), Data: dr}
+			ch.Templates = append(ch.Templates, &drTemplate)
+		}
 	}
 
 	return &ch, nil
@@ -227,6 +298,10 @@ func (c *GeneratedChartHandler) GenerateMeshChart(helmManifest string, project s
 // UpdateCanaryWeight updates the provided traffic weight in the VirtualService contained in the chart
 func (c *GeneratedChartHandler) UpdateCanaryWeight(ch *chart.Chart, canaryWeight int32) error {
 
+	if !c.mesh.SplitsTraffic() {
+		return nil
+	}
+
 	// Set weights in all virtualservices
 	for _, template := range ch.Templates {
 		if strings.HasPrefix(template.Name, "templates/") &&
@@ -0,0 +1,175 @@
+package helm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+	"github.com/keptn/keptn/helm-service/controller/mesh"
+	"gotest.tools/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func getTemplateNames(templates []string) map[string]bool {
+	res := make(map[string]bool)
+	for _, template := range templates {
+		res[template] = true
+	}
+	return res
+}
+
+func TestGenerateDuplicateManagedChartWithSMI(t *testing.T) {
+
+	h := NewGeneratedChartHandler(mesh.NewSMIMesh(), keptnevents.NewLogger("", "", "helm-service"))
+	ch, err := h.GenerateDuplicateManagedChart(helmManifestResource, "sockshop-staging", "carts")
+	assert.NilError(t, err)
+
+	templates := []string{}
+	for _, template := range ch.Templates {
+		assert.Assert(t, !strings.Contains(string(template.Data), "istio"), template.Name)
+		templates = append(templates, template.Name)
+	}
+	assert.DeepEqual(t, getTemplateNames(templates), getTemplateNames([]string{
+		"templates/carts-canary-service.yaml",
+		"templates/carts-primary-service.yaml",
+		"templates/carts-smi-trafficsplit.yaml",
+		"templates/carts-primary-deployment.yaml",
+	}))
+
+	err = h.UpdateCanaryWeight(ch, 30)
+	assert.NilError(t, err)
+	for _, template := range ch.Templates {
+		if template.Name == "templates/carts-smi-trafficsplit.yaml" {
+			assert.Assert(t, strings.Contains(string(template.Data), "weight: 30"))
+			assert.Assert(t, strings.Contains(string(template.Data), "weight: 70"))
+		}
+	}
+}
+
+func TestGenerateMeshChartWithSMI(t *testing.T) {
+
+	h := NewGeneratedChartHandler(mesh.NewSMIMesh(), keptnevents.NewLogger("", "", "helm-service"))
+	ch, err := h.GenerateMeshChart(helmManifestResource, "sockshop-dev", "carts")
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(ch.Templates))
+}
+
+func TestGenerateDuplicateManagedChartWithIstio(t *testing.T) {
+
+	h := NewGeneratedChartHandler(mesh.NewIstioMesh(), keptnevents.NewLogger("", "", "helm-service"))
+	ch, err := h.GenerateDuplicateManagedChart(helmManifestResource, "sockshop-staging", "carts")
+	assert.NilError(t, err)
+
+	templates := []string{}
+	for _, template := range ch.Templates {
+		templates = append(templates, template.Name)
+	}
+	assert.DeepEqual(t, getTemplateNames(templates), getTemplateNames([]string{
+		"templates/carts-canary-service.yaml",
+		"templates/carts-canary-istio-destinationrule.yaml",
+		"templates/carts-primary-service.yaml",
+		"templates/carts-primary-istio-destinationrule.yaml",
+		"templates/carts-istio-virtualservice.yaml",
+		"templates/carts-primary-deployment.yaml",
+	}))
+}
+
+func TestGenerateDuplicateManagedChartWithNginx(t *testing.T) {
+
+	h := NewGeneratedChartHandler(mesh.NewNginxMesh(), keptnevents.NewLogger("", "", "helm-service"))
+	ch, err := h.GenerateDuplicateManagedChart(helmManifestResource, "sockshop-staging", "carts")
+	assert.NilError(t, err)
+
+	templates := []string{}
+	for _, template := range ch.Templates {
+		assert.Assert(t, !strings.Contains(string(template.Data), "VirtualService"), template.Name)
+		templates = append(templates, template.Name)
+	}
+	assert.DeepEqual(t, getTemplateNames(templates), getTemplateNames([]string{
+		"templates/carts-canary-service.yaml",
+		"templates/carts-primary-service.yaml",
+		"templates/carts-nginx-ingress.yaml",
+		"templates/carts-primary-deployment.yaml",
+	}))
+
+	err = h.UpdateCanaryWeight(ch, 30)
+	assert.NilError(t, err)
+	for _, template := range ch.Templates {
+		if template.Name == "templates/carts-nginx-ingress.yaml" {
+			assert.Assert(t, strings.Contains(string(template.Data), "nginx.ingress.kubernetes.io/canary-weight: \"30\""))
+			assert.Assert(t, strings.Contains(string(template.Data), "serviceName: carts-primary"))
+		}
+	}
+}
+
+func TestGenerateMeshChartWithNginx(t *testing.T) {
+
+	h := NewGeneratedChartHandler(mesh.NewNginxMesh(), keptnevents.NewLogger("", "", "helm-service"))
+	ch, err := h.GenerateMeshChart(helmManifestResource, "sockshop-dev", "carts")
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(ch.Templates))
+	assert.Equal(t, "templates/carts-nginx-ingress.yaml", ch.Templates[0].Name)
+	assert.Assert(t, strings.Contains(string(ch.Templates[0].Data), "kind: Ingress"))
+	assert.Assert(t, !strings.Contains(string(ch.Templates[0].Data), "canary"))
+}
+
+func TestGenerateDuplicateManagedChartWithStatefulSet(t *testing.T) {
+
+	h := NewGeneratedChartHandler(mesh.NewIstioMesh(), keptnevents.NewLogger("", "", "helm-service"))
+	manifest := helmManifestStatefulSet[:strings.Index(helmManifestStatefulSet, "# Source: carts-db/templates/daemonset.yaml")]
+	ch, err := h.GenerateDuplicateManagedChart(manifest, "sockshop-staging", "carts-db")
+	assert.NilError(t, err)
+
+	var primary *chart.File
+	for _, template := range ch.Templates {
+		if template.Name == "templates/carts-db-primary-statefulset.yaml" {
+			primary = template
+		}
+	}
+	assert.Assert(t, primary != nil)
+
+	sts := appsv1.StatefulSet{}
+	assert.NilError(t, yaml.Unmarshal(primary.Data, &sts))
+	assert.Equal(t, "carts-db-primary", sts.Name)
+	assert.Equal(t, "carts-db-primary", sts.Spec.ServiceName)
+	assert.Equal(t, "carts-db-primary", sts.Spec.Selector.MatchLabels["app"])
+	assert.Equal(t, "carts-db-primary", sts.Spec.Template.ObjectMeta.Labels["app"])
+	assert.Assert(t, strings.Contains(string(primary.Data), "keptn_deployment=primary"))
+}
+
+func TestGenerateDuplicateManagedChartWithDaemonSet(t *testing.T) {
+
+	h := NewGeneratedChartHandler(mesh.NewIstioMesh(), keptnevents.NewLogger("", "", "helm-service"))
+	_, err := h.GenerateDuplicateManagedChart(helmManifestStatefulSet, "sockshop-staging", "carts-db")
+	assert.ErrorContains(t, err, "DaemonSet carts-db-agent cannot be deployed")
+}
+
+func TestGenerateDuplicateManagedChartWithServiceSelectors(t *testing.T) {
+
+	// The services of the user release already select the primary pods
+	rendered, err := NewPrimaryServicePostRenderer().Run(bytes.NewBufferString(helmManifestResource))
+	assert.NilError(t, err)
+
+	h := NewGeneratedChartHandler(mesh.NewServiceSelectorMesh(), keptnevents.NewLogger("", "", "helm-service"))
+	ch, err := h.GenerateDuplicateManagedChart(rendered.String(), "sockshop-staging", "carts")
+	assert.NilError(t, err)
+
+	templates := []string{}
+	for _, template := range ch.Templates {
+		templates = append(templates, template.Name)
+		switch template.Name {
+		case "templates/carts-canary-service.yaml":
+			assert.Assert(t, strings.Contains(string(template.Data), "app: carts\n"), string(template.Data))
+		case "templates/carts-primary-service.yaml":
+			assert.Assert(t, strings.Contains(string(template.Data), "app: carts-primary\n"), string(template.Data))
+		}
+	}
+	assert.DeepEqual(t, getTemplateNames(templates), getTemplateNames([]string{
+		"templates/carts-canary-service.yaml",
+		"templates/carts-primary-service.yaml",
+		"templates/carts-primary-deployment.yaml",
+	}))
+}
@@ -1,11 +1,28 @@
 package helm
 
 import (
+	"time"
+
 	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/postrender"
 )
 
+// UpgradeOptions configures how a chart is installed or upgraded
+type UpgradeOptions struct {
+	// Atomic rolls back the release to its previous revision if the upgrade fails
+	Atomic bool
+	// Timeout limits the time to wait for the release, zero means no limit
+	Timeout time.Duration
+	// PostRenderer modifies the rendered manifests before they are applied, nil applies them unchanged
+	PostRenderer postrender.PostRenderer
+}
+
 // HelmExecutor is an interface for Helm operations
 type HelmExecutor interface {
-	GetManifest(releaseName string, namespace string) (string, error)
-	UpgradeChart(ch *chart.Chart, releaseName, namespace string, vals map[string]interface{}) error
+	GetManifest(releaseName string, target StageTarget) (string, error)
+	UpgradeChart(ch *chart.Chart, releaseName string, target StageTarget, vals map[string]interface{}, opts UpgradeOptions) error
+	RenderChart(ch *chart.Chart, releaseName string, target StageTarget, vals map[string]interface{}) (string, error)
+	UninstallRelease(releaseName string, target StageTarget) error
+	GetHistory(releaseName string, target StageTarget) ([]ReleaseRevision, error)
+	GetImageDigests(releaseName string, target StageTarget) (map[string]string, error)
 }
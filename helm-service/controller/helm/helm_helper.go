@@ -54,3 +54,47 @@ func GetDeployments(helmManifest string) []*appsv1.Deployment {
 	}
 	return deployments
 }
+
+// GetStatefulSets returns all statefulsets contained in the Helm manifest
+func GetStatefulSets(helmManifest string) []*appsv1.StatefulSet {
+
+	statefulSets := []*appsv1.StatefulSet{}
+	dec := kyaml.NewYAMLToJSONDecoder(strings.NewReader(helmManifest))
+	for {
+		var sts appsv1.StatefulSet
+		err := dec.Decode(&sts)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			continue
+		}
+
+		if strings.ToLower(sts.Kind) == "statefulset" {
+			statefulSets = append(statefulSets, &sts)
+		}
+	}
+	return statefulSets
+}
+
+// GetDaemonSets returns all daemonsets contained in the Helm manifest
+func GetDaemonSets(helmManifest string) []*appsv1.DaemonSet {
+
+	daemonSets := []*appsv1.DaemonSet{}
+	dec := kyaml.NewYAMLToJSONDecoder(strings.NewReader(helmManifest))
+	for {
+		var ds appsv1.DaemonSet
+		err := dec.Decode(&ds)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			continue
+		}
+
+		if strings.ToLower(ds.Kind) == "daemonset" {
+			daemonSets = append(daemonSets, &ds)
+		}
+	}
+	return daemonSets
+}
@@ -104,3 +104,76 @@ func TestGetDeployments(t *testing.T) {
 	deployments := GetDeployments(helmManifestResource)
 	assert.Equal(t, 1, len(deployments))
 }
+
+const helmManifestStatefulSet = `
+---
+# Source: carts-db/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: carts-db
+spec:
+  clusterIP: None
+  ports:
+  - name: mongo
+    port: 27017
+  selector:
+    app: carts-db
+---
+# Source: carts-db/templates/statefulset.yaml
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: carts-db
+spec:
+  serviceName: carts-db
+  replicas: 1
+  selector:
+    matchLabels:
+      app: carts-db
+  template:
+    metadata:
+      labels:
+        app: carts-db
+    spec:
+      containers:
+      - name: carts-db
+        image: "mongo:4.2"
+        env:
+        - name: DT_CUSTOM_PROP
+          value: "keptn_project=sockshop keptn_service=carts-db keptn_stage=dev keptn_deployment=canary"
+---
+# Source: carts-db/templates/daemonset.yaml
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: carts-db-agent
+spec:
+  selector:
+    matchLabels:
+      app: carts-db-agent
+  template:
+    metadata:
+      labels:
+        app: carts-db-agent
+    spec:
+      containers:
+      - name: agent
+        image: "agent:1.0"
+`
+
+func TestGetStatefulSets(t *testing.T) {
+
+	statefulSets := GetStatefulSets(helmManifestStatefulSet)
+	assert.Equal(t, 1, len(statefulSets))
+	assert.Equal(t, "carts-db", statefulSets[0].Name)
+	assert.Equal(t, 0, len(GetStatefulSets(helmManifestResource)))
+}
+
+func TestGetDaemonSets(t *testing.T) {
+
+	daemonSets := GetDaemonSets(helmManifestStatefulSet)
+	assert.Equal(t, 1, len(daemonSets))
+	assert.Equal(t, "carts-db-agent", daemonSets[0].Name)
+	assert.Equal(t, 0, len(GetDaemonSets(helmManifestResource)))
+}
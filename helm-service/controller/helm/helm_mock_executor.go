@@ -8,11 +8,17 @@ import (
 
 // HelmMockExecutor mocks Helm operations
 type HelmMockExecutor struct {
+	// UninstalledReleases contains the namespace and name of the uninstalled releases
+	UninstalledReleases []string
+	// Revisions contains the revisions returned by GetHistory, keyed by the namespace and name of the release
+	Revisions map[string][]ReleaseRevision
+	// ImageDigests contains the digests returned by GetImageDigests, keyed by the image
+	ImageDigests map[string]string
 }
 
 // NewHelmMockExecutor creates a new HelmMockExecutor
 func NewHelmMockExecutor() *HelmMockExecutor {
-	return &HelmMockExecutor{}
+	return &HelmMockExecutor{Revisions: map[string][]ReleaseRevision{}, ImageDigests: map[string]string{}}
 }
 
 const userService = `--- 
@@ -172,17 +178,45 @@ status: {}
 `
 
 // GetManifest returns test/sample manifests
-func (h *HelmMockExecutor) GetManifest(releaseName, namespace string) (string, error) {
+func (h *HelmMockExecutor) GetManifest(releaseName string, target StageTarget) (string, error) {
 
 	if strings.HasSuffix(releaseName, "-generated") {
 		genManifests := GeneratedPrimaryDeployment + GeneratedCanaryService + GeneratedPrimaryService + GeneratedCanaryDestinationRule +
 			GeneratedPrimaryDestinationRule + GeneratedVirtualService
-		return strings.ReplaceAll(genManifests, "NAMESPACE_PLACEHOLDER", namespace), nil
+		return strings.ReplaceAll(genManifests, "NAMESPACE_PLACEHOLDER", target.Namespace), nil
 	}
 	return userDeployment + userService, nil
 }
 
 // UpgradeChart does not execute any action
-func (h *HelmMockExecutor) UpgradeChart(ch *chart.Chart, releaseName, namespace string, vals map[string]interface{}) error {
+func (h *HelmMockExecutor) UpgradeChart(ch *chart.Chart, releaseName string, target StageTarget, vals map[string]interface{}, opts UpgradeOptions) error {
 	return nil
 }
+
+// RenderChart returns the unrendered templates of the chart
+func (h *HelmMockExecutor) RenderChart(ch *chart.Chart, releaseName string, target StageTarget, vals map[string]interface{}) (string, error) {
+	manifest := ""
+	for _, template := range ch.Templates {
+		manifest += string(template.Data)
+	}
+	return manifest, nil
+}
+
+// UninstallRelease records the uninstalled release
+func (h *HelmMockExecutor) UninstallRelease(releaseName string, target StageTarget) error {
+	h.UninstalledReleases = append(h.UninstalledReleases, target.Namespace+"/"+releaseName)
+	return nil
+}
+
+// GetHistory returns the revisions stored for the release
+func (h *HelmMockExecutor) GetHistory(releaseName string, target StageTarget) ([]ReleaseRevision, error) {
+	if revisions, ok := h.Revisions[target.Namespace+"/"+releaseName]; ok {
+		return revisions, nil
+	}
+	return []ReleaseRevision{}, nil
+}
+
+// GetImageDigests returns the stored image digests
+func (h *HelmMockExecutor) GetImageDigests(releaseName string, target StageTarget) (map[string]string, error) {
+	return h.ImageDigests, nil
+}
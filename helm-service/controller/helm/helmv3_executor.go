@@ -3,7 +3,7 @@ package helm
 import (
 	"fmt"
 	"os"
-	"path/filepath"
+	"sort"
 
 	"helm.sh/helm/v3/pkg/release"
 
@@ -15,12 +15,9 @@ import (
 	"helm.sh/helm/v3/pkg/kube"
 	"helm.sh/helm/v3/pkg/storage"
 	"helm.sh/helm/v3/pkg/storage/driver"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
-
-	keptnutils "github.com/keptn/kubernetes-utils/pkg"
 )
 
 func getInClusterConfig() bool {
@@ -42,11 +39,10 @@ func NewHelmV3Executor(logger keptnevents.LoggerInterface) *HelmV3Executor {
 
 func (h *HelmV3Executor) newActionConfig(config *rest.Config, namespace string) (*action.Configuration, error) {
 
-	logFunc := func(format string, v ...interface{}) {
-		fmt.Sprintf(format, v)
-	}
+	// Helm's debug output is discarded
+	logFunc := func(format string, v ...interface{}) {}
 
-	restClientGetter := h.newConfigFlags(config, namespace)
+	restClientGetter := newRESTClientGetter(config, namespace)
 	kubeClient := &kube.Client{
 		Factory: cmdutil.NewFactory(restClientGetter),
 		Log:     logFunc,
@@ -67,32 +63,11 @@ func (h *HelmV3Executor) newActionConfig(config *rest.Config, namespace string)
 	}, nil
 }
 
-func (h *HelmV3Executor) newConfigFlags(config *rest.Config, namespace string) *genericclioptions.ConfigFlags {
-	return &genericclioptions.ConfigFlags{
-		Namespace:   &namespace,
-		APIServer:   &config.Host,
-		CAFile:      &config.CAFile,
-		BearerToken: &config.BearerToken,
-	}
-}
-
-func (h *HelmV3Executor) getKubeRestConfig() (config *rest.Config, err error) {
-
-	if getInClusterConfig() {
-		config, err = rest.InClusterConfig()
-	} else {
-		kubeconfig := filepath.Join(
-			keptnutils.UserHomeDir(), ".kube", "config",
-		)
-		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
-	}
-	return
-}
-
 // GetManifest returns the manifest for the provided release
-func (h *HelmV3Executor) GetManifest(releaseName, namespace string) (string, error) {
+func (h *HelmV3Executor) GetManifest(releaseName string, target StageTarget) (string, error) {
 
-	config, err := h.getKubeRestConfig()
+	namespace := target.Namespace
+	config, err := GetRestConfig(target)
 	if err != nil {
 		return "", err
 	}
@@ -110,12 +85,14 @@ func (h *HelmV3Executor) GetManifest(releaseName, namespace string) (string, err
 	return release.Manifest, nil
 }
 
-// UpgradeChart upgrades the provided chart and waits for all deployments
-func (h *HelmV3Executor) UpgradeChart(ch *chart.Chart, releaseName, namespace string, vals map[string]interface{}) error {
+// UpgradeChart upgrades the provided chart and waits for all deployments.
+// If the upgrade is atomic, a failed release is rolled back to its previous revision.
+func (h *HelmV3Executor) UpgradeChart(ch *chart.Chart, releaseName string, target StageTarget, vals map[string]interface{}, opts UpgradeOptions) error {
 
 	if len(ch.Templates) > 0 {
+		namespace := target.Namespace
 		h.logger.Info(fmt.Sprintf("Start upgrading chart %s in namespace %s", releaseName, namespace))
-		config, err := h.getKubeRestConfig()
+		config, err := GetRestConfig(target)
 		if err != nil {
 			return err
 		}
@@ -127,17 +104,25 @@ func (h *HelmV3Executor) UpgradeChart(ch *chart.Chart, releaseName, namespace st
 		histClient := action.NewHistory(cfg)
 		var release *release.Release
 
+		installed := true
 		if _, err = histClient.Run(releaseName); err == driver.ErrReleaseNotFound {
+			installed = false
 			iCli := action.NewInstall(cfg)
 			iCli.Namespace = namespace
 			iCli.ReleaseName = releaseName
 			iCli.Wait = true
+			iCli.Atomic = opts.Atomic
+			iCli.Timeout = opts.Timeout
+			iCli.PostRenderer = opts.PostRenderer
 			release, err = iCli.Run(ch, vals)
 		} else {
 			iCli := action.NewUpgrade(cfg)
 			iCli.Namespace = namespace
 			iCli.Wait = true
 			iCli.ResetValues = true
+			iCli.Atomic = opts.Atomic
+			iCli.Timeout = opts.Timeout
+			iCli.PostRenderer = opts.PostRenderer
 			release, err = iCli.Run(releaseName, ch, vals)
 		}
 		if err != nil {
@@ -146,7 +131,10 @@ func (h *HelmV3Executor) UpgradeChart(ch *chart.Chart, releaseName, namespace st
 		}
 		if release != nil {
 			h.logger.Debug(release.Manifest)
-			if err := h.waitForDeploymentsOfHelmRelease(release.Manifest); err != nil {
+			if err := h.waitForWorkloadsOfHelmRelease(config, release.Manifest, namespace); err != nil {
+				if opts.Atomic {
+					return h.rollback(cfg, releaseName, namespace, installed, opts, err)
+				}
 				return err
 			}
 		} else {
@@ -159,11 +147,159 @@ func (h *HelmV3Executor) UpgradeChart(ch *chart.Chart, releaseName, namespace st
 	return nil
 }
 
-func (h *HelmV3Executor) waitForDeploymentsOfHelmRelease(helmManifest string) error {
+// UninstallRelease uninstalls the provided release. A release which is not installed is ignored.
+func (h *HelmV3Executor) UninstallRelease(releaseName string, target StageTarget) error {
+
+	namespace := target.Namespace
+	config, err := GetRestConfig(target)
+	if err != nil {
+		return err
+	}
+	cfg, err := h.newActionConfig(config, namespace)
+	if err != nil {
+		return err
+	}
+
+	histClient := action.NewHistory(cfg)
+	if _, err := histClient.Run(releaseName); err == driver.ErrReleaseNotFound {
+		h.logger.Debug(fmt.Sprintf("Uninstall not done as release %s in namespace %s is not installed", releaseName, namespace))
+		return nil
+	}
+
+	h.logger.Info(fmt.Sprintf("Start uninstalling chart %s in namespace %s", releaseName, namespace))
+	uCli := action.NewUninstall(cfg)
+	if _, err := uCli.Run(releaseName); err != nil {
+		return fmt.Errorf("Error when uninstalling chart %s in namespace %s: %s", releaseName, namespace, err.Error())
+	}
+	h.logger.Info(fmt.Sprintf("Finished uninstalling chart %s in namespace %s", releaseName, namespace))
+	return nil
+}
+
+// GetHistory returns the revisions of the provided release, starting with the oldest one.
+// A release which is not installed has no revisions.
+func (h *HelmV3Executor) GetHistory(releaseName string, target StageTarget) ([]ReleaseRevision, error) {
+
+	namespace := target.Namespace
+	config, err := GetRestConfig(target)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := h.newActionConfig(config, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	releases, err := action.NewHistory(cfg).Run(releaseName)
+	if err == driver.ErrReleaseNotFound {
+		return []ReleaseRevision{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error when querying the history of chart %s in namespace %s: %s",
+			releaseName, namespace, err.Error())
+	}
+
+	revisions := []ReleaseRevision{}
+	for _, rel := range releases {
+		revision := ReleaseRevision{
+			Release:  rel.Name,
+			Revision: rel.Version,
+			Images:   GetImages(rel.Manifest),
+		}
+		if rel.Info != nil {
+			revision.Status = rel.Info.Status.String()
+			revision.Description = rel.Info.Description
+			revision.Deployed = rel.Info.LastDeployed.Time
+		}
+		revisions = append(revisions, revision)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+// GetImageDigests returns the digests of the images run by the provided release, keyed by the image of the manifest
+func (h *HelmV3Executor) GetImageDigests(releaseName string, target StageTarget) (map[string]string, error) {
+
+	manifest, err := h.GetManifest(releaseName, target)
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := GetClientset(target)
+	if err != nil {
+		return nil, err
+	}
+	return GetImageDigests(clientset, manifest, target.Namespace)
+}
+
+// RenderChart renders the manifest of the provided chart by a dry-run of its installation or upgrade
+func (h *HelmV3Executor) RenderChart(ch *chart.Chart, releaseName string, target StageTarget, vals map[string]interface{}) (string, error) {
+
+	namespace := target.Namespace
+	config, err := GetRestConfig(target)
+	if err != nil {
+		return "", err
+	}
+	cfg, err := h.newActionConfig(config, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	histClient := action.NewHistory(cfg)
+	var release *release.Release
+
+	if _, err = histClient.Run(releaseName); err == driver.ErrReleaseNotFound {
+		iCli := action.NewInstall(cfg)
+		iCli.Namespace = namespace
+		iCli.ReleaseName = releaseName
+		iCli.DryRun = true
+		release, err = iCli.Run(ch, vals)
+	} else {
+		iCli := action.NewUpgrade(cfg)
+		iCli.Namespace = namespace
+		iCli.ResetValues = true
+		iCli.DryRun = true
+		release, err = iCli.Run(releaseName, ch, vals)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error when rendering chart %s in namespace %s: %s",
+			releaseName, namespace, err.Error())
+	}
+	if release == nil {
+		return "", nil
+	}
+	return release.Manifest, nil
+}
+
+// rollback restores the previous revision of the release or uninstalls the release if it was newly installed
+func (h *HelmV3Executor) rollback(cfg *action.Configuration, releaseName, namespace string, installed bool,
+	opts UpgradeOptions, cause error) error {
+
+	if !installed {
+		h.logger.Info(fmt.Sprintf("Uninstalling chart %s in namespace %s because the installation failed", releaseName, namespace))
+		uCli := action.NewUninstall(cfg)
+		uCli.Timeout = opts.Timeout
+		if _, err := uCli.Run(releaseName); err != nil {
+			return fmt.Errorf("%s and uninstalling chart %s failed: %s", cause.Error(), releaseName, err.Error())
+		}
+		return fmt.Errorf("%s; chart %s was uninstalled", cause.Error(), releaseName)
+	}
+
+	h.logger.Info(fmt.Sprintf("Rolling back chart %s in namespace %s because the upgrade failed", releaseName, namespace))
+	rCli := action.NewRollback(cfg)
+	rCli.Wait = true
+	rCli.Timeout = opts.Timeout
+	if err := rCli.Run(releaseName); err != nil {
+		return fmt.Errorf("%s and rolling back chart %s failed: %s", cause.Error(), releaseName, err.Error())
+	}
+	return fmt.Errorf("%s; chart %s was rolled back to its previous revision", cause.Error(), releaseName)
+}
+
+func (h *HelmV3Executor) waitForDeploymentsOfHelmRelease(clientset kubernetes.Interface, helmManifest string, namespace string) error {
 	depls := GetDeployments(helmManifest)
 	for _, depl := range depls {
-		if err := keptnutils.WaitForDeploymentToBeRolledOut(getInClusterConfig(), depl.Name, depl.Namespace); err != nil {
-			return fmt.Errorf("Error when waiting for deployment %s in namespace %s: %s", depl.Name, depl.Namespace, err.Error())
+		deplNamespace := getWorkloadNamespace(depl, namespace)
+		if err := waitForDeploymentToBeRolledOut(clientset, depl.Name, deplNamespace); err != nil {
+			return fmt.Errorf("Error when waiting for deployment %s in namespace %s: %s", depl.Name, deplNamespace, err.Error())
 		}
 	}
 	return nil
@@ -0,0 +1,94 @@
+package helm
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// getImageDigest returns the digest of the image ID reported by the container runtime,
+// e.g. sha256:2f8a... for docker-pullable://docker.io/keptnexamples/carts@sha256:2f8a...
+func getImageDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		return imageID[i+1:]
+	}
+	return ""
+}
+
+// GetImageDigests returns the digests of the images run by the deployments and statefulsets of the Helm manifest,
+// keyed by the image as specified in the manifest. The digests are read from the ready pods running the specified image.
+func GetImageDigests(clientset kubernetes.Interface, helmManifest string, namespace string) (map[string]string, error) {
+
+	type workload struct {
+		namespace  string
+		selector   *metav1.LabelSelector
+		containers []corev1.Container
+	}
+	workloads := []workload{}
+	for _, depl := range GetDeployments(helmManifest) {
+		workloads = append(workloads, workload{getWorkloadNamespace(depl, namespace), depl.Spec.Selector, depl.Spec.Template.Spec.Containers})
+	}
+	for _, sts := range GetStatefulSets(helmManifest) {
+		workloads = append(workloads, workload{getWorkloadNamespace(sts, namespace), sts.Spec.Selector, sts.Spec.Template.Spec.Containers})
+	}
+
+	digests := map[string]string{}
+	for _, w := range workloads {
+		if w.selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(w.selector)
+		if err != nil {
+			return nil, err
+		}
+		pods, err := clientset.CoreV1().Pods(w.namespace).List(metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, fmt.Errorf("error when listing pods in namespace %s: %v", w.namespace, err)
+		}
+		for _, container := range w.containers {
+			if digest := getContainerImageDigest(pods.Items, container); digest != "" {
+				digests[container.Image] = digest
+			}
+		}
+	}
+	return digests, nil
+}
+
+// getContainerImageDigest returns the image digest of the container of the first ready pod running its image
+func getContainerImageDigest(pods []corev1.Pod, container corev1.Container) string {
+
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || !isPodReady(&pod) {
+			continue
+		}
+		runsImage := false
+		for _, c := range pod.Spec.Containers {
+			if c.Name == container.Name && c.Image == container.Image {
+				runsImage = true
+			}
+		}
+		if !runsImage {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == container.Name {
+				if digest := getImageDigest(status.ImageID); digest != "" {
+					return digest
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
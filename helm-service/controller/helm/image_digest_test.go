@@ -0,0 +1,63 @@
+package helm
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const digestTestManifest = `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: carts
+spec:
+  selector:
+    matchLabels:
+      app: carts
+  template:
+    metadata:
+      labels:
+        app: carts
+    spec:
+      containers:
+      - name: carts
+        image: docker.io/keptnexamples/carts:0.11.1
+`
+
+func getDigestTestPod(name string, image string, imageID string, ready corev1.ConditionStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "sockshop-dev", Labels: map[string]string{"app": "carts"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "carts", Image: image}}},
+		Status: corev1.PodStatus{
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "carts", Image: image, ImageID: imageID}},
+		},
+	}
+}
+
+func TestGetImageDigests(t *testing.T) {
+
+	clientset := fake.NewSimpleClientset(
+		getDigestTestPod("carts-old", "docker.io/keptnexamples/carts:0.11.0",
+			"docker-pullable://docker.io/keptnexamples/carts@sha256:1111", corev1.ConditionTrue),
+		getDigestTestPod("carts-starting", "docker.io/keptnexamples/carts:0.11.1",
+			"docker-pullable://docker.io/keptnexamples/carts@sha256:3333", corev1.ConditionFalse),
+		getDigestTestPod("carts-new", "docker.io/keptnexamples/carts:0.11.1",
+			"docker-pullable://docker.io/keptnexamples/carts@sha256:2222", corev1.ConditionTrue),
+	)
+
+	digests, err := GetImageDigests(clientset, digestTestManifest, "sockshop-dev")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]string{"docker.io/keptnexamples/carts:0.11.1": "sha256:2222"}, digests)
+}
+
+func TestGetImageDigestsWithoutPods(t *testing.T) {
+
+	digests, err := GetImageDigests(fake.NewSimpleClientset(), digestTestManifest, "sockshop-dev")
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(digests))
+}
@@ -0,0 +1,159 @@
+package helm
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Change types of an ObjectDiff
+const (
+	ObjectAdded   = "added"
+	ObjectRemoved = "removed"
+	ObjectChanged = "changed"
+)
+
+// ObjectDiff describes how a Kubernetes object of a release changes
+type ObjectDiff struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Change    string `json:"change"`
+	// Fields contains the paths of the changed fields, e.g. spec.template.spec.containers[0].image
+	Fields []string `json:"fields,omitempty"`
+}
+
+// ManifestDiff describes the changes of all Kubernetes objects of a release
+type ManifestDiff struct {
+	Release string       `json:"release"`
+	Objects []ObjectDiff `json:"objects"`
+}
+
+type manifestObject struct {
+	kind      string
+	namespace string
+	name      string
+	content   map[string]interface{}
+}
+
+func (o manifestObject) key() string {
+	return o.kind + "/" + o.namespace + "/" + o.name
+}
+
+// DiffManifests compares the currently deployed manifest with the desired manifest of a release.
+// Documents which cannot be parsed as Kubernetes objects are ignored.
+func DiffManifests(release string, currentManifest string, desiredManifest string) ManifestDiff {
+
+	current := getManifestObjects(currentManifest)
+	desired := getManifestObjects(desiredManifest)
+
+	diff := ManifestDiff{Release: release, Objects: []ObjectDiff{}}
+	for key, desiredObj := range desired {
+		currentObj, ok := current[key]
+		if !ok {
+			diff.Objects = append(diff.Objects, newObjectDiff(desiredObj, ObjectAdded, nil))
+			continue
+		}
+		fields := []string{}
+		diffFields("", currentObj.content, desiredObj.content, &fields)
+		if len(fields) > 0 {
+			sort.Strings(fields)
+			diff.Objects = append(diff.Objects, newObjectDiff(desiredObj, ObjectChanged, fields))
+		}
+	}
+	for key, currentObj := range current {
+		if _, ok := desired[key]; !ok {
+			diff.Objects = append(diff.Objects, newObjectDiff(currentObj, ObjectRemoved, nil))
+		}
+	}
+
+	sort.Slice(diff.Objects, func(i, j int) bool {
+		a, b := diff.Objects[i], diff.Objects[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return diff
+}
+
+// HasChanges returns true if at least one object of the release changes
+func (d ManifestDiff) HasChanges() bool {
+	return len(d.Objects) > 0
+}
+
+func newObjectDiff(obj manifestObject, change string, fields []string) ObjectDiff {
+	return ObjectDiff{Kind: obj.kind, Namespace: obj.namespace, Name: obj.name, Change: change, Fields: fields}
+}
+
+func getManifestObjects(manifest string) map[string]manifestObject {
+
+	objects := make(map[string]manifestObject)
+	dec := kyaml.NewYAMLToJSONDecoder(strings.NewReader(manifest))
+	for {
+		content := make(map[string]interface{})
+		err := dec.Decode(&content)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			continue
+		}
+
+		kind, _ := content["kind"].(string)
+		metadata, _ := content["metadata"].(map[string]interface{})
+		if kind == "" || metadata == nil {
+			continue
+		}
+		name, _ := metadata["name"].(string)
+		namespace, _ := metadata["namespace"].(string)
+		obj := manifestObject{kind: kind, namespace: namespace, name: name, content: content}
+		objects[obj.key()] = obj
+	}
+	return objects
+}
+
+// diffFields adds the paths of all fields which differ between current and desired
+func diffFields(path string, current interface{}, desired interface{}, fields *[]string) {
+
+	currentMap, isCurrentMap := current.(map[string]interface{})
+	desiredMap, isDesiredMap := desired.(map[string]interface{})
+	if isCurrentMap && isDesiredMap {
+		for k, v := range desiredMap {
+			diffFields(joinPath(path, k), currentMap[k], v, fields)
+		}
+		for k, v := range currentMap {
+			if _, ok := desiredMap[k]; !ok {
+				diffFields(joinPath(path, k), v, nil, fields)
+			}
+		}
+		return
+	}
+
+	currentSlice, isCurrentSlice := current.([]interface{})
+	desiredSlice, isDesiredSlice := desired.([]interface{})
+	if isCurrentSlice && isDesiredSlice && len(currentSlice) == len(desiredSlice) {
+		for i := range desiredSlice {
+			diffFields(fmt.Sprintf("%s[%d]", path, i), currentSlice[i], desiredSlice[i], fields)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(current, desired) {
+		*fields = append(*fields, path)
+	}
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,100 @@
+package helm
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const currentDiffManifest = `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: carts
+  namespace: sockshop-dev
+spec:
+  ports:
+  - port: 80
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: carts
+  namespace: sockshop-dev
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: carts
+        image: docker.io/keptnexamples/carts:0.10.1
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: carts-config
+  namespace: sockshop-dev
+data:
+  key: value
+`
+
+const desiredDiffManifest = `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: carts
+  namespace: sockshop-dev
+spec:
+  ports:
+  - port: 80
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: carts
+  namespace: sockshop-dev
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: carts
+        image: docker.io/keptnexamples/carts:0.10.2
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: carts-secret
+  namespace: sockshop-dev
+`
+
+func TestDiffManifests(t *testing.T) {
+
+	diff := DiffManifests("sockshop-dev-carts", currentDiffManifest, desiredDiffManifest)
+
+	assert.Assert(t, diff.HasChanges())
+	assert.DeepEqual(t, diff, ManifestDiff{
+		Release: "sockshop-dev-carts",
+		Objects: []ObjectDiff{
+			{Kind: "ConfigMap", Namespace: "sockshop-dev", Name: "carts-config", Change: ObjectRemoved},
+			{Kind: "Deployment", Namespace: "sockshop-dev", Name: "carts", Change: ObjectChanged,
+				Fields: []string{"spec.replicas", "spec.template.spec.containers[0].image"}},
+			{Kind: "Secret", Namespace: "sockshop-dev", Name: "carts-secret", Change: ObjectAdded},
+		},
+	})
+}
+
+func TestDiffManifestsWithoutChanges(t *testing.T) {
+
+	diff := DiffManifests("sockshop-dev-carts", currentDiffManifest, currentDiffManifest)
+	assert.Assert(t, !diff.HasChanges())
+}
+
+func TestDiffManifestsOfNewRelease(t *testing.T) {
+
+	diff := DiffManifests("sockshop-dev-carts", "", desiredDiffManifest)
+	assert.Equal(t, 3, len(diff.Objects))
+	for _, obj := range diff.Objects {
+		assert.Equal(t, ObjectAdded, obj.Change)
+	}
+}
@@ -0,0 +1,72 @@
+package helm
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// NamespaceConfigURI is the project resource defining the namespaces of the stages
+const NamespaceConfigURI = "namespaces.yaml"
+
+// NamespaceConfig defines the namespaces of the stages of a project. A namespace name may contain the
+// placeholders ${project} and ${stage}.
+type NamespaceConfig struct {
+	// Namespace is the name template of stages without own template, defaults to ${project}-${stage}
+	Namespace string           `json:"namespace,omitempty"`
+	Stages    []StageNamespace `json:"stages,omitempty"`
+}
+
+// StageNamespace defines the namespace of a stage
+type StageNamespace struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Resources contains the policy objects created in the namespace, e.g. ResourceQuotas and NetworkPolicies
+	Resources []map[string]interface{} `json:"resources,omitempty"`
+}
+
+// ParseNamespaceConfig parses and validates the namespace config of a project
+func ParseNamespaceConfig(data []byte) (*NamespaceConfig, error) {
+
+	config := &NamespaceConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("error when parsing %s: %v", NamespaceConfigURI, err)
+	}
+	for _, stage := range config.Stages {
+		if stage.Name == "" {
+			return nil, fmt.Errorf("%s contains a stage without name", NamespaceConfigURI)
+		}
+		for _, resource := range stage.Resources {
+			if kind, _ := resource["kind"].(string); kind == "" {
+				return nil, fmt.Errorf("%s contains a resource without kind in stage %s", NamespaceConfigURI, stage.Name)
+			}
+		}
+	}
+	return config, nil
+}
+
+// GetStageNamespace returns the namespace definition of the stage, which contains the resolved namespace name.
+// A nil config resolves the namespace ${project}-${stage}.
+func (c *NamespaceConfig) GetStageNamespace(project string, stage string) StageNamespace {
+
+	result := StageNamespace{Name: stage}
+	template := "${project}-${stage}"
+	if c != nil {
+		if c.Namespace != "" {
+			template = c.Namespace
+		}
+		for _, s := range c.Stages {
+			if s.Name == stage {
+				result = s
+				if s.Namespace != "" {
+					template = s.Namespace
+				}
+			}
+		}
+	}
+	result.Namespace = strings.NewReplacer("${project}", project, "${stage}", stage).Replace(template)
+	return result
+}
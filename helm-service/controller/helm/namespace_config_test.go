@@ -0,0 +1,45 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const namespaceConfig = `namespace: team-a-${stage}
+stages:
+- name: production
+  namespace: team-a-prod
+  labels:
+    team: a
+  resources:
+  - apiVersion: v1
+    kind: ResourceQuota
+    metadata:
+      name: quota
+`
+
+func TestGetStageNamespace(t *testing.T) {
+
+	assert.Equal(t, "sockshop-dev", GetUmbrellaNamespace("sockshop", "dev", nil))
+
+	config, err := ParseNamespaceConfig([]byte(namespaceConfig))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "team-a-dev", GetUmbrellaNamespace("sockshop", "dev", config))
+	production := config.GetStageNamespace("sockshop", "production")
+	assert.Equal(t, "team-a-prod", production.Namespace)
+	assert.Equal(t, map[string]string{"team": "a"}, production.Labels)
+	assert.Equal(t, 1, len(production.Resources))
+	assert.Equal(t, "other-dev", GetUmbrellaNamespace("other", "dev", nil))
+	assert.Equal(t, "sockshop-production", GetUmbrellaNamespace("sockshop", "production", nil))
+}
+
+func TestParseNamespaceConfigInvalid(t *testing.T) {
+
+	_, err := ParseNamespaceConfig([]byte("stages:\n- namespace: team-a-prod\n"))
+	assert.Error(t, err)
+
+	_, err = ParseNamespaceConfig([]byte("stages:\n- name: production\n  resources:\n  - metadata:\n      name: quota\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,28 @@
+package helm
+
+import (
+	"time"
+)
+
+// ReleaseRevision describes a revision of a Helm release as stored by Helm
+type ReleaseRevision struct {
+	Release     string    `json:"release"`
+	Revision    int       `json:"revision"`
+	Status      string    `json:"status"`
+	Description string    `json:"description,omitempty"`
+	Deployed    time.Time `json:"deployed"`
+	// Images contains the container images of the deployments of the revision
+	Images []string `json:"images,omitempty"`
+}
+
+// GetImages returns the container images of all deployments contained in the Helm manifest
+func GetImages(helmManifest string) []string {
+
+	images := []string{}
+	for _, depl := range GetDeployments(helmManifest) {
+		for _, container := range depl.Spec.Template.Spec.Containers {
+			images = append(images, container.Image)
+		}
+	}
+	return images
+}
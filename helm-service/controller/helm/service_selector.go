@@ -0,0 +1,100 @@
+package helm
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/yaml"
+)
+
+var manifestSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// PrimaryServicePostRenderer is a Helm post-renderer which points the selectors of the services to the primary pods.
+// It is used for user charts whose services are switched between the primary and the canary pods, so that upgrading
+// the user chart does not route the traffic to the canary.
+type PrimaryServicePostRenderer struct {
+}
+
+// NewPrimaryServicePostRenderer creates a new PrimaryServicePostRenderer
+func NewPrimaryServicePostRenderer() *PrimaryServicePostRenderer {
+	return &PrimaryServicePostRenderer{}
+}
+
+// Run adds the suffix -primary to the app labels of the selectors of all services contained in the rendered manifests
+func (*PrimaryServicePostRenderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+
+	result := &bytes.Buffer{}
+	for _, doc := range manifestSeparator.Split(renderedManifests.String(), -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return nil, fmt.Errorf("error when parsing rendered manifest: %v", err)
+		}
+		if kind, _ := obj["kind"].(string); kind == "Service" {
+			if spec, ok := obj["spec"].(map[string]interface{}); ok {
+				if selector, ok := spec["selector"].(map[string]interface{}); ok {
+					for _, key := range []string{"app", "app.kubernetes.io/name"} {
+						if value, ok := selector[key].(string); ok && !strings.HasSuffix(value, "-primary") {
+							selector[key] = value + "-primary"
+						}
+					}
+				}
+			}
+			data, err := yaml.Marshal(obj)
+			if err != nil {
+				return nil, err
+			}
+			doc = "\n" + string(data)
+		}
+		result.WriteString("---" + strings.TrimRight(doc, "\n") + "\n")
+	}
+	return result, nil
+}
+
+// removePrimarySuffix removes the suffix -primary from the app labels used to select the pods
+func removePrimarySuffix(labels map[string]string) {
+	for _, key := range []string{"app", "app.kubernetes.io/name"} {
+		if value, ok := labels[key]; ok {
+			labels[key] = strings.TrimSuffix(value, "-primary")
+		}
+	}
+}
+
+// SwitchServiceSelectors points the selectors of the services contained in the Helm manifest either to the primary
+// or to the canary pods, which are selected by the labels of the user chart
+func SwitchServiceSelectors(clientset kubernetes.Interface, helmManifest string, namespace string, primary bool) error {
+
+	for _, svc := range GetServices(helmManifest) {
+		services := clientset.CoreV1().Services(getWorkloadNamespace(svc, namespace))
+
+		selector := map[string]string{}
+		for k, v := range svc.Spec.Selector {
+			selector[k] = v
+		}
+		removePrimarySuffix(selector)
+		if primary {
+			addPrimarySuffix(selector)
+		}
+		// The service is read again if it was changed concurrently, e.g., by a Helm upgrade
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			liveSvc, err := services.Get(svc.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			liveSvc.Spec.Selector = selector
+			_, err = services.Update(liveSvc)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("error when switching the selector of service %s: %v", svc.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package helm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestPrimaryServicePostRenderer(t *testing.T) {
+
+	rendered, err := NewPrimaryServicePostRenderer().Run(bytes.NewBufferString(helmManifestResource))
+	assert.NilError(t, err)
+
+	services := GetServices(rendered.String())
+	assert.Equal(t, 1, len(services))
+	assert.Equal(t, "carts-primary", services[0].Spec.Selector["app"])
+
+	// The pods of the user chart are not changed
+	deployments := GetDeployments(rendered.String())
+	assert.Equal(t, 1, len(deployments))
+	assert.Equal(t, "carts", deployments[0].Spec.Template.Labels["app"])
+
+	// Rendering again does not add another suffix
+	again, err := NewPrimaryServicePostRenderer().Run(rendered)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(again.String(), "carts-primary-primary"))
+}
+
+func TestSwitchServiceSelectors(t *testing.T) {
+
+	clientset := fake.NewSimpleClientset(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "carts", Namespace: "sockshop-staging"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "carts-primary"}},
+	})
+	rendered, err := NewPrimaryServicePostRenderer().Run(bytes.NewBufferString(helmManifestResource))
+	assert.NilError(t, err)
+
+	assert.NilError(t, SwitchServiceSelectors(clientset, rendered.String(), "sockshop-staging", false))
+	svc, err := clientset.CoreV1().Services("sockshop-staging").Get("carts", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]string{"app": "carts"}, svc.Spec.Selector)
+
+	assert.NilError(t, SwitchServiceSelectors(clientset, rendered.String(), "sockshop-staging", true))
+	svc, err = clientset.CoreV1().Services("sockshop-staging").Get("carts", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]string{"app": "carts-primary"}, svc.Spec.Selector)
+}
+
+func TestSwitchServiceSelectorsRetriesOnConflict(t *testing.T) {
+
+	clientset := fake.NewSimpleClientset(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "carts", Namespace: "sockshop-staging"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "carts-primary"}},
+	})
+	conflicts := 0
+	clientset.PrependReactor("update", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicts > 0 {
+			return false, nil, nil
+		}
+		conflicts++
+		return true, nil, k8serrors.NewConflict(schema.GroupResource{Resource: "services"}, "carts", nil)
+	})
+	rendered, err := NewPrimaryServicePostRenderer().Run(bytes.NewBufferString(helmManifestResource))
+	assert.NilError(t, err)
+
+	assert.NilError(t, SwitchServiceSelectors(clientset, rendered.String(), "sockshop-staging", false))
+	assert.Equal(t, 1, conflicts)
+	svc, err := clientset.CoreV1().Services("sockshop-staging").Get("carts", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]string{"app": "carts"}, svc.Spec.Selector)
+}
@@ -230,6 +230,67 @@ func (u *UmbrellaChartHandler) AddChartInUmbrellaValues(project string, helmChar
 	return nil
 }
 
+// RemoveChartFromUmbrellaRequirements removes the chart from the requirements.yaml of the Umbrella chart
+func (u *UmbrellaChartHandler) RemoveChartFromUmbrellaRequirements(project string, helmChartName string, stage string) error {
+
+	rHandler := configutils.NewResourceHandler(u.configServiceURL)
+
+	resource, err := rHandler.GetStageResource(project, stage, requirementsURI)
+	if err != nil {
+		return err
+	}
+
+	requirements := Requirements{}
+	err = yaml.Unmarshal([]byte(resource.ResourceContent), &requirements)
+	if err != nil {
+		return err
+	}
+
+	dependencies := []RequirementDependencies{}
+	for _, dependency := range requirements.Dependencies {
+		if dependency.Name != helmChartName {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	requirements.Dependencies = dependencies
+
+	requirementsData, err := yaml.Marshal(requirements)
+	if err != nil {
+		return err
+	}
+	resource.ResourceContent = string(requirementsData)
+
+	_, err = rHandler.CreateStageResources(project, stage, []*configmodels.Resource{resource})
+	return err
+}
+
+// RemoveChartFromUmbrellaValues removes the chart from the values.yaml of the Umbrella chart
+func (u *UmbrellaChartHandler) RemoveChartFromUmbrellaValues(project string, helmChartName string, stage string) error {
+
+	rHandler := configutils.NewResourceHandler(u.configServiceURL)
+
+	resource, err := rHandler.GetStageResource(project, stage, valuesURI)
+	if err != nil {
+		return err
+	}
+
+	values := Values{}
+	err = yaml.Unmarshal([]byte(resource.ResourceContent), &values)
+	if err != nil {
+		return err
+	}
+
+	delete(values, helmChartName)
+	valuesData, err := yaml.Marshal(values)
+	if err != nil {
+		return err
+	}
+	resource.ResourceContent = string(valuesData)
+
+	_, err = rHandler.CreateStageResources(project, stage, []*configmodels.Resource{resource})
+	return err
+}
+
 // IsUmbrellaChartAvailableInAllStages checks whether all stages contain a umbrella Helm Chart
 func (u *UmbrellaChartHandler) IsUmbrellaChartAvailableInAllStages(project string, stages []*configmodels.Stage) (bool, error) {
 
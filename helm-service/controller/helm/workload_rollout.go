@@ -0,0 +1,119 @@
+package helm
+
+import (
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// waitForDeploymentToBeRolledOut waits until all replicas of the deployment are updated and available
+func waitForDeploymentToBeRolledOut(clientset kubernetes.Interface, name string, namespace string) error {
+	for {
+		depl, err := clientset.AppsV1().Deployments(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		rolledOut, err := isDeploymentRolledOut(depl)
+		if err != nil || rolledOut {
+			return err
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+func isDeploymentRolledOut(depl *appsv1.Deployment) (bool, error) {
+	for _, cond := range depl.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			return false, fmt.Errorf("Deployment %q exceeded its progress deadline", depl.Name)
+		}
+	}
+	return !(depl.Spec.Replicas != nil && depl.Status.UpdatedReplicas < *depl.Spec.Replicas ||
+		depl.Status.Replicas > depl.Status.UpdatedReplicas ||
+		depl.Status.AvailableReplicas < depl.Status.UpdatedReplicas), nil
+}
+
+// waitForStatefulSetToBeRolledOut waits until all replicas of the statefulset are updated and ready
+func waitForStatefulSetToBeRolledOut(clientset kubernetes.Interface, name string, namespace string) error {
+	for {
+		sts, err := clientset.AppsV1().StatefulSets(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if isStatefulSetRolledOut(sts) {
+			return nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+func isStatefulSetRolledOut(sts *appsv1.StatefulSet) bool {
+	if sts.Status.ObservedGeneration < sts.Generation {
+		return false
+	}
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	if sts.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType &&
+		sts.Status.UpdateRevision != sts.Status.CurrentRevision && sts.Status.UpdatedReplicas < replicas {
+		return false
+	}
+	return sts.Status.ReadyReplicas >= replicas
+}
+
+// waitForDaemonSetToBeRolledOut waits until the daemonset runs an updated and available pod on every node
+func waitForDaemonSetToBeRolledOut(clientset kubernetes.Interface, name string, namespace string) error {
+	for {
+		ds, err := clientset.AppsV1().DaemonSets(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if isDaemonSetRolledOut(ds) {
+			return nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+func isDaemonSetRolledOut(ds *appsv1.DaemonSet) bool {
+	if ds.Status.ObservedGeneration < ds.Generation {
+		return false
+	}
+	return ds.Status.UpdatedNumberScheduled >= ds.Status.DesiredNumberScheduled &&
+		ds.Status.NumberAvailable >= ds.Status.DesiredNumberScheduled
+}
+
+// getWorkloadNamespace returns the namespace of the workload or the namespace of the release if none is set
+func getWorkloadNamespace(workload metav1.Object, releaseNamespace string) string {
+	if workload.GetNamespace() != "" {
+		return workload.GetNamespace()
+	}
+	return releaseNamespace
+}
+
+func (h *HelmV3Executor) waitForWorkloadsOfHelmRelease(config *rest.Config, helmManifest string, namespace string) error {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	if err := h.waitForDeploymentsOfHelmRelease(clientset, helmManifest, namespace); err != nil {
+		return err
+	}
+	for _, sts := range GetStatefulSets(helmManifest) {
+		stsNamespace := getWorkloadNamespace(sts, namespace)
+		if err := waitForStatefulSetToBeRolledOut(clientset, sts.Name, stsNamespace); err != nil {
+			return fmt.Errorf("Error when waiting for statefulset %s in namespace %s: %s", sts.Name, stsNamespace, err.Error())
+		}
+	}
+	for _, ds := range GetDaemonSets(helmManifest) {
+		dsNamespace := getWorkloadNamespace(ds, namespace)
+		if err := waitForDaemonSetToBeRolledOut(clientset, ds.Name, dsNamespace); err != nil {
+			return fmt.Errorf("Error when waiting for daemonset %s in namespace %s: %s", ds.Name, dsNamespace, err.Error())
+		}
+	}
+	return nil
+}
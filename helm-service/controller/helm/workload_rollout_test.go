@@ -0,0 +1,87 @@
+package helm
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func TestIsStatefulSetRolledOut(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		status appsv1.StatefulSetStatus
+		want   bool
+	}{
+		{
+			name:   "all replicas updated and ready",
+			status: appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdateRevision: "r2", CurrentRevision: "r2", UpdatedReplicas: 2, ReadyReplicas: 2},
+			want:   true,
+		},
+		{
+			name:   "generation not observed",
+			status: appsv1.StatefulSetStatus{ObservedGeneration: 1, UpdateRevision: "r2", CurrentRevision: "r2", UpdatedReplicas: 2, ReadyReplicas: 2},
+			want:   false,
+		},
+		{
+			name:   "update in progress",
+			status: appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdateRevision: "r2", CurrentRevision: "r1", UpdatedReplicas: 1, ReadyReplicas: 2},
+			want:   false,
+		},
+		{
+			name:   "replica not ready",
+			status: appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdateRevision: "r2", CurrentRevision: "r2", UpdatedReplicas: 2, ReadyReplicas: 1},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas:       int32Ptr(2),
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+				},
+				Status: tt.status,
+			}
+			assert.Equal(t, tt.want, isStatefulSetRolledOut(sts))
+		})
+	}
+}
+
+func TestIsDaemonSetRolledOut(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		status appsv1.DaemonSetStatus
+		want   bool
+	}{
+		{
+			name:   "all nodes updated and available",
+			status: appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+			want:   true,
+		},
+		{
+			name:   "update in progress",
+			status: appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 2, NumberAvailable: 3},
+			want:   false,
+		},
+		{
+			name:   "pod not available",
+			status: appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 2},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Generation: 1}, Status: tt.status}
+			assert.Equal(t, tt.want, isDaemonSetRolledOut(ds))
+		})
+	}
+}
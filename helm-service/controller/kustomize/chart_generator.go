@@ -0,0 +1,121 @@
+package kustomize
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const imagePlaceholder = "KEPTN_IMAGE_PLACEHOLDER"
+const replicasPlaceholder = "KEPTN_REPLICAS_PLACEHOLDER"
+
+// GenerateChart generates a Helm chart containing the objects of the manifest built by kustomize.
+// The image of the first container and the replicas of the workloads are set by the values image and
+// replicaCount, which allows to deploy new artifacts and to scale the workloads as for any other chart.
+// The provided values overwrite the values found in the manifest.
+func GenerateChart(service string, manifest string, values map[string]interface{}) (*chart.Chart, error) {
+
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: "v2",
+			Name:       service,
+			Keywords:   []string{Keyword},
+			Version:    "0.1.0",
+		},
+		Values: map[string]interface{}{},
+	}
+
+	dec := kyaml.NewYAMLToJSONDecoder(strings.NewReader(manifest))
+	for {
+		obj := make(map[string]interface{})
+		err := dec.Decode(&obj)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error when parsing kustomize output: %v", err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+
+		kind, _ := obj["kind"].(string)
+		metadata, _ := obj["metadata"].(map[string]interface{})
+		name, _ := metadata["name"].(string)
+		if kind == "" || name == "" {
+			return nil, fmt.Errorf("kustomize output contains an object without kind or name")
+		}
+
+		if isWorkload(kind) {
+			if err := templateWorkload(obj, ch.Values); err != nil {
+				return nil, fmt.Errorf("error when templating %s %s: %v", kind, name, err)
+			}
+		}
+
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		// The objects are rendered by Helm, hence template actions contained in the objects have to be escaped
+		content := strings.ReplaceAll(string(data), "{{", `{{ "{{" }}`)
+		content = strings.ReplaceAll(content, imagePlaceholder, "{{ .Values.image }}")
+		content = strings.ReplaceAll(content, replicasPlaceholder, "{{ .Values.replicaCount }}")
+
+		ch.Templates = append(ch.Templates, &chart.File{
+			Name: "templates/" + name + "-" + strings.ToLower(kind) + ".yaml",
+			Data: []byte(content),
+		})
+	}
+
+	for k, v := range values {
+		ch.Values[k] = v
+	}
+	valuesData, err := yaml.Marshal(ch.Values)
+	if err != nil {
+		return nil, err
+	}
+	ch.Raw = []*chart.File{{Name: "values.yaml", Data: valuesData}}
+	return ch, nil
+}
+
+func isWorkload(kind string) bool {
+	return kind == "Deployment" || kind == "StatefulSet" || kind == "DaemonSet"
+}
+
+// templateWorkload replaces the image of the first container and the replicas of the workload by placeholders
+// and stores their values
+func templateWorkload(obj map[string]interface{}, values map[string]interface{}) error {
+
+	spec, _ := obj["spec"].(map[string]interface{})
+	template, _ := spec["template"].(map[string]interface{})
+	podSpec, _ := template["spec"].(map[string]interface{})
+	containers, _ := podSpec["containers"].([]interface{})
+	if len(containers) == 0 {
+		return fmt.Errorf("no container found")
+	}
+	container, _ := containers[0].(map[string]interface{})
+	if image, ok := container["image"].(string); ok {
+		values["image"] = image
+		container["image"] = imagePlaceholder
+	}
+
+	if obj["kind"] == "DaemonSet" {
+		return nil
+	}
+	replicas := json.Number("1")
+	if r, ok := spec["replicas"]; ok {
+		replicas = json.Number(fmt.Sprintf("%v", r))
+	}
+	replicaCount, err := replicas.Int64()
+	if err != nil {
+		return fmt.Errorf("invalid replicas %v", spec["replicas"])
+	}
+	values["replicaCount"] = replicaCount
+	spec["replicas"] = replicasPlaceholder
+	return nil
+}
@@ -0,0 +1,78 @@
+package kustomize
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	kustomizebuild "k8s.io/cli-runtime/pkg/kustomize"
+	"sigs.k8s.io/kustomize/pkg/fs"
+)
+
+// Keyword marks a chart which contains a kustomization instead of templates
+const Keyword = "kustomize"
+
+// ResourcePrefix is the prefix of the service resources containing the kustomization of a stage
+const ResourcePrefix = "kustomize/"
+
+const kustomizationFile = "kustomization.yaml"
+
+// root is the directory of the in-memory file system the kustomization is built in
+const root = "/kustomization"
+
+// IsKustomizeChart checks whether the chart contains a kustomization or was generated from one
+func IsKustomizeChart(ch *chart.Chart) bool {
+	for _, keyword := range ch.Metadata.Keywords {
+		if keyword == Keyword {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStageFiles returns the base and the overlay of the stage contained in the files of the chart.
+// The file names are prefixed by ResourcePrefix.
+func GetStageFiles(ch *chart.Chart, stage string) map[string][]byte {
+
+	files := make(map[string][]byte)
+	for _, file := range ch.Files {
+		if strings.HasPrefix(file.Name, ResourcePrefix+"base/") ||
+			strings.HasPrefix(file.Name, ResourcePrefix+"overlays/"+stage+"/") {
+			files[file.Name] = file.Data
+		}
+	}
+	return files
+}
+
+// GetBuildPath returns the overlay of the stage if available and the base otherwise
+func GetBuildPath(files map[string][]byte, stage string) string {
+
+	overlay := ResourcePrefix + "overlays/" + stage
+	if _, ok := files[path.Join(overlay, kustomizationFile)]; ok {
+		return overlay
+	}
+	return ResourcePrefix + "base"
+}
+
+// Build builds the kustomization in the provided path and returns the resulting manifest
+func Build(files map[string][]byte, buildPath string) (string, error) {
+
+	if _, ok := files[path.Join(buildPath, kustomizationFile)]; !ok {
+		return "", fmt.Errorf("no %s found in %s", kustomizationFile, buildPath)
+	}
+
+	fSys := fs.MakeFakeFS()
+	for name, data := range files {
+		if err := fSys.WriteFile(path.Join(root, name), data); err != nil {
+			return "", err
+		}
+	}
+
+	var out bytes.Buffer
+	if err := kustomizebuild.RunKustomizeBuild(&out, fSys, path.Join(root, buildPath)); err != nil {
+		return "", fmt.Errorf("error when building kustomization %s: %v", buildPath, err)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,137 @@
+package kustomize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+)
+
+const baseKustomization = `resources:
+- deployment.yaml
+- service.yaml
+`
+
+const baseDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: carts
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: carts
+  template:
+    metadata:
+      labels:
+        app: carts
+      annotations:
+        description: "{{ not a template }}"
+    spec:
+      containers:
+      - name: carts
+        image: docker.io/keptnexamples/carts:0.10.1
+`
+
+const baseService = `apiVersion: v1
+kind: Service
+metadata:
+  name: carts
+spec:
+  ports:
+  - port: 80
+  selector:
+    app: carts
+`
+
+const productionKustomization = `bases:
+- ../../base
+patchesStrategicMerge:
+- replicas.yaml
+`
+
+const productionReplicas = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: carts
+spec:
+  replicas: 3
+`
+
+func getKustomizeChart() *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{Name: "carts", Version: "0.1.0", APIVersion: "v2", Keywords: []string{Keyword}},
+		Files: []*chart.File{
+			{Name: "kustomize/base/kustomization.yaml", Data: []byte(baseKustomization)},
+			{Name: "kustomize/base/deployment.yaml", Data: []byte(baseDeployment)},
+			{Name: "kustomize/base/service.yaml", Data: []byte(baseService)},
+			{Name: "kustomize/overlays/production/kustomization.yaml", Data: []byte(productionKustomization)},
+			{Name: "kustomize/overlays/production/replicas.yaml", Data: []byte(productionReplicas)},
+		},
+	}
+}
+
+func TestIsKustomizeChart(t *testing.T) {
+	assert.True(t, IsKustomizeChart(getKustomizeChart()))
+	assert.False(t, IsKustomizeChart(&chart.Chart{Metadata: &chart.Metadata{Name: "carts"}}))
+}
+
+func TestGetStageFiles(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		stage         string
+		wantFiles     int
+		wantBuildPath string
+	}{
+		{name: "stage with overlay", stage: "production", wantFiles: 5, wantBuildPath: "kustomize/overlays/production"},
+		{name: "stage without overlay", stage: "dev", wantFiles: 3, wantBuildPath: "kustomize/base"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := GetStageFiles(getKustomizeChart(), tt.stage)
+			assert.Equal(t, tt.wantFiles, len(files))
+			assert.Equal(t, tt.wantBuildPath, GetBuildPath(files, tt.stage))
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+
+	files := GetStageFiles(getKustomizeChart(), "production")
+	manifest, err := Build(files, GetBuildPath(files, "production"))
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(manifest, "replicas: 3"))
+	assert.True(t, strings.Contains(manifest, "kind: Service"))
+
+	_, err = Build(files, "kustomize/overlays/dev")
+	assert.NotNil(t, err)
+}
+
+func TestGenerateChart(t *testing.T) {
+
+	files := GetStageFiles(getKustomizeChart(), "production")
+	manifest, err := Build(files, GetBuildPath(files, "production"))
+	assert.Nil(t, err)
+
+	ch, err := GenerateChart("carts", manifest, map[string]interface{}{"image": "docker.io/keptnexamples/carts:0.11.1"})
+	assert.Nil(t, err)
+	assert.True(t, IsKustomizeChart(ch))
+	assert.Equal(t, 2, len(ch.Templates))
+	assert.Equal(t, "docker.io/keptnexamples/carts:0.11.1", ch.Values["image"])
+	assert.EqualValues(t, 3, ch.Values["replicaCount"])
+
+	// The generated chart renders the objects with the provided values
+	vals, err := chartutil.ToRenderValues(ch, ch.Values, chartutil.ReleaseOptions{Name: "carts"}, nil)
+	assert.Nil(t, err)
+	rendered, err := engine.Render(ch, vals)
+	assert.Nil(t, err)
+	deployment := rendered["carts/templates/carts-deployment.yaml"]
+	assert.True(t, strings.Contains(deployment, "image: docker.io/keptnexamples/carts:0.11.1"))
+	assert.True(t, strings.Contains(deployment, "replicas: 3"))
+	assert.True(t, strings.Contains(deployment, "{{ not a template }}"))
+}
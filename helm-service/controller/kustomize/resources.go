@@ -0,0 +1,52 @@
+package kustomize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+
+	"github.com/keptn/keptn/helm-service/pkg/serviceutils"
+)
+
+// StoreStageResources stores the kustomization of a stage as resources of the service
+func StoreStageResources(project string, stage string, service string, files map[string][]byte, configServiceURL string) error {
+
+	resources := []*models.Resource{}
+	for name, data := range files {
+		uri := name
+		resources = append(resources, &models.Resource{ResourceURI: &uri, ResourceContent: string(data)})
+	}
+
+	rHandler := configutils.NewResourceHandler(configServiceURL)
+	if _, err := rHandler.CreateServiceResources(project, stage, service, resources); err != nil {
+		return fmt.Errorf("error when storing kustomization of service %s in stage %s: %v", service, stage, err)
+	}
+	return nil
+}
+
+// GetStageResources returns the kustomization of a stage stored as resources of the service.
+// The kustomization is empty if the resources of the service cannot be listed.
+func GetStageResources(project string, stage string, service string, configServiceURL string) (map[string][]byte, error) {
+
+	rHandler := configutils.NewResourceHandler(configServiceURL)
+	resources, err := serviceutils.ListServiceResources(project, stage, service, configServiceURL)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading resources of service %s in stage %s: %v", service, stage, err)
+	}
+
+	files := make(map[string][]byte)
+	for _, resource := range resources {
+		if resource.ResourceURI == nil || !strings.HasPrefix(strings.TrimPrefix(*resource.ResourceURI, "/"), ResourcePrefix) {
+			continue
+		}
+		uri := strings.TrimPrefix(*resource.ResourceURI, "/")
+		content, err := rHandler.GetServiceResource(project, stage, service, uri)
+		if err != nil {
+			return nil, fmt.Errorf("error when reading resource %s of service %s in stage %s: %v", uri, service, stage, err)
+		}
+		files[uri] = []byte(content.ResourceContent)
+	}
+	return files, nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/keptn/keptn/helm-service/pkg/apis/networking/istio/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 )
@@ -86,3 +87,16 @@ func (*IstioMesh) GetDestinationRuleSuffix() string {
 func (*IstioMesh) GetVirtualServiceSuffix() string {
 	return "-istio-virtualservice.yaml"
 }
+
+// InjectNamespace enables the Istio sidecar injection for the namespace
+func (*IstioMesh) InjectNamespace(namespace *corev1.Namespace) {
+	if namespace.ObjectMeta.Labels == nil {
+		namespace.ObjectMeta.Labels = make(map[string]string)
+	}
+	namespace.ObjectMeta.Labels["istio-injection"] = "enabled"
+}
+
+// SplitsTraffic returns true because the traffic is split by weighted routes
+func (*IstioMesh) SplitsTraffic() bool {
+	return true
+}
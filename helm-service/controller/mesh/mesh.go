@@ -1,16 +1,71 @@
 package mesh
 
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
 // Mesh abstracts the underlying mesh router
 type Mesh interface {
+	// GenerateDestinationRule generates the manifest describing a destination.
+	// Returns nil if the mesh does not require such a manifest.
 	GenerateDestinationRule(name string, host string) ([]byte, error)
+	// GenerateVirtualService generates the manifest routing the traffic to the destinations.
+	// Returns nil if the mesh does not require such a manifest.
 	GenerateVirtualService(name string, gateways []string, hosts []string, httpRouteDestinations []HTTPRouteDestination) ([]byte, error)
 	UpdateWeights(virtualService []byte, canaryWeight int32) ([]byte, error)
 	GetDestinationRuleSuffix() string
 	GetVirtualServiceSuffix() string
+	// InjectNamespace prepares the namespace such that the mesh is injected into its pods
+	InjectNamespace(namespace *corev1.Namespace)
+	// SplitsTraffic returns whether the mesh splits the traffic between destinations.
+	// Meshes not splitting the traffic do not generate any routing manifests.
+	SplitsTraffic() bool
 }
 
 // HTTPRouteDestination helper struct for route destinations in a VirtualService
 type HTTPRouteDestination struct {
 	Host   string
 	Weight int32
+	// Port of the destination service, which is required by meshes not resolving the port on their own
+	Port int32
+}
+
+const (
+	// IstioMeshType identifies the Istio mesh
+	IstioMeshType = "istio"
+	// SMIMeshType identifies meshes implementing the SMI TrafficSplit, e.g. Linkerd
+	SMIMeshType = "smi"
+	// LinkerdMeshType identifies the Linkerd mesh, which is configured via SMI TrafficSplits
+	LinkerdMeshType = "linkerd"
+	// NginxMeshType identifies the ingress-nginx controller, which splits the ingress traffic via canary Ingresses
+	NginxMeshType = "nginx"
+	// NoneMeshType identifies the use without service mesh, where the selectors of the services are switched
+	NoneMeshType = "none"
+)
+
+// GetMeshType returns the configured mesh type
+func GetMeshType() string {
+	if os.Getenv("MESH") != "" {
+		return strings.ToLower(os.Getenv("MESH"))
+	}
+	return IstioMeshType
+}
+
+// NewMesh creates the mesh of the configured mesh type
+func NewMesh() (Mesh, error) {
+	switch GetMeshType() {
+	case IstioMeshType:
+		return NewIstioMesh(), nil
+	case SMIMeshType, LinkerdMeshType:
+		return NewSMIMesh(), nil
+	case NginxMeshType:
+		return NewNginxMesh(), nil
+	case NoneMeshType:
+		return NewServiceSelectorMesh(), nil
+	}
+	return nil, fmt.Errorf("unsupported mesh %s", GetMeshType())
 }
@@ -0,0 +1,136 @@
+package mesh
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const nginxIngressClassAnnotation = "kubernetes.io/ingress.class"
+const nginxCanaryAnnotation = "nginx.ingress.kubernetes.io/canary"
+const nginxCanaryWeightAnnotation = "nginx.ingress.kubernetes.io/canary-weight"
+
+// NginxMesh is an implementation of interface Mesh based on ingress-nginx. The ingress traffic is split
+// between a primary Ingress and a canary Ingress using the canary annotations of ingress-nginx.
+// Note that only the traffic entering the cluster via the ingress is split.
+type NginxMesh struct {
+}
+
+// NewNginxMesh generates a new ingress-nginx mesh
+func NewNginxMesh() *NginxMesh {
+	return &NginxMesh{}
+}
+
+// GenerateDestinationRule returns nil because ingress-nginx does not require destination rules
+func (*NginxMesh) GenerateDestinationRule(name string, host string) ([]byte, error) {
+	return nil, nil
+}
+
+// GenerateVirtualService generates an Ingress for the primary destination and, if available,
+// a canary Ingress for the canary destination. The gateways are not used.
+func (*NginxMesh) GenerateVirtualService(name string, gateways []string, hosts []string, httpRouteDestinations []HTTPRouteDestination) ([]byte, error) {
+
+	// Only external hosts can be used in Ingress rules
+	ingressHosts := []string{}
+	for _, host := range hosts {
+		if strings.Contains(host, ".") {
+			ingressHosts = append(ingressHosts, host)
+		}
+	}
+
+	var data []byte
+	for _, httpRouteDst := range httpRouteDestinations {
+		serviceName := getServiceName(httpRouteDst.Host)
+		ingress := networkingv1beta1.Ingress{
+			TypeMeta: metav1.TypeMeta{Kind: "Ingress", APIVersion: "networking.k8s.io/v1beta1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{nginxIngressClassAnnotation: "nginx"},
+			},
+		}
+		if serviceName == name+"-canary" {
+			ingress.Name = serviceName
+			ingress.Annotations[nginxCanaryAnnotation] = "true"
+			ingress.Annotations[nginxCanaryWeightAnnotation] = strconv.Itoa(int(httpRouteDst.Weight))
+		}
+		backend := networkingv1beta1.IngressBackend{ServiceName: serviceName, ServicePort: intstr.FromInt(int(httpRouteDst.Port))}
+		for _, host := range ingressHosts {
+			ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1beta1.IngressRule{
+				Host: host,
+				IngressRuleValue: networkingv1beta1.IngressRuleValue{
+					HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+						Paths: []networkingv1beta1.HTTPIngressPath{{Backend: backend}},
+					},
+				},
+			})
+		}
+
+		ingressData, err := yaml.Marshal(ingress)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, []byte("---\n")...)
+		data = append(data, ingressData...)
+	}
+	return data, nil
+}
+
+// UpdateWeights returns the Ingresses with an updated canary weight
+func (*NginxMesh) UpdateWeights(ingresses []byte, canaryWeight int32) ([]byte, error) {
+
+	if canaryWeight < 0 || canaryWeight > 100 {
+		return nil, errors.New("Invalid canary weight")
+	}
+
+	var data []byte
+	dec := kyaml.NewYAMLToJSONDecoder(bytes.NewReader(ingresses))
+	for {
+		ingress := networkingv1beta1.Ingress{}
+		err := dec.Decode(&ingress)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ingress.Annotations[nginxCanaryAnnotation] == "true" {
+			ingress.Annotations[nginxCanaryWeightAnnotation] = strconv.Itoa(int(canaryWeight))
+		}
+
+		ingressData, err := yaml.Marshal(ingress)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, []byte("---\n")...)
+		data = append(data, ingressData...)
+	}
+	return data, nil
+}
+
+// GetDestinationRuleSuffix returns the file name suffix of destination rules, which are not used by ingress-nginx
+func (*NginxMesh) GetDestinationRuleSuffix() string {
+	return "-nginx-destinationrule.yaml"
+}
+
+// GetVirtualServiceSuffix returns the file name suffix of the Ingresses
+func (*NginxMesh) GetVirtualServiceSuffix() string {
+	return "-nginx-ingress.yaml"
+}
+
+// InjectNamespace does not change the namespace because ingress-nginx does not require an injection
+func (*NginxMesh) InjectNamespace(namespace *corev1.Namespace) {
+}
+
+// SplitsTraffic returns true because the traffic is split by weighted routes
+func (*NginxMesh) SplitsTraffic() bool {
+	return true
+}
@@ -0,0 +1,152 @@
+package mesh
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/helm-service/pkg/objectutils"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+)
+
+const expectedPrimaryIngress = `
+    {
+		"apiVersion": "networking.k8s.io/v1beta1",
+		"kind": "Ingress",
+		"metadata": {
+		  "name": "carts",
+		  "creationTimestamp": null,
+		  "annotations": {
+			"kubernetes.io/ingress.class": "nginx"
+		  }
+		},
+		"spec": {
+		  "rules": [
+			{
+			  "host": "carts.sockshop-dev.35.226.86.78.xip.io",
+			  "http": {
+				"paths": [
+				  {
+					"backend": {
+					  "serviceName": "carts-primary",
+					  "servicePort": 80
+					}
+				  }
+				]
+			  }
+			}
+		  ]
+		},
+		"status": {
+		  "loadBalancer": {}
+		}
+	  }`
+
+const expectedCanaryIngress = `
+    {
+		"apiVersion": "networking.k8s.io/v1beta1",
+		"kind": "Ingress",
+		"metadata": {
+		  "name": "carts-canary",
+		  "creationTimestamp": null,
+		  "annotations": {
+			"kubernetes.io/ingress.class": "nginx",
+			"nginx.ingress.kubernetes.io/canary": "true",
+			"nginx.ingress.kubernetes.io/canary-weight": "%s"
+		  }
+		},
+		"spec": {
+		  "rules": [
+			{
+			  "host": "carts.sockshop-dev.35.226.86.78.xip.io",
+			  "http": {
+				"paths": [
+				  {
+					"backend": {
+					  "serviceName": "carts-canary",
+					  "servicePort": 80
+					}
+				  }
+				]
+			  }
+			}
+		  ]
+		},
+		"status": {
+		  "loadBalancer": {}
+		}
+	  }`
+
+func getIngressDocuments(data []byte) []string {
+	docs := []string{}
+	for _, doc := range strings.Split(string(data), "---\n") {
+		if strings.TrimSpace(doc) != "" {
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
+
+func TestNginxDestinationRule(t *testing.T) {
+
+	nginxMesh := NewNginxMesh()
+	data, err := nginxMesh.GenerateDestinationRule("carts-primary", "carts-primary.sockshop-dev.svc.cluster.local")
+	assert.Nil(t, err)
+	assert.Nil(t, data)
+}
+
+func TestNginxIngresses(t *testing.T) {
+
+	routeDestinations := []HTTPRouteDestination{{Host: "carts-canary.sockshop-dev.svc.cluster.local", Weight: 0, Port: 80},
+		{Host: "carts-primary.sockshop-dev.svc.cluster.local", Weight: 100, Port: 80}}
+
+	nginxMesh := NewNginxMesh()
+	data, err := nginxMesh.GenerateVirtualService("carts", []string{"public-gateway.istio-system", "mesh"},
+		[]string{"carts.sockshop-dev.35.226.86.78.xip.io", "carts"}, routeDestinations)
+	if err != nil {
+		t.Error(err)
+	}
+
+	docs := getIngressDocuments(data)
+	assert.Equal(t, 2, len(docs))
+
+	ja := jsonassert.New(t)
+	jsonData, err := objectutils.ToJSON([]byte(docs[0]))
+	assert.Nil(t, err)
+	ja.Assertf(string(jsonData), expectedCanaryIngress, "0")
+
+	jsonData, err = objectutils.ToJSON([]byte(docs[1]))
+	assert.Nil(t, err)
+	ja.Assertf(string(jsonData), expectedPrimaryIngress)
+}
+
+func TestUpdateNginxIngressWeights(t *testing.T) {
+
+	routeDestinations := []HTTPRouteDestination{{Host: "carts-canary.sockshop-dev.svc.cluster.local", Weight: 0, Port: 80},
+		{Host: "carts-primary.sockshop-dev.svc.cluster.local", Weight: 100, Port: 80}}
+
+	nginxMesh := NewNginxMesh()
+	data, err := nginxMesh.GenerateVirtualService("carts", nil, []string{"carts.sockshop-dev.35.226.86.78.xip.io"}, routeDestinations)
+	if err != nil {
+		t.Error(err)
+	}
+	data, err = nginxMesh.UpdateWeights(data, 40)
+	if err != nil {
+		t.Error(err)
+	}
+
+	docs := getIngressDocuments(data)
+	assert.Equal(t, 2, len(docs))
+
+	ja := jsonassert.New(t)
+	jsonData, err := objectutils.ToJSON([]byte(docs[0]))
+	assert.Nil(t, err)
+	ja.Assertf(string(jsonData), expectedCanaryIngress, "40")
+
+	jsonData, err = objectutils.ToJSON([]byte(docs[1]))
+	assert.Nil(t, err)
+	ja.Assertf(string(jsonData), expectedPrimaryIngress)
+
+	_, err = nginxMesh.UpdateWeights(data, 101)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,55 @@
+package mesh
+
+import (
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// errNoTrafficSplit is returned when routing manifests are requested from a mesh which does not split the traffic
+var errNoTrafficSplit = errors.New("mesh " + NoneMeshType + " does not split the traffic")
+
+// ServiceSelectorMesh is an implementation of interface Mesh which does not require a service mesh.
+// Instead of splitting the traffic, the selectors of the services of the user chart are switched between
+// the primary and the canary pods. Hence, the traffic is cut over all-or-nothing.
+type ServiceSelectorMesh struct {
+}
+
+// NewServiceSelectorMesh generates a new mesh switching service selectors
+func NewServiceSelectorMesh() *ServiceSelectorMesh {
+	return &ServiceSelectorMesh{}
+}
+
+// GenerateDestinationRule returns an error because the mesh does not split the traffic
+func (*ServiceSelectorMesh) GenerateDestinationRule(name string, host string) ([]byte, error) {
+	return nil, errNoTrafficSplit
+}
+
+// GenerateVirtualService returns an error because the mesh does not split the traffic
+func (*ServiceSelectorMesh) GenerateVirtualService(name string, gateways []string, hosts []string, httpRouteDestinations []HTTPRouteDestination) ([]byte, error) {
+	return nil, errNoTrafficSplit
+}
+
+// UpdateWeights returns an error because the mesh does not split the traffic
+func (*ServiceSelectorMesh) UpdateWeights(virtualService []byte, canaryWeight int32) ([]byte, error) {
+	return nil, errNoTrafficSplit
+}
+
+// GetDestinationRuleSuffix returns an empty suffix because no destination rules are generated
+func (*ServiceSelectorMesh) GetDestinationRuleSuffix() string {
+	return ""
+}
+
+// GetVirtualServiceSuffix returns an empty suffix because no virtual services are generated
+func (*ServiceSelectorMesh) GetVirtualServiceSuffix() string {
+	return ""
+}
+
+// InjectNamespace does not change the namespace because no mesh is injected
+func (*ServiceSelectorMesh) InjectNamespace(namespace *corev1.Namespace) {
+}
+
+// SplitsTraffic returns false because the selectors of the services are switched instead
+func (*ServiceSelectorMesh) SplitsTraffic() bool {
+	return false
+}
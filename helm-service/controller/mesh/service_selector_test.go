@@ -0,0 +1,18 @@
+package mesh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceSelectorMesh(t *testing.T) {
+
+	selectorMesh := NewServiceSelectorMesh()
+	assert.False(t, selectorMesh.SplitsTraffic())
+
+	_, err := selectorMesh.GenerateVirtualService("carts", []string{"public-gateway.istio-system", "mesh"}, []string{"carts"},
+		[]HTTPRouteDestination{{Host: "carts-canary.sockshop-dev.svc.cluster.local", Weight: 0},
+			{Host: "carts-primary.sockshop-dev.svc.cluster.local", Weight: 100}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package mesh
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/keptn/keptn/helm-service/pkg/apis/split/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// SMIMesh is an implementation of interface Mesh based on the SMI TrafficSplit, which is e.g. supported by Linkerd
+type SMIMesh struct {
+}
+
+// NewSMIMesh generates a new SMI mesh
+func NewSMIMesh() *SMIMesh {
+	return &SMIMesh{}
+}
+
+// GenerateDestinationRule returns nil because SMI does not require destination rules
+func (*SMIMesh) GenerateDestinationRule(name string, host string) ([]byte, error) {
+	return nil, nil
+}
+
+// GenerateVirtualService generates a new SMI TrafficSplit for the apex service with the provided name.
+// As SMI does not cover ingress traffic, the gateways and hosts are not used.
+// Returns nil if the only destination is the apex service itself.
+func (*SMIMesh) GenerateVirtualService(name string, gateways []string, hosts []string, httpRouteDestinations []HTTPRouteDestination) ([]byte, error) {
+
+	backends := []v1alpha1.TrafficSplitBackend{}
+	for _, httpRouteDst := range httpRouteDestinations {
+		backends = append(backends, v1alpha1.TrafficSplitBackend{Service: getServiceName(httpRouteDst.Host), Weight: httpRouteDst.Weight})
+	}
+	if len(backends) == 1 && backends[0].Service == name {
+		return nil, nil
+	}
+
+	ts := v1alpha1.TrafficSplit{TypeMeta: metav1.TypeMeta{Kind: "TrafficSplit", APIVersion: "split.smi-spec.io/v1alpha1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: v1alpha1.TrafficSplitSpec{Service: name, Backends: backends}}
+	return yaml.Marshal(ts)
+}
+
+// getServiceName returns the name of the Kubernetes service addressed by the host
+func getServiceName(host string) string {
+	return strings.Split(host, ".")[0]
+}
+
+// UpdateWeights returns a TrafficSplit with updated weights
+func (*SMIMesh) UpdateWeights(trafficSplit []byte, canaryWeight int32) ([]byte, error) {
+
+	ts := v1alpha1.TrafficSplit{}
+	err := yaml.Unmarshal(trafficSplit, &ts)
+	if err != nil {
+		return nil, err
+	}
+
+	primaryWeight := int32(100 - canaryWeight)
+	if primaryWeight < 0 {
+		return nil, errors.New("Invalid canary weight")
+	}
+
+	for idx, backend := range ts.Spec.Backends {
+		if !strings.HasPrefix(backend.Service, ts.Spec.Service) {
+			return nil, fmt.Errorf("Cannot update TrafficSplit because backend has unexpected name %s", backend.Service)
+		}
+		if backend.Service == ts.Spec.Service+"-canary" {
+			ts.Spec.Backends[idx].Weight = canaryWeight
+		}
+		if backend.Service == ts.Spec.Service+"-primary" {
+			ts.Spec.Backends[idx].Weight = primaryWeight
+		}
+	}
+
+	return yaml.Marshal(ts)
+}
+
+// GetDestinationRuleSuffix returns the file name suffix of destination rules, which are not used by SMI
+func (*SMIMesh) GetDestinationRuleSuffix() string {
+	return "-smi-destinationrule.yaml"
+}
+
+// GetVirtualServiceSuffix returns the file name suffix of traffic splits
+func (*SMIMesh) GetVirtualServiceSuffix() string {
+	return "-smi-trafficsplit.yaml"
+}
+
+// InjectNamespace enables the Linkerd proxy injection for the namespace
+func (*SMIMesh) InjectNamespace(namespace *corev1.Namespace) {
+	if namespace.ObjectMeta.Annotations == nil {
+		namespace.ObjectMeta.Annotations = make(map[string]string)
+	}
+	namespace.ObjectMeta.Annotations["linkerd.io/inject"] = "enabled"
+}
+
+// SplitsTraffic returns true because the traffic is split by weighted routes
+func (*SMIMesh) SplitsTraffic() bool {
+	return true
+}
@@ -0,0 +1,114 @@
+package mesh
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/helm-service/pkg/objectutils"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSMIDestinationRule(t *testing.T) {
+
+	smiMesh := NewSMIMesh()
+	data, err := smiMesh.GenerateDestinationRule("carts-primary", "carts-primary.sockshop-dev.svc.cluster.local")
+	assert.Nil(t, err)
+	assert.Nil(t, data)
+}
+
+func TestTrafficSplit(t *testing.T) {
+
+	routeDestinations := []HTTPRouteDestination{{Host: "carts-canary.sockshop-dev.svc.cluster.local", Weight: 0},
+		{Host: "carts-primary.sockshop-dev.svc.cluster.local", Weight: 100}}
+
+	smiMesh := NewSMIMesh()
+	data, err := smiMesh.GenerateVirtualService("carts", []string{"public-gateway.istio-system", "mesh"}, []string{"carts.sockshop-dev.35.226.86.78.xip.io", "carts"}, routeDestinations)
+	if err != nil {
+		t.Error(err)
+	}
+	jsonData, err := objectutils.ToJSON(data)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ja := jsonassert.New(t)
+	ja.Assertf(string(jsonData), `
+    {
+		"apiVersion": "split.smi-spec.io/v1alpha1",
+		"kind": "TrafficSplit",
+		"metadata": {
+		  "name": "carts",
+		  "creationTimestamp": null
+		},
+		"spec": {
+		  "service": "carts",
+		  "backends": [
+			{
+			  "service": "carts-canary",
+			  "weight": 0
+			},
+			{
+			  "service": "carts-primary",
+			  "weight": 100
+			}
+		  ]
+		}
+	  }`)
+}
+
+func TestTrafficSplitForApexOnly(t *testing.T) {
+
+	routeDestinations := []HTTPRouteDestination{{Host: "carts.sockshop-dev.svc.cluster.local"}}
+
+	smiMesh := NewSMIMesh()
+	data, err := smiMesh.GenerateVirtualService("carts", []string{"public-gateway.istio-system", "mesh"}, []string{"carts"}, routeDestinations)
+	assert.Nil(t, err)
+	assert.Nil(t, data)
+}
+
+func TestUpdateTrafficSplitWeights(t *testing.T) {
+
+	routeDestinations := []HTTPRouteDestination{{Host: "carts-canary.sockshop-dev.svc.cluster.local", Weight: 0},
+		{Host: "carts-primary.sockshop-dev.svc.cluster.local", Weight: 100}}
+
+	smiMesh := NewSMIMesh()
+	data, err := smiMesh.GenerateVirtualService("carts", nil, nil, routeDestinations)
+	if err != nil {
+		t.Error(err)
+	}
+	data, err = smiMesh.UpdateWeights(data, 25)
+	if err != nil {
+		t.Error(err)
+	}
+	jsonData, err := objectutils.ToJSON(data)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ja := jsonassert.New(t)
+	ja.Assertf(string(jsonData), `
+    {
+		"apiVersion": "split.smi-spec.io/v1alpha1",
+		"kind": "TrafficSplit",
+		"metadata": {
+		  "name": "carts",
+		  "creationTimestamp": null
+		},
+		"spec": {
+		  "service": "carts",
+		  "backends": [
+			{
+			  "service": "carts-canary",
+			  "weight": 25
+			},
+			{
+			  "service": "carts-primary",
+			  "weight": 75
+			}
+		  ]
+		}
+	  }`)
+
+	_, err = smiMesh.UpdateWeights(data, 101)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"fmt"
+
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+
+	"github.com/keptn/keptn/helm-service/controller/helm"
+)
+
+// loadNamespaceConfig reads the namespace config of the project, which defines the namespaces of its stages.
+// Projects without namespace config get a nil config, which resolves the namespace PROJECT-STAGE.
+func loadNamespaceConfig(project string, configServiceURL string) (*helm.NamespaceConfig, error) {
+
+	rHandler := configutils.NewResourceHandler(configServiceURL)
+	resource, err := rHandler.GetProjectResource(project, helm.NamespaceConfigURI)
+	if err == configutils.ResourceNotFoundError {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error when reading %s of project %s: %v", helm.NamespaceConfigURI, project, err)
+	}
+
+	return helm.ParseNamespaceConfig([]byte(resource.ResourceContent))
+}
@@ -1,15 +1,20 @@
 package controller
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	"github.com/keptn/keptn/helm-service/controller/helm"
+	"github.com/keptn/keptn/helm-service/controller/mesh"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/keptn/go-utils/pkg/api/models"
 	keptn "github.com/keptn/go-utils/pkg/lib"
-	keptnutils "github.com/keptn/kubernetes-utils/pkg"
 )
 
 type NamespaceManager struct {
@@ -20,49 +25,162 @@ func NewNamespaceManager(logger keptn.LoggerInterface) *NamespaceManager {
 	return &NamespaceManager{logger: logger}
 }
 
-// InitNamespaces initializes namespaces if they do not exist yet
-func (p *NamespaceManager) InitNamespaces(project string, stages []*models.Stage) error {
+// InitNamespaces initializes the namespaces of the stages if they do not exist yet and applies the labels, annotations
+// and policy objects defined by the namespace config of the project
+func (p *NamespaceManager) InitNamespaces(project string, stages []*models.Stage, config *helm.NamespaceConfig) error {
 
 	for _, shipyardStage := range stages {
 
-		namespace := helm.GetUmbrellaNamespace(project, shipyardStage.StageName)
-		exists, err := keptnutils.ExistsNamespace(true, namespace)
+		stageNamespace := config.GetStageNamespace(project, shipyardStage.StageName)
+		clientset, err := helm.GetClientset(helm.NewStageTarget(project, shipyardStage.StageName, config))
 		if err != nil {
-			return fmt.Errorf("error when checking availability of namespace: %v", err)
+			return fmt.Errorf("error when getting the cluster of stage %s: %v", shipyardStage.StageName, err)
 		}
-		if exists {
-			p.logger.Debug(fmt.Sprintf("Reuse existing namespace %s", namespace))
-		} else {
-			p.logger.Debug(fmt.Sprintf("Create new namespace %s", namespace))
-			if err != keptnutils.CreateNamespace(true, namespace) {
-				return fmt.Errorf("error when creating namespace %s: %v", namespace, err)
+		if err := p.applyNamespace(clientset, stageNamespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyNamespace creates or updates the namespace and its policy objects
+func (p *NamespaceManager) applyNamespace(clientset kubernetes.Interface, stageNamespace helm.StageNamespace) error {
+
+	namespace := stageNamespace.Namespace
+	ns, err := clientset.CoreV1().Namespaces().Get(namespace, v1.GetOptions{})
+	if err == nil {
+		p.logger.Debug(fmt.Sprintf("Reuse existing namespace %s", namespace))
+		labelsChanged := mergeStringMap(&ns.Labels, stageNamespace.Labels)
+		annotationsChanged := mergeStringMap(&ns.Annotations, stageNamespace.Annotations)
+		if labelsChanged || annotationsChanged {
+			if _, err := clientset.CoreV1().Namespaces().Update(ns); err != nil {
+				return fmt.Errorf("error when updating namespace %s: %v", namespace, err)
 			}
 		}
+	} else if k8serrors.IsNotFound(err) {
+		p.logger.Debug(fmt.Sprintf("Create new namespace %s", namespace))
+		ns := &corev1.Namespace{ObjectMeta: v1.ObjectMeta{
+			Name:        namespace,
+			Labels:      stageNamespace.Labels,
+			Annotations: stageNamespace.Annotations,
+		}}
+		if _, err := clientset.CoreV1().Namespaces().Create(ns); err != nil {
+			return fmt.Errorf("error when creating namespace %s: %v", namespace, err)
+		}
+	} else {
+		return fmt.Errorf("error when checking availability of namespace: %v", err)
+	}
+
+	for _, resource := range stageNamespace.Resources {
+		if err := p.applyNamespaceResource(clientset, namespace, resource); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeStringMap adds the entries to the map and returns whether the map was changed
+func mergeStringMap(m *map[string]string, entries map[string]string) bool {
+	changed := false
+	for key, value := range entries {
+		if *m == nil {
+			*m = map[string]string{}
+		}
+		if current, ok := (*m)[key]; !ok || current != value {
+			(*m)[key] = value
+			changed = true
+		}
+	}
+	return changed
+}
+
+// applyNamespaceResource creates the policy object in the namespace or updates it if it already exists
+func (p *NamespaceManager) applyNamespaceResource(clientset kubernetes.Interface, namespace string,
+	resource map[string]interface{}) error {
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+	kind, _ := resource["kind"].(string)
+
+	switch kind {
+	case "ResourceQuota":
+		obj := &corev1.ResourceQuota{}
+		if err := json.Unmarshal(data, obj); err != nil {
+			return fmt.Errorf("error when parsing %s: %v", kind, err)
+		}
+		obj.Namespace = namespace
+		client := clientset.CoreV1().ResourceQuotas(namespace)
+		existing, err := client.Get(obj.Name, v1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			_, err = client.Create(obj)
+		} else if err == nil {
+			obj.ResourceVersion = existing.ResourceVersion
+			_, err = client.Update(obj)
+		}
+		return wrapNamespaceResourceError(err, kind, obj.Name, namespace)
+	case "LimitRange":
+		obj := &corev1.LimitRange{}
+		if err := json.Unmarshal(data, obj); err != nil {
+			return fmt.Errorf("error when parsing %s: %v", kind, err)
+		}
+		obj.Namespace = namespace
+		client := clientset.CoreV1().LimitRanges(namespace)
+		existing, err := client.Get(obj.Name, v1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			_, err = client.Create(obj)
+		} else if err == nil {
+			obj.ResourceVersion = existing.ResourceVersion
+			_, err = client.Update(obj)
+		}
+		return wrapNamespaceResourceError(err, kind, obj.Name, namespace)
+	case "NetworkPolicy":
+		obj := &networkingv1.NetworkPolicy{}
+		if err := json.Unmarshal(data, obj); err != nil {
+			return fmt.Errorf("error when parsing %s: %v", kind, err)
+		}
+		obj.Namespace = namespace
+		client := clientset.NetworkingV1().NetworkPolicies(namespace)
+		existing, err := client.Get(obj.Name, v1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			_, err = client.Create(obj)
+		} else if err == nil {
+			obj.ResourceVersion = existing.ResourceVersion
+			_, err = client.Update(obj)
+		}
+		return wrapNamespaceResourceError(err, kind, obj.Name, namespace)
+	default:
+		return fmt.Errorf("%s of namespace %s is not supported, only ResourceQuota, LimitRange and NetworkPolicy can be applied",
+			kind, namespace)
+	}
+}
+
+func wrapNamespaceResourceError(err error, kind string, name string, namespace string) error {
+	if err != nil {
+		return fmt.Errorf("error when applying %s %s in namespace %s: %v", kind, name, namespace, err)
 	}
 	return nil
 }
 
-// InjectIstio injects Istio into the namespace used for the project and stage
-func (p *NamespaceManager) InjectIstio(project string, stage string) error {
-	kubeClient, err := keptnutils.GetKubeAPI(true)
+// InjectMesh injects the mesh into the namespace of a stage
+func (p *NamespaceManager) InjectMesh(stageTarget helm.StageTarget, mesh mesh.Mesh) error {
+	clientset, err := helm.GetClientset(stageTarget)
 	if err != nil {
 		return fmt.Errorf("error when getting kube API: %v", err)
 	}
-	namespace, err := kubeClient.Namespaces().Get(helm.GetUmbrellaNamespace(project, stage), v1.GetOptions{})
+	kubeClient := clientset.CoreV1()
+	ns, err := kubeClient.Namespaces().Get(stageTarget.Namespace, v1.GetOptions{})
 	if err != nil {
 		return err
 	}
-	if namespace == nil {
+	if ns == nil {
 		return errors.New("error when getting namespace")
 	}
 
-	p.logger.Info(fmt.Sprintf("Inject Istio to the %s namespace for blue-green deployments", helm.GetUmbrellaNamespace(project, stage)))
-
-	if namespace.ObjectMeta.Labels == nil {
-		namespace.ObjectMeta.Labels = make(map[string]string)
-	}
+	p.logger.Info(fmt.Sprintf("Inject the mesh to the %s namespace for blue-green deployments", stageTarget.Namespace))
 
-	namespace.ObjectMeta.Labels["istio-injection"] = "enabled"
-	_, err = kubeClient.Namespaces().Update(namespace)
+	mesh.InjectNamespace(ns)
+	_, err = kubeClient.Namespaces().Update(ns)
 	return err
 }
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"testing"
+
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/keptn/keptn/helm-service/controller/helm"
+)
+
+func TestApplyNamespace(t *testing.T) {
+
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "team-a-prod",
+		Labels: map[string]string{"istio-injection": "enabled"},
+	}})
+	stageNamespace := helm.StageNamespace{
+		Name:        "production",
+		Namespace:   "team-a-prod",
+		Labels:      map[string]string{"team": "a"},
+		Annotations: map[string]string{"owner": "team-a@example.com"},
+		Resources: []map[string]interface{}{
+			{
+				"apiVersion": "v1",
+				"kind":       "ResourceQuota",
+				"metadata":   map[string]interface{}{"name": "quota"},
+				"spec":       map[string]interface{}{"hard": map[string]interface{}{"pods": "10"}},
+			},
+			{
+				"apiVersion": "networking.k8s.io/v1",
+				"kind":       "NetworkPolicy",
+				"metadata":   map[string]interface{}{"name": "deny-ingress"},
+				"spec":       map[string]interface{}{"podSelector": map[string]interface{}{}},
+			},
+		},
+	}
+
+	p := NewNamespaceManager(keptnevents.NewLogger("", "", "helm-service"))
+	// applying the namespace twice has the same result
+	assert.NoError(t, p.applyNamespace(clientset, stageNamespace))
+	assert.NoError(t, p.applyNamespace(clientset, stageNamespace))
+
+	ns, err := clientset.CoreV1().Namespaces().Get("team-a-prod", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"istio-injection": "enabled", "team": "a"}, ns.Labels)
+	assert.Equal(t, map[string]string{"owner": "team-a@example.com"}, ns.Annotations)
+
+	quota, err := clientset.CoreV1().ResourceQuotas("team-a-prod").Get("quota", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "10", quota.Spec.Hard.Pods().String())
+
+	_, err = clientset.NetworkingV1().NetworkPolicies("team-a-prod").Get("deny-ingress", metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	stageNamespace.Namespace = "team-a-dev"
+	stageNamespace.Resources = []map[string]interface{}{{"kind": "Deployment"}}
+	assert.Error(t, p.applyNamespace(clientset, stageNamespace))
+	_, err = clientset.CoreV1().Namespaces().Get("team-a-dev", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go"
+	configmodels "github.com/keptn/go-utils/pkg/api/models"
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+
+	"github.com/keptn/keptn/helm-service/controller/helm"
+)
+
+// InternalServiceDeleteEventType is a CloudEvent type for deleting a service in all stages
+const InternalServiceDeleteEventType = "sh.keptn.internal.event.service.delete"
+
+// serviceDeleteEventData represents the data for deleting a service
+type serviceDeleteEventData struct {
+	Project string `json:"project"`
+	Service string `json:"service"`
+}
+
+// Offboarder is a container of variables required for deleting a service
+type Offboarder struct {
+	keptnHandler     *keptnevents.Keptn
+	helmExecutor     helm.HelmExecutor
+	configServiceURL string
+}
+
+// NewOffboarder creates a new Offboarder
+func NewOffboarder(keptnHandler *keptnevents.Keptn, configServiceURL string) *Offboarder {
+	return &Offboarder{
+		keptnHandler:     keptnHandler,
+		helmExecutor:     helm.NewHelmV3Executor(keptnHandler.Logger),
+		configServiceURL: configServiceURL,
+	}
+}
+
+// DoOffboard uninstalls the releases of the service and deletes the service in all stages
+func (o *Offboarder) DoOffboard(ce cloudevents.Event, loggingDone chan bool) error {
+
+	defer func() { loggingDone <- true }()
+
+	event := &serviceDeleteEventData{}
+	if err := ce.DataAs(event); err != nil {
+		o.keptnHandler.Logger.Error(fmt.Sprintf("Got Data Error: %s", err.Error()))
+		return err
+	}
+
+	o.keptnHandler.Logger.Info(fmt.Sprintf("Start deleting service %s in project %s", event.Service, event.Project))
+
+	namespaces, err := loadNamespaceConfig(event.Project, o.configServiceURL)
+	if err != nil {
+		o.keptnHandler.Logger.Error(err.Error())
+		return err
+	}
+
+	stageHandler := configutils.NewStageHandler(o.configServiceURL)
+	stages, err := stageHandler.GetAllStages(event.Project)
+	if err != nil {
+		o.keptnHandler.Logger.Error("Error when getting all stages: " + err.Error())
+		return err
+	}
+
+	for _, stage := range stages {
+		if err := o.offboardService(event.Project, stage.StageName, helm.NewStageTarget(event.Project, stage.StageName, namespaces), event.Service); err != nil {
+			o.keptnHandler.Logger.Error(err.Error())
+			return err
+		}
+	}
+
+	o.keptnHandler.Logger.Info(fmt.Sprintf("Finished deleting service %s in project %s", event.Service, event.Project))
+	return nil
+}
+
+func (o *Offboarder) offboardService(project string, stage string, stageTarget helm.StageTarget, service string) error {
+
+	for _, generated := range []bool{true, false} {
+		if err := o.helmExecutor.UninstallRelease(helm.GetReleaseName(project, stage, service, generated), stageTarget); err != nil {
+			return err
+		}
+	}
+
+	if err := o.removeChartsFromUmbrellaChart(project, stage, service); err != nil {
+		return fmt.Errorf("Error when removing service %s from the umbrella chart in stage %s: %s", service, stage, err.Error())
+	}
+
+	exists, err := o.serviceExists(project, stage, service)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		o.keptnHandler.Logger.Debug(fmt.Sprintf("Service %s does not exist in stage %s", service, stage))
+		return nil
+	}
+	o.keptnHandler.Logger.Info("Deleting Keptn service " + service + " in stage " + stage)
+	return o.deleteServiceInStage(project, stage, service)
+}
+
+// removeChartsFromUmbrellaChart removes the user and the generated chart from the umbrella chart.
+// Stages without umbrella chart are skipped.
+func (o *Offboarder) removeChartsFromUmbrellaChart(project string, stage string, service string) error {
+
+	umbrellaChartHandler := helm.NewUmbrellaChartHandler(o.configServiceURL)
+	for _, generated := range []bool{true, false} {
+		helmChartName := helm.GetChartName(service, generated)
+		if err := umbrellaChartHandler.RemoveChartFromUmbrellaValues(project, helmChartName, stage); err == configutils.ResourceNotFoundError {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := umbrellaChartHandler.RemoveChartFromUmbrellaRequirements(project, helmChartName, stage); err != nil &&
+			err != configutils.ResourceNotFoundError {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Offboarder) serviceExists(project string, stage string, service string) (bool, error) {
+
+	serviceHandler := configutils.NewServiceHandler(o.configServiceURL)
+	services, err := serviceHandler.GetAllServices(project, stage)
+	if err != nil {
+		return false, fmt.Errorf("Error when getting services of stage %s: %s", stage, err.Error())
+	}
+	for _, svc := range services {
+		if svc.ServiceName == service {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deleteServiceInStage deletes the service in the configuration-service
+func (o *Offboarder) deleteServiceInStage(project string, stage string, service string) error {
+
+	configServiceURL := o.configServiceURL
+	if !strings.HasPrefix(configServiceURL, "http://") && !strings.HasPrefix(configServiceURL, "https://") {
+		configServiceURL = "http://" + configServiceURL
+	}
+	req, err := http.NewRequest("DELETE", configServiceURL+"/v1/project/"+project+"/stage/"+stage+"/service/"+service, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	respErr := &configmodels.Error{}
+	if err := json.Unmarshal(body, respErr); err != nil || respErr.Message == nil {
+		return fmt.Errorf("Error when deleting service %s in stage %s: %s", service, stage, string(body))
+	}
+	return errors.New(*respErr.Message)
+}
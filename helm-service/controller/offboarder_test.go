@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	"github.com/ghodss/yaml"
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn/keptn/helm-service/controller/helm"
+)
+
+func TestDoOffboard(t *testing.T) {
+
+	const values = `carts:
+  enabled: true
+carts-generated:
+  enabled: true
+orders:
+  enabled: true
+`
+	const requirements = `dependencies:
+- condition: carts.enabled
+  name: carts
+  repository: ""
+  version: 0.1.0
+- condition: orders.enabled
+  name: orders
+  repository: ""
+  version: 0.1.0
+`
+
+	storedResources := map[string]string{"values.yaml": values, "requirements.yaml": requirements}
+	deletedServices := []string{}
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/v1/project/sockshop/stage":
+				stages := models.Stages{Stages: []*models.Stage{{StageName: "dev"}, {StageName: "production"}}}
+				json.NewEncoder(w).Encode(stages)
+			case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/project/sockshop/stage/dev/resource/"):
+				uri := strings.TrimPrefix(r.URL.Path, "/v1/project/sockshop/stage/dev/resource/")
+				content, ok := storedResources[uri]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					json.NewEncoder(w).Encode(models.Error{Code: 404})
+					return
+				}
+				json.NewEncoder(w).Encode(models.Resource{ResourceURI: &uri, ResourceContent: base64.StdEncoding.EncodeToString([]byte(content))})
+			case r.Method == http.MethodPost && r.URL.Path == "/v1/project/sockshop/stage/dev/resource":
+				resources := &models.Resources{}
+				json.NewDecoder(r.Body).Decode(resources)
+				for _, resource := range resources.Resources {
+					content, _ := base64.StdEncoding.DecodeString(resource.ResourceContent)
+					storedResources[*resource.ResourceURI] = string(content)
+				}
+				json.NewEncoder(w).Encode(models.Version{Version: "1"})
+			case r.Method == http.MethodGet && r.URL.Path == "/v1/project/sockshop/stage/dev/service":
+				services := models.Services{Services: []*models.Service{{ServiceName: "carts"}, {ServiceName: "orders"}}}
+				json.NewEncoder(w).Encode(services)
+			case r.Method == http.MethodGet && r.URL.Path == "/v1/project/sockshop/stage/production/service":
+				json.NewEncoder(w).Encode(models.Services{Services: []*models.Service{{ServiceName: "orders"}}})
+			case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v1/project/sockshop/stage/"):
+				deletedServices = append(deletedServices, r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(models.Error{Code: 404})
+			}
+		}),
+	)
+	defer ts.Close()
+
+	ce := cloudevents.New("0.2")
+	dataBytes, err := json.Marshal(serviceDeleteEventData{Project: "sockshop", Service: "carts"})
+	assert.NoError(t, err)
+	ce.Data = dataBytes
+	keptnHandler, err := keptnevents.NewKeptn(&ce, keptnevents.KeptnOpts{})
+	assert.NoError(t, err)
+
+	helmExecutor := helm.NewHelmMockExecutor()
+	o := &Offboarder{keptnHandler: keptnHandler, helmExecutor: helmExecutor, configServiceURL: ts.URL}
+
+	loggingDone := make(chan bool, 1)
+	assert.NoError(t, o.DoOffboard(ce, loggingDone))
+
+	assert.Equal(t, []string{
+		"sockshop-dev/sockshop-dev-carts-generated",
+		"sockshop-dev/sockshop-dev-carts",
+		"sockshop-production/sockshop-production-carts-generated",
+		"sockshop-production/sockshop-production-carts",
+	}, helmExecutor.UninstalledReleases)
+	assert.Equal(t, []string{"/v1/project/sockshop/stage/dev/service/carts"}, deletedServices)
+
+	storedValues := helm.Values{}
+	assert.NoError(t, yaml.Unmarshal([]byte(storedResources["values.yaml"]), &storedValues))
+	assert.Equal(t, helm.Values{"orders": helm.Enabler{Enabled: true}}, storedValues)
+
+	storedRequirements := helm.Requirements{}
+	assert.NoError(t, yaml.Unmarshal([]byte(storedResources["requirements.yaml"]), &storedRequirements))
+	assert.Equal(t, 1, len(storedRequirements.Dependencies))
+	assert.Equal(t, "orders", storedRequirements.Dependencies[0].Name)
+}
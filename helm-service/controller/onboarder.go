@@ -8,6 +8,7 @@ import (
 
 	"helm.sh/helm/v3/pkg/chart"
 	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
 
 	cloudevents "github.com/cloudevents/sdk-go"
 
@@ -15,7 +16,9 @@ import (
 	keptnevents "github.com/keptn/go-utils/pkg/lib"
 	keptnutils "github.com/keptn/kubernetes-utils/pkg"
 
+	"github.com/keptn/keptn/helm-service/controller/chartrepo"
 	"github.com/keptn/keptn/helm-service/controller/helm"
+	"github.com/keptn/keptn/helm-service/controller/kustomize"
 	"github.com/keptn/keptn/helm-service/controller/mesh"
 )
 
@@ -24,6 +27,14 @@ type Onboarder struct {
 	mesh             mesh.Mesh
 	keptnHandler     *keptnevents.Keptn
 	configServiceURL string
+	chartPuller      *chartrepo.Puller
+}
+
+// serviceCreateEventData extends the service create event by a reference to a chart in a chart repository,
+// which is used instead of the Helm chart contained in the event
+type serviceCreateEventData struct {
+	keptnevents.ServiceCreateEventData `json:",inline"`
+	ChartRef                           *chartrepo.Reference `json:"chartRef,omitempty"`
 }
 
 // NewOnboarder creates a new Onboarder
@@ -32,6 +43,7 @@ func NewOnboarder(mesh mesh.Mesh, keptnHandler *keptnevents.Keptn, configService
 		mesh:             mesh,
 		keptnHandler:     keptnHandler,
 		configServiceURL: configServiceURL,
+		chartPuller:      chartrepo.NewPuller(getChartCacheDir()),
 	}
 }
 
@@ -45,11 +57,25 @@ func (o *Onboarder) DoOnboard(ce cloudevents.Event, loggingDone chan bool) error
 		o.keptnHandler.Logger.Error("Could not initialize Keptn handler: " + err.Error())
 		return err
 	}
-	event := &keptnevents.ServiceCreateEventData{}
-	if err := ce.DataAs(event); err != nil {
+	eventData := &serviceCreateEventData{}
+	if err := ce.DataAs(eventData); err != nil {
 		o.keptnHandler.Logger.Error(fmt.Sprintf("Got Data Error: %s", err.Error()))
 		return err
 	}
+	event := &eventData.ServiceCreateEventData
+
+	var chartRef *chartrepo.Reference
+	if eventData.ChartRef != nil {
+		o.keptnHandler.Logger.Info(fmt.Sprintf("Pulling chart %s in version %s from chart repository %s",
+			eventData.ChartRef.Name, eventData.ChartRef.Version, eventData.ChartRef.RepoURL))
+		chartData, resolvedRef, err := o.chartPuller.Pull(*eventData.ChartRef)
+		if err != nil {
+			o.keptnHandler.Logger.Error(fmt.Sprintf("Error when pulling chart: %s", err.Error()))
+			return err
+		}
+		event.HelmChart = base64.StdEncoding.EncodeToString(chartData)
+		chartRef = resolvedRef
+	}
 
 	if err := o.checkAndSetServiceName(event); err != nil {
 		o.keptnHandler.Logger.Error(fmt.Sprintf("Invalid service name: %s", err.Error()))
@@ -87,6 +113,12 @@ func (o *Onboarder) DoOnboard(ce cloudevents.Event, loggingDone chan bool) error
 		return errors.New("Cannot onboard service because no stage is available")
 	}
 
+	namespaces, err := loadNamespaceConfig(event.Project, o.configServiceURL)
+	if err != nil {
+		o.keptnHandler.Logger.Error(err.Error())
+		return err
+	}
+
 	namespaceMng := NewNamespaceManager(o.keptnHandler.Logger)
 
 	if event.HelmChart != "" {
@@ -103,7 +135,7 @@ func (o *Onboarder) DoOnboard(ce cloudevents.Event, loggingDone chan bool) error
 			return err
 		}
 
-		if err := namespaceMng.InitNamespaces(event.Project, stages); err != nil {
+		if err := namespaceMng.InitNamespaces(event.Project, stages, namespaces); err != nil {
 			o.keptnHandler.Logger.Error(err.Error())
 			return err
 		}
@@ -128,9 +160,15 @@ func (o *Onboarder) DoOnboard(ce cloudevents.Event, loggingDone chan bool) error
 			o.keptnHandler.Logger.Error(err.Error())
 			return err
 		}
+		if chartRef != nil {
+			if err := chartrepo.StoreReference(event.Project, stage.StageName, event.Service, chartRef, o.configServiceURL); err != nil {
+				o.keptnHandler.Logger.Error(err.Error())
+				return err
+			}
+		}
 		if event.DeploymentStrategies[stage.StageName] == keptnevents.Duplicate && event.HelmChart != "" {
-			// inject Istio to the namespace for blue-green deployments
-			if err := namespaceMng.InjectIstio(event.Project, stage.StageName); err != nil {
+			// inject the mesh to the namespace for blue-green deployments
+			if err := namespaceMng.InjectMesh(helm.NewStageTarget(event.Project, stage.StageName, namespaces), o.mesh); err != nil {
 				o.keptnHandler.Logger.Error(err.Error())
 				return err
 			}
@@ -182,9 +220,20 @@ func (o *Onboarder) checkAndSetServiceName(event *keptnevents.ServiceCreateEvent
 	if err != nil {
 		return fmt.Errorf("Error when loading Helm Chart: %v", err)
 	}
-	services, err := keptnutils.GetRenderedServices(ch)
-	if err != nil {
-		return fmt.Errorf("Error when rendering services: %v", err)
+	var services []*corev1.Service
+	if kustomize.IsKustomizeChart(ch) {
+		// The service is taken from the base because the overlays must not rename it
+		files := kustomize.GetStageFiles(ch, "")
+		manifest, err := kustomize.Build(files, kustomize.GetBuildPath(files, ""))
+		if err != nil {
+			return err
+		}
+		services = helm.GetServices(manifest)
+	} else {
+		services, err = keptnutils.GetRenderedServices(ch)
+		if err != nil {
+			return fmt.Errorf("Error when rendering services: %v", err)
+		}
 	}
 	if len(services) != 1 {
 		return fmt.Errorf("Helm Chart has to contain exactly one Kubernetes service, but it contains %d services", len(services))
@@ -221,6 +270,12 @@ func (o *Onboarder) onboardService(stageName string, event *keptnevents.ServiceC
 			return err
 		}
 
+		helmChartData, err = o.onboardKustomization(stageName, event, helmChartData)
+		if err != nil {
+			o.keptnHandler.Logger.Error("Error when onboarding the kustomization: " + err.Error())
+			return err
+		}
+
 		o.keptnHandler.Logger.Debug("Storing the Helm Chart provided by the user in stage " + stageName)
 		if err := keptnutils.StoreChart(event.Project, event.Service, stageName, helm.GetChartName(event.Service, false),
 			helmChartData, o.configServiceURL); err != nil {
@@ -256,13 +311,42 @@ func (o *Onboarder) onboardService(stageName string, event *keptnevents.ServiceC
 	return nil
 }
 
+// onboardKustomization stores the base and the overlay of the stage if the provided chart contains a kustomization.
+// The returned chart contains the objects built by kustomize for the stage. Other charts are returned unchanged.
+func (o *Onboarder) onboardKustomization(stageName string, event *keptnevents.ServiceCreateEventData, helmChartData []byte) ([]byte, error) {
+
+	ch, err := keptnutils.LoadChart(helmChartData)
+	if err != nil {
+		return nil, err
+	}
+	if !kustomize.IsKustomizeChart(ch) {
+		return helmChartData, nil
+	}
+
+	files := kustomize.GetStageFiles(ch, stageName)
+	o.keptnHandler.Logger.Debug("Storing the kustomization provided by the user in stage " + stageName)
+	if err := kustomize.StoreStageResources(event.Project, stageName, event.Service, files, o.configServiceURL); err != nil {
+		return nil, err
+	}
+
+	manifest, err := kustomize.Build(files, kustomize.GetBuildPath(files, stageName))
+	if err != nil {
+		return nil, err
+	}
+	userChart, err := kustomize.GenerateChart(event.Service, manifest, nil)
+	if err != nil {
+		return nil, err
+	}
+	return keptnutils.PackageChart(userChart)
+}
+
 // IsGeneratedChartEmpty checks whether the generated chart is empty
 func (c *Onboarder) IsGeneratedChartEmpty(chart *chart.Chart) bool {
 
 	return len(chart.Templates) == 0
 }
 
-func (o *Onboarder) OnboardGeneratedService(helmManifest string, project string, stageName string,
+func (o *Onboarder) OnboardGeneratedService(helmManifest string, project string, stageName string, namespace string,
 	service string, strategy keptnevents.DeploymentStrategy) (*chart.Chart, error) {
 
 	chartGenerator := helm.NewGeneratedChartHandler(o.mesh, o.keptnHandler.Logger)
@@ -275,7 +359,7 @@ func (o *Onboarder) OnboardGeneratedService(helmManifest string, project string,
 	if strategy == keptnevents.Duplicate {
 		o.keptnHandler.Logger.Debug(fmt.Sprintf("For service %s in stage %s with deployment strategy %s, "+
 			"a chart for a duplicate deployment strategy is generated", service, stageName, strategy.String()))
-		generatedChart, err = chartGenerator.GenerateDuplicateManagedChart(helmManifest, project, stageName, service)
+		generatedChart, err = chartGenerator.GenerateDuplicateManagedChart(helmManifest, namespace, service)
 		if err != nil {
 			o.keptnHandler.Logger.Error("Error when generating the managed chart: " + err.Error())
 			return nil, err
@@ -283,7 +367,7 @@ func (o *Onboarder) OnboardGeneratedService(helmManifest string, project string,
 	} else {
 		o.keptnHandler.Logger.Debug(fmt.Sprintf("For service %s in stage %s with deployment strategy %s, a mesh chart is generated",
 			service, stageName, strategy.String()))
-		generatedChart, err = chartGenerator.GenerateMeshChart(helmManifest, project, stageName, service)
+		generatedChart, err = chartGenerator.GenerateMeshChart(helmManifest, namespace, service)
 		if err != nil {
 			o.keptnHandler.Logger.Error("Error when generating the managed chart: " + err.Error())
 			return nil, err
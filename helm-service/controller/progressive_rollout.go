@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+)
+
+// progressiveRolloutURI is the stage resource defining the progressive rollout of blue_green_service stages
+const progressiveRolloutURI = "progressive_rollout.yaml"
+
+// canaryWeightLabel is the label which carries the canary weight of the current rollout step
+const canaryWeightLabel = "canary-weight"
+
+// ProgressiveRollout describes the traffic weights which are applied step by step on the canary.
+// Each step is evaluated before the next one is applied.
+type ProgressiveRollout struct {
+	Steps []int32 `json:"steps"`
+}
+
+// getProgressiveRollout returns the progressive rollout defined for the stage or nil if none is defined
+func getProgressiveRollout(project string, stage string, configServiceURL string) (*ProgressiveRollout, error) {
+
+	rHandler := configutils.NewResourceHandler(configServiceURL)
+	resource, err := rHandler.GetStageResource(project, stage, progressiveRolloutURI)
+	if err == configutils.ResourceNotFoundError {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error when reading %s of stage %s: %v", progressiveRolloutURI, stage, err)
+	}
+
+	rollout := &ProgressiveRollout{}
+	if err := yaml.Unmarshal([]byte(resource.ResourceContent), rollout); err != nil {
+		return nil, fmt.Errorf("error when parsing %s of stage %s: %v", progressiveRolloutURI, stage, err)
+	}
+	if err := rollout.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s of stage %s: %v", progressiveRolloutURI, stage, err)
+	}
+	return rollout, nil
+}
+
+func (r *ProgressiveRollout) validate() error {
+	if len(r.Steps) == 0 {
+		return errors.New("no steps defined")
+	}
+	var previous int32
+	for _, step := range r.Steps {
+		if step <= previous || step > 100 {
+			return fmt.Errorf("step %d has to be greater than %d and at most 100", step, previous)
+		}
+		previous = step
+	}
+	if previous != 100 {
+		return errors.New("last step has to be 100")
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+	"github.com/stretchr/testify/assert"
+)
+
+const progressiveRolloutResource = `steps:
+- 10
+- 25
+- 50
+- 100
+`
+
+func mockProgressiveRolloutEndpoint() *httptest.Server {
+
+	return httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			if r.Method == http.MethodGet &&
+				strings.Contains(r.RequestURI, "/v1/project/sockshop/stage/staging/resource/progressive_rollout.yaml") {
+				w.WriteHeader(200)
+				resp := models.Resource{
+					ResourceContent: base64.StdEncoding.EncodeToString([]byte(progressiveRolloutResource)),
+					ResourceURI:     stringp("progressive_rollout.yaml"),
+				}
+				data, _ := json.Marshal(resp)
+				w.Write(data)
+				return
+			}
+			w.WriteHeader(404)
+			w.Write([]byte(`{"code": 404, "message": "Stage resource not found"}`))
+		}),
+	)
+}
+
+func TestValidateProgressiveRollout(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		steps   []int32
+		wantErr bool
+	}{
+		{name: "valid", steps: []int32{10, 25, 50, 100}},
+		{name: "single step", steps: []int32{100}},
+		{name: "no steps", steps: nil, wantErr: true},
+		{name: "not ascending", steps: []int32{10, 50, 25, 100}, wantErr: true},
+		{name: "above 100", steps: []int32{10, 120}, wantErr: true},
+		{name: "not ending with 100", steps: []int32{10, 25, 50}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rollout := ProgressiveRollout{Steps: tt.steps}
+			err := rollout.validate()
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestApplyProgressiveRollout(t *testing.T) {
+	ts := mockProgressiveRolloutEndpoint()
+	defer ts.Close()
+
+	tests := []struct {
+		name         string
+		event        keptnevents.ConfigurationChangeEventData
+		wantedWeight int32
+		wantedLabels map[string]string
+	}{
+		{
+			name: "new artifact starts with first step",
+			event: keptnevents.ConfigurationChangeEventData{
+				Project:      "sockshop",
+				Service:      "carts",
+				Stage:        "staging",
+				ValuesCanary: map[string]interface{}{"image": "docker.io/keptnexamples/carts:0.11.1"},
+				Canary:       &keptnevents.Canary{Action: keptnevents.Set, Value: 100},
+				Labels:       map[string]string{"l1": "lValue"},
+			},
+			wantedWeight: 10,
+			wantedLabels: map[string]string{"l1": "lValue", canaryWeightLabel: "10"},
+		},
+		{
+			name: "next step keeps requested weight",
+			event: keptnevents.ConfigurationChangeEventData{
+				Project: "sockshop",
+				Service: "carts",
+				Stage:   "staging",
+				Canary:  &keptnevents.Canary{Action: keptnevents.Set, Value: 50},
+				Labels:  map[string]string{"l1": "lValue", canaryWeightLabel: "25"},
+			},
+			wantedWeight: 50,
+			wantedLabels: map[string]string{"l1": "lValue", canaryWeightLabel: "50"},
+		},
+		{
+			name: "no progressive rollout defined",
+			event: keptnevents.ConfigurationChangeEventData{
+				Project:      "sockshop",
+				Service:      "carts",
+				Stage:        "production",
+				ValuesCanary: map[string]interface{}{"image": "docker.io/keptnexamples/carts:0.11.1"},
+				Canary:       &keptnevents.Canary{Action: keptnevents.Set, Value: 100},
+				Labels:       map[string]string{"l1": "lValue"},
+			},
+			wantedWeight: 100,
+			wantedLabels: map[string]string{"l1": "lValue"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ce := cloudevents.New("0.2")
+			dataBytes, err := json.Marshal(tt.event)
+			if err != nil {
+				t.Error(err)
+			}
+			ce.Data = dataBytes
+			keptnHandler, _ := keptnevents.NewKeptn(&ce, keptnevents.KeptnOpts{})
+			c := &ConfigurationChanger{keptnHandler: keptnHandler, configServiceURL: ts.URL}
+
+			e := tt.event
+			err = c.applyProgressiveRollout(&e)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantedWeight, e.Canary.Value)
+			assert.Equal(t, tt.wantedLabels, e.Labels)
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package readiness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	batchv1 "k8s.io/api/batch/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ConfigURI is the service resource declaring the readiness checks of the service
+const ConfigURI = "readiness.yaml"
+
+// commandChecksEnv enables the command checks, which are executed in the container of the helm-service
+const commandChecksEnv = "ENABLE_READINESS_COMMAND_CHECKS"
+
+const (
+	defaultTimeout  = 30 * time.Second
+	defaultInterval = 5 * time.Second
+)
+
+// Config declares the readiness checks which have to succeed before a deployment is finished
+type Config struct {
+	// Timeout, Retries and Interval are the defaults of the checks
+	Timeout  string  `json:"timeout,omitempty"`
+	Retries  int     `json:"retries,omitempty"`
+	Interval string  `json:"interval,omitempty"`
+	Checks   []Check `json:"checks"`
+}
+
+// Check is a readiness check, which is either an HTTP probe, a Kubernetes Job or a command
+type Check struct {
+	Name string `json:"name"`
+	// Timeout limits a single attempt of the check, e.g. 30s
+	Timeout string `json:"timeout,omitempty"`
+	// Retries is the number of attempts after the first failed attempt
+	Retries *int `json:"retries,omitempty"`
+	// Interval is the time between two attempts, e.g. 5s
+	Interval string `json:"interval,omitempty"`
+
+	HTTP    *HTTPCheck `json:"http,omitempty"`
+	Job     *JobCheck  `json:"job,omitempty"`
+	Command []string   `json:"command,omitempty"`
+}
+
+// HTTPCheck probes the local URI of the deployment
+type HTTPCheck struct {
+	Path string `json:"path,omitempty"`
+	Port int    `json:"port,omitempty"`
+	// ExpectedStatus is the expected status code, any 2xx status code is accepted by default
+	ExpectedStatus int `json:"expectedStatus,omitempty"`
+}
+
+// JobCheck waits for a Kubernetes Job to complete. If a spec is provided, the Job is recreated by the check,
+// otherwise the Job is expected to be created by the chart.
+type JobCheck struct {
+	Name string           `json:"name"`
+	Spec *batchv1.JobSpec `json:"spec,omitempty"`
+}
+
+// Target describes the deployment which is checked
+type Target struct {
+	Project   string
+	Stage     string
+	Service   string
+	Namespace string
+	// LocalURI is the cluster-internal URI of the deployment, e.g. http://carts.sockshop-dev
+	LocalURI string
+}
+
+// ParseConfig parses and validates the readiness checks
+func ParseConfig(data []byte) (*Config, error) {
+
+	config := &Config{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("error when parsing %s: %v", ConfigURI, err)
+	}
+	for i, check := range config.Checks {
+		if check.Name == "" {
+			config.Checks[i].Name = fmt.Sprintf("check-%d", i+1)
+		}
+		kinds := 0
+		if check.HTTP != nil {
+			kinds++
+		}
+		if check.Job != nil {
+			kinds++
+			if check.Job.Name == "" {
+				return nil, fmt.Errorf("job of readiness check %s has no name", config.Checks[i].Name)
+			}
+		}
+		if len(check.Command) > 0 {
+			kinds++
+		}
+		if kinds != 1 {
+			return nil, fmt.Errorf("readiness check %s has to define exactly one of http, job or command", config.Checks[i].Name)
+		}
+		if _, _, _, err := config.getAttempts(check); err != nil {
+			return nil, fmt.Errorf("readiness check %s is invalid: %v", config.Checks[i].Name, err)
+		}
+	}
+	return config, nil
+}
+
+// getAttempts returns the timeout, retries and interval of the check
+func (c *Config) getAttempts(check Check) (time.Duration, int, time.Duration, error) {
+
+	timeout, err := parseDuration(check.Timeout, c.Timeout, defaultTimeout)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	interval, err := parseDuration(check.Interval, c.Interval, defaultInterval)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	retries := c.Retries
+	if check.Retries != nil {
+		retries = *check.Retries
+	}
+	if retries < 0 {
+		return 0, 0, 0, errors.New("retries must not be negative")
+	}
+	return timeout, retries, interval, nil
+}
+
+func parseDuration(value string, defaultValue string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		value = defaultValue
+	}
+	if value == "" {
+		return fallback, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// Checker executes readiness checks
+type Checker struct {
+	clientset  kubernetes.Interface
+	httpClient *http.Client
+	// commandChecksEnabled allows command checks, they are rejected otherwise
+	commandChecksEnabled bool
+	// sleep waits between two attempts
+	sleep func(time.Duration)
+}
+
+// NewChecker creates a new Checker, which runs the Job checks in the cluster of the clientset. Command checks are
+// only allowed if ENABLE_READINESS_COMMAND_CHECKS is set to true.
+func NewChecker(clientset kubernetes.Interface) *Checker {
+	return &Checker{
+		clientset:            clientset,
+		httpClient:           &http.Client{},
+		commandChecksEnabled: os.Getenv(commandChecksEnv) == "true",
+		sleep:                time.Sleep,
+	}
+}
+
+// Run executes the checks one after another and returns the error of the first check which did not succeed
+// within its retries
+func (c *Checker) Run(config *Config, target Target) error {
+
+	for _, check := range config.Checks {
+		if len(check.Command) > 0 && !c.commandChecksEnabled {
+			return fmt.Errorf("readiness check %s is a command check, which requires %s=true in the helm-service",
+				check.Name, commandChecksEnv)
+		}
+	}
+	for _, check := range config.Checks {
+		timeout, retries, interval, err := config.getAttempts(check)
+		if err != nil {
+			return err
+		}
+		for attempt := 0; ; attempt++ {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			err = c.runCheck(ctx, check, target)
+			cancel()
+			if err == nil {
+				break
+			}
+			if attempt >= retries {
+				return fmt.Errorf("readiness check %s failed after %d attempts: %v", check.Name, attempt+1, err)
+			}
+			c.sleep(interval)
+		}
+	}
+	return nil
+}
+
+func (c *Checker) runCheck(ctx context.Context, check Check, target Target) error {
+	switch {
+	case check.HTTP != nil:
+		return c.runHTTPCheck(ctx, check.HTTP, target)
+	case check.Job != nil:
+		return c.runJobCheck(ctx, check.Job, target)
+	default:
+		return runCommandCheck(ctx, check.Command, target)
+	}
+}
+
+func (c *Checker) runHTTPCheck(ctx context.Context, check *HTTPCheck, target Target) error {
+
+	uri := target.LocalURI
+	if check.Port != 0 {
+		uri += fmt.Sprintf(":%d", check.Port)
+	}
+	uri += "/" + strings.TrimPrefix(check.Path, "/")
+
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	ioutil.ReadAll(resp.Body)
+
+	if check.ExpectedStatus != 0 && resp.StatusCode != check.ExpectedStatus {
+		return fmt.Errorf("%s returned status %d instead of %d", uri, resp.StatusCode, check.ExpectedStatus)
+	}
+	if check.ExpectedStatus == 0 && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
+		return fmt.Errorf("%s returned status %d", uri, resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *Checker) runJobCheck(ctx context.Context, check *JobCheck, target Target) error {
+
+	jobs := c.clientset.BatchV1().Jobs(target.Namespace)
+	if check.Spec != nil {
+		if err := c.recreateJob(ctx, check, target); err != nil {
+			return err
+		}
+	}
+
+	for {
+		job, err := jobs.Get(check.Name, metav1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil {
+			for _, condition := range job.Status.Conditions {
+				if condition.Type == batchv1.JobComplete && condition.Status == "True" {
+					return nil
+				}
+				if condition.Type == batchv1.JobFailed && condition.Status == "True" {
+					return fmt.Errorf("job %s failed: %s", check.Name, condition.Message)
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("job %s did not complete in time", check.Name)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// recreateJob deletes the Job of a previous deployment and creates it again, as the spec of a Job is immutable
+func (c *Checker) recreateJob(ctx context.Context, check *JobCheck, target Target) error {
+
+	jobs := c.clientset.BatchV1().Jobs(target.Namespace)
+	propagation := metav1.DeletePropagationBackground
+	err := jobs.Delete(check.Name, &metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("error when deleting job %s: %v", check.Name, err)
+	}
+	// a Job cannot be created again until the deletion of the previous one has finished
+	for err == nil {
+		_, err = jobs.Get(check.Name, metav1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("job %s was not deleted in time", check.Name)
+			case <-time.After(time.Second):
+			}
+		}
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      check.Name,
+			Namespace: target.Namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "keptn", "keptn.sh/service": target.Service},
+		},
+		Spec: *check.Spec.DeepCopy(),
+	}
+	if _, err := jobs.Create(job); err != nil {
+		return fmt.Errorf("error when creating job %s: %v", check.Name, err)
+	}
+	return nil
+}
+
+// runCommandCheck executes the command in the container of the helm-service. The target is passed by environment
+// variables; apart from PATH, the environment of the helm-service is not passed to the command.
+func runCommandCheck(ctx context.Context, command []string, target Target) error {
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"KEPTN_PROJECT=" + target.Project,
+		"KEPTN_STAGE=" + target.Stage,
+		"KEPTN_SERVICE=" + target.Service,
+		"KEPTN_NAMESPACE=" + target.Namespace,
+		"DEPLOYMENT_URI_LOCAL=" + target.LocalURI,
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("command %s failed: %v: %s", strings.Join(command, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
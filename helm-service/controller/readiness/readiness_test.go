@@ -0,0 +1,171 @@
+package readiness
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestChecker(objects ...runtime.Object) *Checker {
+	c := NewChecker(fake.NewSimpleClientset(objects...))
+	c.sleep = func(time.Duration) {}
+	return c
+}
+
+func TestParseConfig(t *testing.T) {
+
+	config, err := ParseConfig([]byte(`timeout: 10s
+retries: 2
+checks:
+- http:
+    path: /health
+- name: migrations
+  retries: 0
+  job:
+    name: carts-migrations
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, "check-1", config.Checks[0].Name)
+
+	timeout, retries, interval, err := config.getAttempts(config.Checks[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, timeout)
+	assert.Equal(t, 2, retries)
+	assert.Equal(t, defaultInterval, interval)
+
+	_, retries, _, _ = config.getAttempts(config.Checks[1])
+	assert.Equal(t, 0, retries)
+
+	_, err = ParseConfig([]byte("checks:\n- name: empty\n"))
+	assert.Error(t, err)
+	_, err = ParseConfig([]byte("checks:\n- command: [\"true\"]\n  http:\n    path: /\n"))
+	assert.Error(t, err)
+	_, err = ParseConfig([]byte("checks:\n- command: [\"true\"]\n  timeout: soon\n"))
+	assert.Error(t, err)
+}
+
+func TestRunHTTPCheck(t *testing.T) {
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/health", r.URL.Path)
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	config, err := ParseConfig([]byte("retries: 2\nchecks:\n- http:\n    path: health\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, newTestChecker().Run(config, Target{LocalURI: ts.URL}))
+	assert.Equal(t, 3, requests)
+
+	requests = 0
+	config.Retries = 1
+	err = newTestChecker().Run(config, Target{LocalURI: ts.URL})
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "failed after 2 attempts"))
+}
+
+func TestRunJobCheck(t *testing.T) {
+
+	completed := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "carts-migrations", Namespace: "sockshop-dev"},
+		Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+		}},
+	}
+	failed := completed.DeepCopy()
+	failed.Name = "carts-seed"
+	failed.Status.Conditions[0].Type = batchv1.JobFailed
+
+	checker := newTestChecker(completed, failed)
+	target := Target{Namespace: "sockshop-dev"}
+
+	config, err := ParseConfig([]byte("checks:\n- job:\n    name: carts-migrations\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, checker.Run(config, target))
+
+	config, err = ParseConfig([]byte("checks:\n- job:\n    name: carts-seed\n"))
+	assert.NoError(t, err)
+	assert.Error(t, checker.Run(config, target))
+
+	config, err = ParseConfig([]byte("checks:\n- timeout: 1ms\n  job:\n    name: carts-missing\n"))
+	assert.NoError(t, err)
+	assert.Error(t, checker.Run(config, target))
+}
+
+func TestRecreateJob(t *testing.T) {
+
+	checker := newTestChecker(&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "carts-migrations", Namespace: "sockshop-dev"}})
+	check := &JobCheck{Name: "carts-migrations", Spec: &batchv1.JobSpec{
+		Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "migrate", Image: "carts-migrate:0.1.0"}}}},
+	}}
+
+	assert.NoError(t, checker.recreateJob(context.Background(), check, Target{Namespace: "sockshop-dev", Service: "carts"}))
+	job, err := checker.clientset.BatchV1().Jobs("sockshop-dev").Get("carts-migrations", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "carts-migrate:0.1.0", job.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "carts", job.Labels["keptn.sh/service"])
+}
+
+func TestRecreateJobWaitsForDeletion(t *testing.T) {
+
+	checker := newTestChecker(&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "carts-migrations", Namespace: "sockshop-dev"}})
+	// the Job is not removed as long as its finalizers have not run
+	checker.clientset.(*fake.Clientset).PrependReactor("delete", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+	check := &JobCheck{Name: "carts-migrations", Spec: &batchv1.JobSpec{}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := checker.recreateJob(ctx, check, Target{Namespace: "sockshop-dev", Service: "carts"})
+	assert.EqualError(t, err, "job carts-migrations was not deleted in time")
+}
+
+func TestRunCommandCheckIsDisabledByDefault(t *testing.T) {
+
+	config, err := ParseConfig([]byte(`checks:
+- name: cache
+  command: ["sh", "-c", "exit 0"]
+`))
+	assert.NoError(t, err)
+	assert.EqualError(t, newTestChecker().Run(config, Target{Service: "carts"}),
+		"readiness check cache is a command check, which requires ENABLE_READINESS_COMMAND_CHECKS=true in the helm-service")
+}
+
+func TestRunCommandCheck(t *testing.T) {
+
+	os.Setenv("ENABLE_READINESS_COMMAND_CHECKS", "true")
+	defer os.Unsetenv("ENABLE_READINESS_COMMAND_CHECKS")
+
+	config, err := ParseConfig([]byte(`checks:
+- command: ["sh", "-c", "test \"$KEPTN_SERVICE\" = carts"]
+`))
+	assert.NoError(t, err)
+	assert.NoError(t, newTestChecker().Run(config, Target{Service: "carts"}))
+	assert.Error(t, newTestChecker().Run(config, Target{Service: "orders"}))
+
+	// the environment of the helm-service is not passed to commands
+	os.Setenv("GIT_TOKEN", "secret")
+	defer os.Unsetenv("GIT_TOKEN")
+	config, err = ParseConfig([]byte(`checks:
+- command: ["sh", "-c", "test -z \"$GIT_TOKEN\""]
+`))
+	assert.NoError(t, err)
+	assert.NoError(t, newTestChecker().Run(config, Target{Service: "carts"}))
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"fmt"
+
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+
+	"github.com/keptn/keptn/helm-service/controller/helm"
+	"github.com/keptn/keptn/helm-service/controller/readiness"
+)
+
+// newReadinessChecker creates the checker for the readiness checks of a deployment in the stage
+var newReadinessChecker = func(stageTarget helm.StageTarget) (*readiness.Checker, error) {
+	clientset, err := helm.GetClientset(stageTarget)
+	if err != nil {
+		return nil, err
+	}
+	return readiness.NewChecker(clientset), nil
+}
+
+// runReadinessChecks runs the readiness checks declared by the service in the stage. Services without
+// readiness checks are ready as soon as their workloads are rolled out.
+func (c *ConfigurationChanger) runReadinessChecks(keptnHandler *keptnevents.Keptn, e *keptnevents.ConfigurationChangeEventData,
+	stageTarget helm.StageTarget, deploymentStrategy keptnevents.DeploymentStrategy) error {
+
+	rHandler := configutils.NewResourceHandler(c.configServiceURL)
+	resource, err := rHandler.GetServiceResource(e.Project, e.Stage, e.Service, readiness.ConfigURI)
+	if err == configutils.ResourceNotFoundError {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error when reading %s of service %s in stage %s: %v", readiness.ConfigURI, e.Service, e.Stage, err)
+	}
+	config, err := readiness.ParseConfig([]byte(resource.ResourceContent))
+	if err != nil {
+		return err
+	}
+	if len(config.Checks) == 0 {
+		return nil
+	}
+
+	testStrategy, err := getTestStrategy(keptnHandler, e.Stage)
+	if err != nil {
+		return err
+	}
+	checker, err := newReadinessChecker(stageTarget)
+	if err != nil {
+		return err
+	}
+
+	c.keptnHandler.Logger.Info(fmt.Sprintf("Run %d readiness checks for service %s in stage %s of project %s",
+		len(config.Checks), e.Service, e.Stage, e.Project))
+	if err := checker.Run(config, readiness.Target{
+		Project:   e.Project,
+		Stage:     e.Stage,
+		Service:   e.Service,
+		Namespace: stageTarget.Namespace,
+		LocalURI:  getLocalDeploymentURI(stageTarget.Namespace, e.Service, deploymentStrategy, testStrategy),
+	}); err != nil {
+		return err
+	}
+	c.keptnHandler.Logger.Info(fmt.Sprintf("Readiness checks for service %s in stage %s of project %s succeeded",
+		e.Service, e.Stage, e.Project))
+	return nil
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"sync"
+)
+
+// queuedOperation is an operation on a service waiting in the ServiceQueue
+type queuedOperation struct {
+	run func()
+	// skip is called instead of run if the operation is superseded. It is nil if the operation cannot be superseded.
+	skip func()
+}
+
+// ServiceQueue serializes the operations on a service in a stage, while the operations on different
+// services are executed in parallel
+type ServiceQueue struct {
+	mutex   sync.Mutex
+	pending map[string][]*queuedOperation
+	running map[string]bool
+}
+
+// NewServiceQueue creates a new ServiceQueue
+func NewServiceQueue() *ServiceQueue {
+	return &ServiceQueue{
+		pending: make(map[string][]*queuedOperation),
+		running: make(map[string]bool),
+	}
+}
+
+func getServiceQueueKey(project string, stage string, service string) string {
+	return project + "/" + stage + "/" + service
+}
+
+// Enqueue adds an operation to the queue of the service and executes it after all previous operations on the service.
+// An operation providing a skip function supersedes the pending operations of the service, which provide a skip
+// function as well. Their skip function is called instead of executing them.
+func (q *ServiceQueue) Enqueue(project string, stage string, service string, run func(), skip func()) {
+
+	key := getServiceQueueKey(project, stage, service)
+	skipped := []*queuedOperation{}
+
+	q.mutex.Lock()
+	if skip != nil {
+		remaining := []*queuedOperation{}
+		for _, op := range q.pending[key] {
+			if op.skip != nil {
+				skipped = append(skipped, op)
+			} else {
+				remaining = append(remaining, op)
+			}
+		}
+		q.pending[key] = remaining
+	}
+	q.pending[key] = append(q.pending[key], &queuedOperation{run: run, skip: skip})
+	start := !q.running[key]
+	q.running[key] = true
+	q.mutex.Unlock()
+
+	for _, op := range skipped {
+		go op.skip()
+	}
+	if start {
+		go q.work(key)
+	}
+}
+
+// work executes the pending operations of the service until its queue is empty
+func (q *ServiceQueue) work(key string) {
+	for {
+		q.mutex.Lock()
+		ops := q.pending[key]
+		if len(ops) == 0 {
+			delete(q.pending, key)
+			delete(q.running, key)
+			q.mutex.Unlock()
+			return
+		}
+		q.pending[key] = ops[1:]
+		q.mutex.Unlock()
+
+		ops[0].run()
+	}
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceQueueSerializesOperationsOfService(t *testing.T) {
+
+	queue := NewServiceQueue()
+	release := make(chan bool)
+	var mutex sync.Mutex
+	executed := []string{}
+	var wg sync.WaitGroup
+
+	record := func(name string) func() {
+		return func() {
+			mutex.Lock()
+			executed = append(executed, name)
+			mutex.Unlock()
+			wg.Done()
+		}
+	}
+
+	wg.Add(3)
+	queue.Enqueue("sockshop", "dev", "carts", func() {
+		<-release
+		record("first")()
+	}, nil)
+	queue.Enqueue("sockshop", "dev", "carts", record("second"), nil)
+	queue.Enqueue("sockshop", "dev", "carts", record("third"), nil)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, []string{"first", "second", "third"}, executed)
+}
+
+func TestServiceQueueRunsServicesInParallel(t *testing.T) {
+
+	queue := NewServiceQueue()
+	release := make(chan bool)
+	done := make(chan bool)
+
+	queue.Enqueue("sockshop", "dev", "carts", func() { <-release }, nil)
+	queue.Enqueue("sockshop", "dev", "orders", func() { done <- true }, nil)
+	queue.Enqueue("sockshop", "staging", "carts", func() { done <- true }, nil)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("operation of another service was blocked")
+		}
+	}
+	close(release)
+}
+
+func TestServiceQueueSkipsSupersededOperations(t *testing.T) {
+
+	queue := NewServiceQueue()
+	release := make(chan bool)
+	var mutex sync.Mutex
+	executed := []string{}
+	skipped := []string{}
+	var wg sync.WaitGroup
+
+	enqueue := func(name string, supersedable bool) {
+		wg.Add(1)
+		run := func() {
+			mutex.Lock()
+			executed = append(executed, name)
+			mutex.Unlock()
+			wg.Done()
+		}
+		var skip func()
+		if supersedable {
+			skip = func() {
+				mutex.Lock()
+				skipped = append(skipped, name)
+				mutex.Unlock()
+				wg.Done()
+			}
+		}
+		queue.Enqueue("sockshop", "dev", "carts", run, skip)
+	}
+
+	wg.Add(1)
+	queue.Enqueue("sockshop", "dev", "carts", func() {
+		<-release
+		wg.Done()
+	}, nil)
+	enqueue("artifact-1", true)
+	enqueue("promote", false)
+	enqueue("artifact-2", true)
+	enqueue("artifact-3", true)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, []string{"promote", "artifact-3"}, executed)
+	assert.ElementsMatch(t, []string{"artifact-1", "artifact-2"}, skipped)
+}
+
+func TestEnqueueConfigurationChangeResolvesStageInQueue(t *testing.T) {
+
+	os.Setenv("PRE_WORKFLOW_ENGINE", "true")
+	defer os.Unsetenv("PRE_WORKFLOW_ENGINE")
+
+	releaseShipyard := make(chan bool)
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-releaseShipyard
+			w.Header().Add("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(models.Resource{
+				ResourceContent: base64.StdEncoding.EncodeToString([]byte("stages:\n- name: dev\n")),
+			})
+		}),
+	)
+	defer ts.Close()
+
+	ce := cloudevents.New("0.2")
+	ce.Data, _ = json.Marshal(keptnevents.ConfigurationChangeEventData{
+		Project:      "sockshop",
+		Service:      "carts",
+		ValuesCanary: map[string]interface{}{"image": "docker.io/keptnexamples/carts:0.11.1"},
+	})
+	keptnHandler, _ := keptnevents.NewKeptn(&ce, keptnevents.KeptnOpts{ConfigurationServiceURL: ts.URL})
+	c := &ConfigurationChanger{keptnHandler: keptnHandler}
+
+	// the configuration change is kept pending in the resolved stage, hence it is not applied
+	queue := NewServiceQueue()
+	queue.Enqueue("sockshop", "dev", "carts", func() { select {} }, nil)
+
+	enqueued := make(chan error)
+	go func() { enqueued <- c.EnqueueConfigurationChange(queue, ce, make(chan bool, 1)) }()
+	select {
+	case err := <-enqueued:
+		assert.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("enqueueing waited for the shipyard")
+	}
+	close(releaseShipyard)
+
+	key := getServiceQueueKey("sockshop", "dev", "carts")
+	assert.Eventually(t, func() bool {
+		queue.mutex.Lock()
+		defer queue.mutex.Unlock()
+		return len(queue.pending[key]) == 1 && queue.pending[key][0].skip != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
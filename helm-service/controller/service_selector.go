@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"fmt"
+
+	keptnevents "github.com/keptn/go-utils/pkg/lib"
+	keptnutils "github.com/keptn/kubernetes-utils/pkg"
+	"helm.sh/helm/v3/pkg/postrender"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/keptn/keptn/helm-service/controller/helm"
+	"github.com/keptn/keptn/helm-service/controller/mesh"
+)
+
+// getServiceSelectorClientset creates the clientset switching the service selectors in the stage
+var getServiceSelectorClientset = func(stageTarget helm.StageTarget) (kubernetes.Interface, error) {
+	return helm.GetClientset(stageTarget)
+}
+
+// switchesServiceSelectors returns whether the blue-green deployment of the service is switched by the selectors of its services
+func switchesServiceSelectors(m mesh.Mesh, strategy keptnevents.DeploymentStrategy) bool {
+	return !m.SplitsTraffic() && strategy == keptnevents.Duplicate
+}
+
+// getUserChartPostRenderer returns the post-renderer keeping the services of the user chart on the primary pods,
+// or nil if the services are not switched by their selectors. As long as no primary pods are deployed,
+// the services select the pods of the user chart.
+func getUserChartPostRenderer(m mesh.Mesh, project string, stage string, service string,
+	strategy keptnevents.DeploymentStrategy, configServiceURL string) (postrender.PostRenderer, error) {
+
+	if !switchesServiceSelectors(m, strategy) {
+		return nil, nil
+	}
+	genChart, err := keptnutils.GetChart(project, service, stage, helm.GetChartName(service, true), configServiceURL)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading the generated chart of service %s: %v", service, err)
+	}
+	if len(genChart.Templates) == 0 {
+		return nil, nil
+	}
+	return helm.NewPrimaryServicePostRenderer(), nil
+}
+
+// switchServiceSelectors points the services of the user chart to the canary or the primary pods, depending on the
+// canary weight. Services of meshes splitting the traffic are not changed.
+func (c *ConfigurationChanger) switchServiceSelectors(e *keptnevents.ConfigurationChangeEventData, stageTarget helm.StageTarget,
+	deploymentStrategy keptnevents.DeploymentStrategy, canaryWeight int32) error {
+
+	if !switchesServiceSelectors(c.mesh, deploymentStrategy) {
+		return nil
+	}
+	userChartManifest, err := c.helmExecutor.GetManifest(helm.GetReleaseName(e.Project, e.Stage, e.Service, false), stageTarget)
+	if err != nil {
+		return err
+	}
+	clientset, err := getServiceSelectorClientset(stageTarget)
+	if err != nil {
+		return err
+	}
+
+	// As the traffic cannot be split, the canary only receives the traffic at a weight of 100
+	primary := canaryWeight < 100
+	target := "canary"
+	if primary {
+		target = "primary"
+	}
+	c.keptnHandler.Logger.Info(fmt.Sprintf("Switching the services of service %s in stage %s of project %s to the %s pods",
+		e.Service, e.Stage, e.Project, target))
+	return helm.SwitchServiceSelectors(clientset, userChartManifest, stageTarget.Namespace, primary)
+}
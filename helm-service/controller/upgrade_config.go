@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ghodss/yaml"
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/keptn/helm-service/controller/helm"
+)
+
+// upgradeConfigURI is the project or stage resource configuring how charts are upgraded
+const upgradeConfigURI = "helm-service.yaml"
+
+// UpgradeConfig configures how the helm-service upgrades the charts of a project or stage
+type UpgradeConfig struct {
+	// Atomic rolls back a release if its upgrade fails
+	Atomic bool `json:"atomic"`
+	// Timeout is the maximum duration of an upgrade, e.g. 5m
+	Timeout string `json:"timeout"`
+}
+
+// getUpgradeOptions returns the upgrade options of the stage. The configuration of the project is
+// used as default and can be overridden by the configuration of the stage.
+func getUpgradeOptions(project string, stage string, configServiceURL string) (helm.UpgradeOptions, error) {
+
+	rHandler := configutils.NewResourceHandler(configServiceURL)
+	config := &UpgradeConfig{}
+
+	projectResource, err := rHandler.GetProjectResource(project, upgradeConfigURI)
+	if err != nil && err != configutils.ResourceNotFoundError {
+		return helm.UpgradeOptions{}, fmt.Errorf("error when reading %s of project %s: %v", upgradeConfigURI, project, err)
+	}
+	if err == nil {
+		if err := yaml.Unmarshal([]byte(projectResource.ResourceContent), config); err != nil {
+			return helm.UpgradeOptions{}, fmt.Errorf("error when parsing %s of project %s: %v", upgradeConfigURI, project, err)
+		}
+	}
+
+	stageResource, err := rHandler.GetStageResource(project, stage, upgradeConfigURI)
+	if err != nil && err != configutils.ResourceNotFoundError {
+		return helm.UpgradeOptions{}, fmt.Errorf("error when reading %s of stage %s: %v", upgradeConfigURI, stage, err)
+	}
+	if err == nil {
+		if err := yaml.Unmarshal([]byte(stageResource.ResourceContent), config); err != nil {
+			return helm.UpgradeOptions{}, fmt.Errorf("error when parsing %s of stage %s: %v", upgradeConfigURI, stage, err)
+		}
+	}
+
+	return config.toUpgradeOptions()
+}
+
+func (c *UpgradeConfig) toUpgradeOptions() (helm.UpgradeOptions, error) {
+	opts := helm.UpgradeOptions{Atomic: c.Atomic}
+	if c.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return helm.UpgradeOptions{}, fmt.Errorf("invalid timeout %s: %v", c.Timeout, err)
+		}
+		opts.Timeout = timeout
+	}
+	return opts, nil
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/helm-service/controller/helm"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockUpgradeConfigEndpoint(resources map[string]string) *httptest.Server {
+
+	return httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			for uri, content := range resources {
+				if r.Method == http.MethodGet && strings.Contains(r.RequestURI, uri) {
+					w.WriteHeader(200)
+					resp := models.Resource{
+						ResourceContent: base64.StdEncoding.EncodeToString([]byte(content)),
+						ResourceURI:     stringp(upgradeConfigURI),
+					}
+					data, _ := json.Marshal(resp)
+					w.Write(data)
+					return
+				}
+			}
+			w.WriteHeader(404)
+			w.Write([]byte(`{"code": 404, "message": "Resource not found"}`))
+		}),
+	)
+}
+
+func TestGetUpgradeOptions(t *testing.T) {
+
+	const projectURI = "/v1/project/sockshop/resource/helm-service.yaml"
+	const stageURI = "/v1/project/sockshop/stage/staging/resource/helm-service.yaml"
+
+	tests := []struct {
+		name      string
+		resources map[string]string
+		want      helm.UpgradeOptions
+		wantErr   bool
+	}{
+		{
+			name: "no configuration",
+			want: helm.UpgradeOptions{},
+		},
+		{
+			name:      "project configuration",
+			resources: map[string]string{projectURI: "atomic: true\ntimeout: 5m\n"},
+			want:      helm.UpgradeOptions{Atomic: true, Timeout: 5 * time.Minute},
+		},
+		{
+			name: "stage overrides project configuration",
+			resources: map[string]string{
+				projectURI: "atomic: true\ntimeout: 5m\n",
+				stageURI:   "timeout: 90s\n",
+			},
+			want: helm.UpgradeOptions{Atomic: true, Timeout: 90 * time.Second},
+		},
+		{
+			name:      "invalid timeout",
+			resources: map[string]string{stageURI: "timeout: soon\n"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := mockUpgradeConfigEndpoint(tt.resources)
+			defer ts.Close()
+
+			opts, err := getUpgradeOptions("sockshop", "staging", ts.URL)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, opts)
+		})
+	}
+}
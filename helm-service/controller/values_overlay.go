@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+
+	"github.com/keptn/keptn/helm-service/controller/helm"
+	"github.com/keptn/keptn/helm-service/pkg/secretvalues"
+	"github.com/keptn/keptn/helm-service/pkg/serviceutils"
+)
+
+// valuesOverlayDir is the directory of service resources containing values files of the stage
+const valuesOverlayDir = "helm/values/"
+
+// getValuesKey returns the key for decrypting the encrypted values files of the project
+var getValuesKey = func(project string) ([]byte, error) {
+	clientset, err := helm.GetLocalClientset()
+	if err != nil {
+		return nil, err
+	}
+	return secretvalues.GetKey(clientset, helm.GetPodNamespace(), project)
+}
+
+// getStageValuesURI returns the service resource containing the values of the stage
+func getStageValuesURI(stage string) string {
+	return "helm/values-" + stage + ".yaml"
+}
+
+// getValuesOverlay returns the values of the stage, which are merged on top of the values of the user chart.
+// The files in helm/values/ are merged in alphabetical order, followed by helm/values-STAGENAME.yaml.
+// Encrypted values files are decrypted in memory only. The overlay is empty if the resources of the service cannot be listed.
+func getValuesOverlay(project string, stage string, service string, configServiceURL string) (map[string]interface{}, error) {
+
+	rHandler := configutils.NewResourceHandler(configServiceURL)
+	resources, err := serviceutils.ListServiceResources(project, stage, service, configServiceURL)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading resources of service %s in stage %s: %v", service, stage, err)
+	}
+
+	overlayURIs := []string{}
+	stageValuesURI := ""
+	for _, resource := range resources {
+		if resource.ResourceURI == nil {
+			continue
+		}
+		uri := strings.TrimPrefix(*resource.ResourceURI, "/")
+		if uri == getStageValuesURI(stage) {
+			stageValuesURI = uri
+		} else if strings.HasPrefix(uri, valuesOverlayDir) && (strings.HasSuffix(uri, ".yaml") || strings.HasSuffix(uri, ".yml")) {
+			overlayURIs = append(overlayURIs, uri)
+		}
+	}
+	sort.Strings(overlayURIs)
+	if stageValuesURI != "" {
+		overlayURIs = append(overlayURIs, stageValuesURI)
+	}
+
+	overlay := map[string]interface{}{}
+	for _, uri := range overlayURIs {
+		resource, err := rHandler.GetServiceResource(project, stage, service, uri)
+		if err != nil {
+			return nil, fmt.Errorf("error when reading %s of service %s in stage %s: %v", uri, service, stage, err)
+		}
+		content := []byte(resource.ResourceContent)
+		if secretvalues.IsEncrypted(content) {
+			key, err := getValuesKey(project)
+			if err != nil {
+				return nil, err
+			}
+			if content, err = secretvalues.Decrypt(content, key, project); err != nil {
+				return nil, fmt.Errorf("error when decrypting %s of service %s in stage %s: %v", uri, service, stage, err)
+			}
+		}
+		values := map[string]interface{}{}
+		if err := yaml.Unmarshal(content, &values); err != nil {
+			return nil, fmt.Errorf("error when parsing %s of service %s in stage %s: %v", uri, service, stage, err)
+		}
+		mergeValues(overlay, values)
+	}
+	return overlay, nil
+}
+
+// getChartValues returns the values for upgrading or rendering a chart. The values overlay of the stage is
+// applied to the user chart only, as the generated chart is derived from the deployed user chart.
+func getChartValues(project string, stage string, service string, deploymentName string, generated bool,
+	configServiceURL string) (map[string]interface{}, error) {
+
+	vals := map[string]interface{}{}
+	if !generated {
+		overlay, err := getValuesOverlay(project, stage, service, configServiceURL)
+		if err != nil {
+			return nil, err
+		}
+		vals = overlay
+	}
+	mergeValues(vals, getKeptnValues(project, stage, service, deploymentName))
+	return vals, nil
+}
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn/keptn/helm-service/pkg/secretvalues"
+)
+
+func mockValuesOverlayEndpoints(resources map[string]string) *httptest.Server {
+
+	const prefix = "/v1/project/sockshop/stage/dev/service/carts/resource/"
+	return httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			if r.Method == http.MethodGet && r.URL.Path == prefix {
+				list := models.Resources{}
+				for uri := range resources {
+					resourceURI := "/" + uri
+					list.Resources = append(list.Resources, &models.Resource{ResourceURI: &resourceURI})
+				}
+				json.NewEncoder(w).Encode(list)
+				return
+			}
+			if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, prefix) {
+				uri := strings.TrimPrefix(r.URL.Path, prefix)
+				if content, ok := resources[uri]; ok {
+					json.NewEncoder(w).Encode(models.Resource{
+						ResourceURI:     &uri,
+						ResourceContent: base64.StdEncoding.EncodeToString([]byte(content)),
+					})
+					return
+				}
+			}
+			w.WriteHeader(404)
+			w.Write([]byte(`{"code": 404, "message": "Resource not found"}`))
+		}),
+	)
+}
+
+func TestGetChartValues(t *testing.T) {
+
+	ts := mockValuesOverlayEndpoints(map[string]string{
+		"helm/carts.tgz":              "chart",
+		"helm/values/b-limits.yaml":   "resources:\n  limits:\n    cpu: 500m\n    memory: 256Mi\n",
+		"helm/values/a-endpoint.yaml": "endpoint: http://orders\nresources:\n  limits:\n    cpu: 100m\n",
+		"helm/values-dev.yaml":        "endpoint: http://orders.sockshop-dev\nkeptn:\n  stage: overridden\n",
+		"helm/values-production.yaml": "endpoint: http://orders.sockshop-production\n",
+	})
+	defer ts.Close()
+
+	vals, err := getChartValues("sockshop", "dev", "carts", "carts", false, ts.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"endpoint": "http://orders.sockshop-dev",
+		"resources": map[string]interface{}{
+			"limits": map[string]interface{}{"cpu": "500m", "memory": "256Mi"},
+		},
+		"keptn": map[string]interface{}{
+			"project":    "sockshop",
+			"stage":      "dev",
+			"service":    "carts",
+			"deployment": "carts",
+		},
+	}, vals)
+
+	// the generated chart is derived from the deployed user chart, hence the overlay is not applied
+	vals, err = getChartValues("sockshop", "dev", "carts", "carts-primary", true, ts.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, getKeptnValues("sockshop", "dev", "carts", "carts-primary"), vals)
+}
+
+func TestGetChartValuesWithoutResourceListing(t *testing.T) {
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotImplemented)
+			w.Write([]byte(`"operation has not yet been implemented"`))
+		}),
+	)
+	defer ts.Close()
+
+	vals, err := getChartValues("sockshop", "dev", "carts", "carts", false, ts.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, getKeptnValues("sockshop", "dev", "carts", "carts"), vals)
+}
+
+func TestGetChartValuesWithInvalidOverlay(t *testing.T) {
+
+	ts := mockValuesOverlayEndpoints(map[string]string{
+		"helm/values-dev.yaml": "- not a map",
+	})
+	defer ts.Close()
+
+	_, err := getChartValues("sockshop", "dev", "carts", "carts", false, ts.URL)
+	assert.Error(t, err)
+}
+
+func TestGetChartValuesWithEncryptedOverlay(t *testing.T) {
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	encrypted, err := secretvalues.Encrypt([]byte("db:\n  password: secret\n"), key, "sockshop")
+	assert.NoError(t, err)
+
+	ts := mockValuesOverlayEndpoints(map[string]string{
+		"helm/values/secrets.yaml": string(encrypted),
+		"helm/values-dev.yaml":     "db:\n  user: carts\n",
+	})
+	defer ts.Close()
+
+	getKey := getValuesKey
+	defer func() { getValuesKey = getKey }()
+	getValuesKey = func(project string) ([]byte, error) {
+		return key, nil
+	}
+
+	vals, err := getChartValues("sockshop", "dev", "carts", "carts", false, ts.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"password": "secret", "user": "carts"}, vals["db"])
+
+	getValuesKey = func(project string) ([]byte, error) {
+		return []byte("fedcba9876543210fedcba9876543210"), nil
+	}
+	_, err = getChartValues("sockshop", "dev", "carts", "carts", false, ts.URL)
+	assert.Error(t, err)
+}
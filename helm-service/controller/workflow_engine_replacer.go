@@ -1,8 +1,10 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go"
@@ -40,22 +42,56 @@ func getTestStrategy(keptnHandler *keptnevents.Keptn, stageName string) (string,
 	return "", fmt.Errorf("Cannot find stage %s in project %s", stageName, keptnHandler.KeptnBase.Project)
 }
 
-func getLocalDeploymentURI(project string, service string, stage string, deploymentStrategy keptnevents.DeploymentStrategy, testStrategy string) string {
+func getLocalDeploymentURI(namespace string, service string, deploymentStrategy keptnevents.DeploymentStrategy, testStrategy string) string {
 
 	// Use educated guess of the service url based on stage, service name, deployment type
-	serviceURL := "http://" + service + "." + project + "-" + stage
+	serviceURL := "http://" + service + "." + namespace
 	if deploymentStrategy == keptnevents.Duplicate {
 		if testStrategy == "real-user" {
 			// real-user tests will always be conducted on the primary deployment
-			serviceURL = "http://" + service + "-primary" + "." + project + "-" + stage
+			serviceURL = "http://" + service + "-primary" + "." + namespace
 		} else {
-			serviceURL = "http://" + service + "-canary" + "." + project + "-" + stage
+			serviceURL = "http://" + service + "-canary" + "." + namespace
 		}
 	}
 	return serviceURL
 }
 
-func sendDeploymentFinishedEvent(keptnHandler *keptnevents.Keptn, testStrategy string, deploymentStrategy keptnevents.DeploymentStrategy, image string, tag string, labels map[string]string, ingressHostnameSuffix string, protocol string, port string) error {
+// deploymentResultFailed is the result of a deployment which could not be applied
+const deploymentResultFailed = "fail"
+
+// deploymentResultSkipped is the result of a deployment which was superseded by a newer one before it was applied
+const deploymentResultSkipped = "skipped"
+
+// errDeploymentSuperseded reports that a deployment was skipped as it was superseded by a newer one
+var errDeploymentSuperseded = errors.New("the configuration change was superseded by a newer one and not applied")
+
+// deploymentFinishedEventData extends the deployment finished event by the result of the deployment
+type deploymentFinishedEventData struct {
+	keptnevents.DeploymentFinishedEventData
+	// Result is set to fail if the deployment could not be applied and to skipped if it was superseded
+	Result string `json:"result,omitempty"`
+	// ResultDetails describes why the deployment failed, e.g. whether it was rolled back
+	ResultDetails string `json:"resultDetails,omitempty"`
+	// DiffURI references the service resource containing the diff of the deployment
+	DiffURI string `json:"diffURI,omitempty"`
+	// ImageDigest is the digest of the image run by the deployed pods, e.g. sha256:2f8a...
+	ImageDigest string `json:"imageDigest,omitempty"`
+}
+
+// splitImage splits a container image of the form name[:tag][@digest] into its name, tag and digest
+func splitImage(image string) (string, string, string) {
+	digest := ""
+	if i := strings.Index(image, "@"); i >= 0 {
+		image, digest = image[:i], image[i+1:]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:], digest
+	}
+	return image, "", digest
+}
+
+func sendDeploymentFinishedEvent(keptnHandler *keptnevents.Keptn, namespace string, testStrategy string, deploymentStrategy keptnevents.DeploymentStrategy, image string, tag string, imageDigest string, labels map[string]string, ingressHostnameSuffix string, protocol string, port string, diffURI string, deploymentErr error) error {
 
 	source, _ := url.Parse("helm-service")
 	contentType := "application/json"
@@ -67,7 +103,7 @@ func sendDeploymentFinishedEvent(keptnHandler *keptnevents.Keptn, testStrategy s
 		deploymentStrategyOldIdentifier = "direct"
 	}
 
-	depFinishedEvent := keptnevents.DeploymentFinishedEventData{
+	depFinishedEvent := deploymentFinishedEventData{DeploymentFinishedEventData: keptnevents.DeploymentFinishedEventData{
 		Project:            keptnHandler.KeptnBase.Project,
 		Stage:              keptnHandler.KeptnBase.Stage,
 		Service:            keptnHandler.KeptnBase.Service,
@@ -76,10 +112,17 @@ func sendDeploymentFinishedEvent(keptnHandler *keptnevents.Keptn, testStrategy s
 		Image:              image,
 		Tag:                tag,
 		Labels:             labels,
-		DeploymentURILocal: getLocalDeploymentURI(keptnHandler.KeptnBase.Project, keptnHandler.KeptnBase.Service, keptnHandler.KeptnBase.Stage, deploymentStrategy, testStrategy),
+		DeploymentURILocal: getLocalDeploymentURI(namespace, keptnHandler.KeptnBase.Service, deploymentStrategy, testStrategy),
+	}, DiffURI: diffURI, ImageDigest: imageDigest}
+	if deploymentErr == errDeploymentSuperseded {
+		depFinishedEvent.Result = deploymentResultSkipped
+		depFinishedEvent.ResultDetails = deploymentErr.Error()
+	} else if deploymentErr != nil {
+		depFinishedEvent.Result = deploymentResultFailed
+		depFinishedEvent.ResultDetails = deploymentErr.Error()
 	}
 
-	publicDeploymentURI := protocol + "://" + keptnHandler.KeptnBase.Service + "." + keptnHandler.KeptnBase.Project + "-" + keptnHandler.KeptnBase.Stage + "." + ingressHostnameSuffix + ":" + port
+	publicDeploymentURI := protocol + "://" + keptnHandler.KeptnBase.Service + "." + namespace + "." + ingressHostnameSuffix + ":" + port
 	depFinishedEvent.DeploymentURIPublic = publicDeploymentURI
 
 	event := cloudevents.Event{
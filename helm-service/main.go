@@ -13,6 +13,7 @@ import (
 	"github.com/keptn/keptn/helm-service/controller/mesh"
 	"github.com/keptn/keptn/helm-service/pkg/serviceutils"
 	"log"
+	"net/http"
 	"os"
 )
 
@@ -20,16 +21,22 @@ type envConfig struct {
 	// Port on which to listen for cloudevents
 	Port int    `envconfig:"RCV_PORT" default:"8080"`
 	Path string `envconfig:"RCV_PATH" default:"/"`
+	// HistoryPort on which to serve the deployment history
+	HistoryPort string `envconfig:"HISTORY_PORT" default:"8081"`
 }
 
 const serviceName = "helm-service"
 
+// serviceQueue serializes the configuration changes and actions of a service
+var serviceQueue = controller.NewServiceQueue()
+
 func main() {
 	var env envConfig
 	if err := envconfig.Process("", &env); err != nil {
 		log.Fatalf("Failed to process env var: %s", err)
 	}
 	go keptnapi.RunHealthEndpoint("10999")
+	go serveDeploymentHistory(env.HistoryPort)
 	os.Exit(_main(os.Args[1:], env))
 }
 
@@ -53,7 +60,12 @@ func gotEvent(ctx context.Context, event cloudevents.Event) error {
 	loggingDone := make(chan bool)
 	go closeLogger(loggingDone, keptnHandler.Logger)
 
-	mesh := mesh.NewIstioMesh()
+	mesh, err := mesh.NewMesh()
+	if err != nil {
+		keptnHandler.Logger.Error(fmt.Sprintf("Error when creating the mesh: %s", err.Error()))
+		loggingDone <- true
+		return err
+	}
 
 	url, err := serviceutils.GetConfigServiceURL()
 	if err != nil {
@@ -66,13 +78,16 @@ func gotEvent(ctx context.Context, event cloudevents.Event) error {
 
 	if event.Type() == keptnevents.ConfigurationChangeEventType {
 		configChanger := controller.NewConfigurationChanger(mesh, keptnHandler, url.String())
-		go configChanger.ChangeAndApplyConfiguration(event, loggingDone)
+		configChanger.EnqueueConfigurationChange(serviceQueue, event, loggingDone)
 	} else if event.Type() == keptnevents.InternalServiceCreateEventType {
 		onboarder := controller.NewOnboarder(mesh, keptnHandler, url.String())
 		go onboarder.DoOnboard(event, loggingDone)
+	} else if event.Type() == controller.InternalServiceDeleteEventType {
+		offboarder := controller.NewOffboarder(keptnHandler, url.String())
+		go offboarder.DoOffboard(event, loggingDone)
 	} else if event.Type() == keptnevents.ActionTriggeredEventType {
-		actionHandler := controller.NewActionTriggeredHandler(keptnHandler, url.String())
-		go actionHandler.HandleEvent(event, loggingDone)
+		actionHandler := controller.NewActionTriggeredHandler(mesh, keptnHandler, url.String())
+		actionHandler.Enqueue(serviceQueue, event, loggingDone)
 	} else {
 		logger.Error("Received unexpected keptn event")
 		loggingDone <- true
@@ -81,6 +96,22 @@ func gotEvent(ctx context.Context, event cloudevents.Event) error {
 	return nil
 }
 
+func serveDeploymentHistory(port string) {
+	configServiceURL, err := serviceutils.GetConfigServiceURL()
+	if err != nil {
+		log.Printf("deployment history is not served: %v", err)
+		return
+	}
+	datastoreURL, err := serviceutils.GetDatastoreURL()
+	if err != nil {
+		log.Printf("deployment history is not served: %v", err)
+		return
+	}
+	handler := controller.NewDeploymentHistoryHandler(configServiceURL.String(), datastoreURL.String())
+	// the CloudEvents receiver keeps running if the deployment history cannot be served
+	log.Printf("deployment history is not served: %v", http.ListenAndServe(":"+port, handler))
+}
+
 func closeLogger(loggingDone chan bool, logger keptnevents.LoggerInterface) {
 	<-loggingDone
 	if combinedLogger, ok := logger.(*keptnevents.CombinedLogger); ok {
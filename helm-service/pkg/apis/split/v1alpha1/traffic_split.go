@@ -0,0 +1,33 @@
+// Package v1alpha1 contains the SMI TrafficSplit types (split.smi-spec.io/v1alpha1),
+// which are used by meshes implementing the Service Mesh Interface, e.g. Linkerd.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TrafficSplit allows users to incrementally direct percentages of traffic
+// between various services
+type TrafficSplit struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TrafficSplitSpec `json:"spec"`
+}
+
+// TrafficSplitSpec is the specification for a TrafficSplit
+type TrafficSplitSpec struct {
+	// Service represents the apex service
+	Service string `json:"service"`
+	// Backends defines a list of Kubernetes services
+	// used as the traffic split destination
+	Backends []TrafficSplitBackend `json:"backends"`
+}
+
+// TrafficSplitBackend defines a backend
+type TrafficSplitBackend struct {
+	// Service is the name of a Kubernetes service
+	Service string `json:"service"`
+	// Weight defines the traffic split percentage
+	Weight int32 `json:"weight"`
+}
@@ -0,0 +1,138 @@
+package secretvalues
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// APIVersion is the apiVersion of an encrypted values file
+	APIVersion = "keptn.sh/v1"
+	// Kind is the kind of an encrypted values file
+	Kind = "EncryptedValues"
+	// Cipher is the cipher used for encrypting the values
+	Cipher = "AES-256-GCM"
+	// KeySize is the size of the key in bytes
+	KeySize = 32
+
+	// keySecretKey is the key of the secret containing the base64 encoded key
+	keySecretKey = "key"
+)
+
+// EncryptedValues is a values file, whose content is encrypted with the key of the project.
+// The values are encrypted by the CLI and decrypted by the helm-service when upgrading a chart.
+type EncryptedValues struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Cipher     string `json:"cipher"`
+	// Data contains the base64 encoded nonce followed by the encrypted values
+	Data string `json:"data"`
+}
+
+// GetKeySecretName returns the name of the Kubernetes secret containing the key of the project
+func GetKeySecretName(project string) string {
+	return "keptn-values-key-" + project
+}
+
+// GenerateKey generates a random key
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// IsEncrypted returns whether the content of a values file is encrypted
+func IsEncrypted(data []byte) bool {
+	encrypted := &EncryptedValues{}
+	if err := yaml.Unmarshal(data, encrypted); err != nil {
+		return false
+	}
+	return encrypted.APIVersion == APIVersion && encrypted.Kind == Kind
+}
+
+// Encrypt encrypts the values file with the key. The project is authenticated, hence the
+// encrypted values cannot be used in another project.
+func Encrypt(values []byte, key []byte, project string) ([]byte, error) {
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	data := gcm.Seal(nonce, nonce, values, []byte(project))
+
+	return yaml.Marshal(EncryptedValues{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		Cipher:     Cipher,
+		Data:       base64.StdEncoding.EncodeToString(data),
+	})
+}
+
+// Decrypt decrypts the encrypted values file with the key of the project
+func Decrypt(data []byte, key []byte, project string) ([]byte, error) {
+
+	encrypted := &EncryptedValues{}
+	if err := yaml.Unmarshal(data, encrypted); err != nil {
+		return nil, err
+	}
+	if encrypted.Cipher != Cipher {
+		return nil, fmt.Errorf("unsupported cipher %s", encrypted.Cipher)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encrypted.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("encrypted values are too short")
+	}
+	values, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], []byte(project))
+	if err != nil {
+		return nil, errors.New("encrypted values cannot be decrypted with the key of project " + project)
+	}
+	return values, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must have %d bytes", KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// GetKey reads the key of the project from the Kubernetes secret in the namespace
+func GetKey(clientset kubernetes.Interface, namespace string, project string) ([]byte, error) {
+
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(GetKeySecretName(project), metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when reading the values key of project %s: %v", project, err)
+	}
+	key, err := base64.StdEncoding.DecodeString(string(secret.Data[keySecretKey]))
+	if err != nil {
+		return nil, fmt.Errorf("error when decoding the values key of project %s: %v", project, err)
+	}
+	return key, nil
+}
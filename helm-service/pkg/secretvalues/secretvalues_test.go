@@ -0,0 +1,71 @@
+package secretvalues
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var testKey = bytes.Repeat([]byte{7}, KeySize)
+
+func TestEncryptDecrypt(t *testing.T) {
+
+	values := []byte("db:\n  password: secret\n")
+	encrypted, err := Encrypt(values, testKey, "sockshop")
+	assert.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+	assert.NotContains(t, string(encrypted), "secret")
+
+	decrypted, err := Decrypt(encrypted, testKey, "sockshop")
+	assert.NoError(t, err)
+	assert.Equal(t, values, decrypted)
+
+	_, err = Decrypt(encrypted, testKey, "other")
+	assert.Error(t, err)
+	_, err = Decrypt(encrypted, bytes.Repeat([]byte{8}, KeySize), "sockshop")
+	assert.Error(t, err)
+}
+
+func TestGenerateKey(t *testing.T) {
+
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+	assert.Equal(t, KeySize, len(key))
+
+	values := []byte("db:\n  password: secret\n")
+	encrypted, err := Encrypt(values, key, "sockshop")
+	assert.NoError(t, err)
+	decrypted, err := Decrypt(encrypted, key, "sockshop")
+	assert.NoError(t, err)
+	assert.Equal(t, values, decrypted)
+}
+
+func TestEncryptRejectsInvalidKey(t *testing.T) {
+	_, err := Encrypt([]byte("a: b"), []byte("short"), "sockshop")
+	assert.Error(t, err)
+}
+
+func TestIsEncrypted(t *testing.T) {
+	assert.False(t, IsEncrypted([]byte("db:\n  password: secret\n")))
+	assert.False(t, IsEncrypted([]byte("- not a map")))
+}
+
+func TestGetKey(t *testing.T) {
+
+	clientset := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: GetKeySecretName("sockshop"), Namespace: "keptn"},
+		Data:       map[string][]byte{keySecretKey: []byte(base64.StdEncoding.EncodeToString(testKey))},
+	})
+
+	key, err := GetKey(clientset, "keptn", "sockshop")
+	assert.NoError(t, err)
+	assert.Equal(t, testKey, key)
+
+	_, err = GetKey(clientset, "keptn", "other")
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package serviceutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+)
+
+// ListServiceResources returns the resources of the service in the stage. A configuration-service which does not
+// implement listing service resources (501) or does not know the service (404) is treated as if the service
+// had no resources, so that optional resources are simply not found.
+func ListServiceResources(project string, stage string, service string, configServiceURL string) ([]*models.Resource, error) {
+
+	baseURL := strings.TrimRight(configServiceURL, "/")
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		baseURL = "http://" + baseURL
+	}
+	u, err := url.Parse(baseURL + "/v1/project/" + project + "/stage/" + stage + "/service/" + service + "/resource/")
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []*models.Resource{}
+	nextPageKey := ""
+	for {
+		if nextPageKey != "" {
+			q := u.Query()
+			q.Set("nextPageKey", nextPageKey)
+			u.RawQuery = q.Encode()
+		}
+		resp, err := http.Get(u.String())
+		if err != nil {
+			return nil, err
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+		case http.StatusNotFound, http.StatusNotImplemented:
+			return []*models.Resource{}, nil
+		default:
+			return nil, fmt.Errorf("received status %d when listing resources: %s", resp.StatusCode, string(body))
+		}
+
+		received := models.Resources{}
+		if err := json.Unmarshal(body, &received); err != nil {
+			return nil, err
+		}
+		resources = append(resources, received.Resources...)
+		if received.NextPageKey == "" || received.NextPageKey == "0" {
+			return resources, nil
+		}
+		nextPageKey = received.NextPageKey
+	}
+}
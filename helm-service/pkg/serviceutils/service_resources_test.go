@@ -0,0 +1,56 @@
+package serviceutils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListServiceResources(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		status    int
+		pages     map[string]string
+		wantURIs  []string
+		wantError bool
+	}{
+		{
+			name:   "paged resources",
+			status: http.StatusOK,
+			pages: map[string]string{
+				"":  `{"nextPageKey": "1", "resources": [{"resourceURI": "/helm/values-dev.yaml"}]}`,
+				"1": `{"nextPageKey": "0", "resources": [{"resourceURI": "/kustomize/base/kustomization.yaml"}]}`,
+			},
+			wantURIs: []string{"/helm/values-dev.yaml", "/kustomize/base/kustomization.yaml"},
+		},
+		{name: "listing not implemented", status: http.StatusNotImplemented, wantURIs: []string{}},
+		{name: "service not found", status: http.StatusNotFound, wantURIs: []string{}},
+		{name: "server error", status: http.StatusInternalServerError, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/project/sockshop/stage/dev/service/carts/resource/", r.URL.Path)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.pages[r.URL.Query().Get("nextPageKey")]))
+			}))
+			defer ts.Close()
+
+			resources, err := ListServiceResources("sockshop", "dev", "carts", ts.URL)
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			uris := []string{}
+			for _, resource := range resources {
+				uris = append(uris, *resource.ResourceURI)
+			}
+			assert.Equal(t, tt.wantURIs, uris)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 const configservice = "CONFIGURATION_SERVICE"
 const eventbroker = "EVENTBROKER"
 const api = "API"
+const datastore = "MONGODB_DATASTORE"
 
 func GetConfigServiceURL() (*url.URL, error) {
 	url, err := keptn.GetServiceEndpoint(configservice)
@@ -23,3 +24,8 @@ func GetEventbrokerURL() (*url.URL, error) {
 	url, err := keptn.GetServiceEndpoint(eventbroker)
 	return &url, err
 }
+
+func GetDatastoreURL() (*url.URL, error) {
+	url, err := keptn.GetServiceEndpoint(datastore)
+	return &url, err
+}
@@ -40,6 +40,38 @@ func main() {
 	os.Exit(_main(os.Args[1:], env))
 }
 
+// deploymentResultData contains the result of the deployment reported in the deployment finished event
+type deploymentResultData struct {
+	Result        string `json:"result"`
+	ResultDetails string `json:"resultDetails"`
+}
+
+// testAction describes how the tests react on the result of a deployment
+type testAction int
+
+const (
+	// runTestsAction runs the tests against the deployment
+	runTestsAction testAction = iota
+	// failTestsAction reports failed tests without running them
+	failTestsAction
+	// skipTestsAction neither runs nor reports tests
+	skipTestsAction
+)
+
+// getTestAction returns how the tests react on the deployment result.
+// The helm-service reports a deployment which could not be applied with the result fail
+// and a deployment which was superseded by a newer one with the result skipped.
+// Events without a result are tested as before.
+func getTestAction(deploymentResult deploymentResultData) testAction {
+	switch deploymentResult.Result {
+	case "fail":
+		return failTestsAction
+	case "skipped":
+		return skipTestsAction
+	}
+	return runTestsAction
+}
+
 func gotEvent(ctx context.Context, event cloudevents.Event) error {
 	var shkeptncontext string
 	event.Context.ExtensionAs("shkeptncontext", &shkeptncontext)
@@ -62,6 +94,24 @@ func gotEvent(ctx context.Context, event cloudevents.Event) error {
 		logger.Info("Received '" + TestStrategy_RealUser + "' test strategy, hence no tests are triggered")
 		return nil
 	}
+
+	deploymentResult := &deploymentResultData{}
+	if err := event.DataAs(deploymentResult); err != nil {
+		logger.Error(fmt.Sprintf("Got Data Error: %s", err.Error()))
+		return err
+	}
+	switch getTestAction(*deploymentResult) {
+	case failTestsAction:
+		logger.Info("Received failed deployment, hence no tests are triggered: " + deploymentResult.ResultDetails)
+		if err := sendTestsFinishedEvent(shkeptncontext, event, time.Now(), "fail", logger); err != nil {
+			logger.Error(fmt.Sprintf("Error sending test finished event: %s", err.Error()))
+			return err
+		}
+		return nil
+	case skipTestsAction:
+		logger.Info("Received skipped deployment, hence no tests are triggered: " + deploymentResult.ResultDetails)
+		return nil
+	}
 	go runTests(event, shkeptncontext, *data, logger)
 
 	return nil
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"log"
 	"net/url"
 	"reflect"
@@ -46,3 +47,27 @@ func TestGetServiceURL(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTestAction(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want testAction
+	}{
+		{"pass", `{"project": "sockshop", "result": "pass"}`, runTestsAction},
+		{"fail", `{"project": "sockshop", "result": "fail", "resultDetails": "readiness check failed"}`, failTestsAction},
+		{"missing result", `{"project": "sockshop"}`, runTestsAction},
+		{"skipped", `{"project": "sockshop", "result": "skipped", "resultDetails": "superseded by a newer artifact"}`, skipTestsAction},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deploymentResult := deploymentResultData{}
+			if err := json.Unmarshal([]byte(tt.data), &deploymentResult); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := getTestAction(deploymentResult); got != tt.want {
+				t.Errorf("got %v, want %v for %s", got, tt.want, tt.name)
+			}
+		})
+	}
+}
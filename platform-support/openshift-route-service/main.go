@@ -93,10 +93,14 @@ func createRoutes(data *keptn.ProjectCreateEventData) error {
 	if err != nil {
 		return err
 	}
+	namespaces, err := loadNamespaceConfig(data.Project)
+	if err != nil {
+		return err
+	}
 	for _, stage := range shipyard.Stages {
 		if stage.DeploymentStrategy == "blue_green_service" {
 			// add required security context constraints to the generated namespace to make istio injection work
-			if err := enableMesh(data.Project, stage.Name); err != nil {
+			if err := enableMesh(namespaces.getStageNamespace(data.Project, stage.Name)); err != nil {
 				return err
 			}
 		}
@@ -104,7 +108,7 @@ func createRoutes(data *keptn.ProjectCreateEventData) error {
 	return nil
 }
 
-func enableMesh(project string, stage string) error {
+func enableMesh(namespace string) error {
 	_, err := keptn.ExecuteCommand("oc",
 		[]string{
 			"adm",
@@ -113,21 +117,21 @@ func enableMesh(project string, stage string) error {
 			"privileged",
 			"system:serviceaccounts",
 			"-n",
-			project + "-" + stage,
+			namespace,
 		})
 	if err != nil {
-		return errors.New("Could not add security context constraint 'privileged' for namespace " + project + "-" + stage + ": " + err.Error())
+		return errors.New("Could not add security context constraint 'privileged' for namespace " + namespace + ": " + err.Error())
 	}
 	out, err := keptn.ExecuteCommand("oc",
-		getEnableMeshCommandArgs(project, stage))
+		getEnableMeshCommandArgs(namespace))
 	if err != nil {
-		return errors.New("Could not add security context constraint 'anyuid' for namespace " + project + "-" + stage + ": " + err.Error())
+		return errors.New("Could not add security context constraint 'anyuid' for namespace " + namespace + ": " + err.Error())
 	}
 	fmt.Println("enableMesh() output: " + out)
 	return nil
 }
 
-func getEnableMeshCommandArgs(project string, stage string) []string {
+func getEnableMeshCommandArgs(namespace string) []string {
 	return []string{
 		"adm",
 		"policy",
@@ -135,6 +139,6 @@ func getEnableMeshCommandArgs(project string, stage string) []string {
 		"anyuid",
 		"system:serviceaccounts",
 		"-n",
-		project + "-" + stage,
+		namespace,
 	}
 }
@@ -2,6 +2,10 @@ package main
 
 import (
 	"context"
+	b64 "encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"reflect"
 	"testing"
 
@@ -12,8 +16,7 @@ import (
 
 func Test_getEnableMeshCommandArgs(t *testing.T) {
 	type args struct {
-		project string
-		stage   string
+		namespace string
 	}
 	tests := []struct {
 		name string
@@ -23,8 +26,7 @@ func Test_getEnableMeshCommandArgs(t *testing.T) {
 		{
 			name: "Enable mesh command",
 			args: args{
-				project: "sockshop",
-				stage:   "dev",
+				namespace: "sockshop-dev",
 			},
 			want: []string{
 				"adm",
@@ -39,7 +41,7 @@ func Test_getEnableMeshCommandArgs(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := getEnableMeshCommandArgs(tt.args.project, tt.args.stage); !reflect.DeepEqual(got, tt.want) {
+			if got := getEnableMeshCommandArgs(tt.args.namespace); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("getEnableMeshCommandArgs() = %v, want %v", got, tt.want)
 			}
 		})
@@ -146,3 +148,39 @@ func Test__main(t *testing.T) {
 		})
 	}
 }
+
+func Test_getStageNamespace(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/project/sockshop/resource/namespaces.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content := "namespace: team-a-${stage}\nstages:\n- name: production\n  namespace: team-a-prod\n"
+		w.Write([]byte(`{"resourceContent": "` + b64.StdEncoding.EncodeToString([]byte(content)) + `"}`))
+	}))
+	defer ts.Close()
+	os.Setenv("CONFIGURATION_SERVICE", ts.URL)
+	defer os.Unsetenv("CONFIGURATION_SERVICE")
+
+	tests := []struct {
+		name    string
+		project string
+		stage   string
+		want    string
+	}{
+		{name: "namespace template", project: "sockshop", stage: "dev", want: "team-a-dev"},
+		{name: "namespace of stage", project: "sockshop", stage: "production", want: "team-a-prod"},
+		{name: "no namespace config", project: "other", stage: "dev", want: "other-dev"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespaces, err := loadNamespaceConfig(tt.project)
+			if err != nil {
+				t.Fatalf("loadNamespaceConfig() error = %v", err)
+			}
+			if got := namespaces.getStageNamespace(tt.project, tt.stage); got != tt.want {
+				t.Errorf("getStageNamespace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
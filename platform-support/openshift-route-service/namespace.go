@@ -0,0 +1,82 @@
+package main
+
+import (
+	b64 "encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// namespaceConfigURI is the project resource defining the namespaces of the stages, see the helm-service
+const namespaceConfigURI = "namespaces.yaml"
+
+// namespaceConfig contains the namespace names defined by the namespaces.yaml of a project.
+// A namespace name may contain the placeholders ${project} and ${stage}.
+type namespaceConfig struct {
+	Namespace string `yaml:"namespace"`
+	Stages    []struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"stages"`
+}
+
+// loadNamespaceConfig reads the namespace config of the project, which is nil if the project does not define one
+func loadNamespaceConfig(project string) (*namespaceConfig, error) {
+	configServiceURL := os.Getenv("CONFIGURATION_SERVICE")
+	if configServiceURL == "" {
+		return nil, nil
+	}
+
+	resp, err := http.Get(strings.TrimRight(configServiceURL, "/") + "/v1/project/" + project + "/resource/" + namespaceConfigURI)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading %s: %v", namespaceConfigURI, err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading %s: %v", namespaceConfigURI, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error when reading %s: %s", namespaceConfigURI, string(body))
+	}
+
+	resource := struct {
+		ResourceContent string `json:"resourceContent"`
+	}{}
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return nil, fmt.Errorf("error when reading %s: %v", namespaceConfigURI, err)
+	}
+	content, err := b64.StdEncoding.DecodeString(resource.ResourceContent)
+	if err != nil {
+		return nil, fmt.Errorf("error when decoding %s: %v", namespaceConfigURI, err)
+	}
+	config := &namespaceConfig{}
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("error when parsing %s: %v", namespaceConfigURI, err)
+	}
+	return config, nil
+}
+
+// getStageNamespace returns the namespace of the stage. A nil config resolves the namespace ${project}-${stage}.
+func (c *namespaceConfig) getStageNamespace(project string, stage string) string {
+	template := "${project}-${stage}"
+	if c != nil {
+		if c.Namespace != "" {
+			template = c.Namespace
+		}
+		for _, s := range c.Stages {
+			if s.Name == stage && s.Namespace != "" {
+				template = s.Namespace
+			}
+		}
+	}
+	return strings.NewReplacer("${project}", project, "${stage}", stage).Replace(template)
+}
@@ -109,6 +109,18 @@ func gotEvent(ctx context.Context, event cloudevents.Event) error {
 			return err
 		}
 		return nil
+	} else if event.Type() == internalStageUpdateEventType {
+		err := updateStage(event, *logger, ws)
+		if err := closeWebsocketWithMessage(event, err, "Stage successfully updated", *logger, ws); err != nil {
+			return err
+		}
+		return nil
+	} else if event.Type() == internalStageDeleteEventType {
+		err := deleteStage(event, *logger, ws)
+		if err := closeWebsocketWithMessage(event, err, "Stage successfully deleted", *logger, ws); err != nil {
+			return err
+		}
+		return nil
 	}
 
 	const errorMsg = "Received unexpected keptn event that cannot be processed"
@@ -285,9 +297,13 @@ func getDeleteInfoMessage(keptnHandler *keptn.Keptn) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("error when getting shipyard: %v", err)
 	}
+	namespaces, err := loadNamespaceConfig(keptnHandler.KeptnBase.Project)
+	if err != nil {
+		return "", err
+	}
 	msg := "\n"
 	for _, stage := range shipyard.Stages {
-		namespace := keptnHandler.KeptnBase.Project + "-" + stage.Name
+		namespace := namespaces.getStageNamespace(keptnHandler.KeptnBase.Project, stage.Name)
 		msg += fmt.Sprintf("- A potentially created namespace %s is not managed by Keptn anymore and not deleted. This may cause problems if "+
 			"a project with the same name is created later. "+
 			"If you would like to delete this namespace, please execute "+
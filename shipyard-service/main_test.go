@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"io"
 	"net"
@@ -109,6 +111,147 @@ func TestCreateStageStatusNoContent(t *testing.T) {
 	assert.Equal(t, err, nil, "Received unexpected error")
 }
 
+func TestDeleteStageRequestBadRequest(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, r.Method, "DELETE", "Expect DELETE request")
+			assert.Equal(t, r.URL.EscapedPath(), "/v1/project/sockshop/stage/hardening", "Expect /v1/project/sockshop/stage/hardening endpoint")
+			w.WriteHeader(http.StatusBadRequest) // 400 - BadRequest
+			io.WriteString(w, `{"code": 400, "message": "Stage does not exist."}`)
+		}),
+	)
+	defer ts.Close()
+
+	logger := keptnutils.NewLogger("4711-a83b-4bc1-9dc0-1f050c7e789b", "4711-a83b-4bc1-9dc0-1f050c7e781b", "shipyard-service")
+	os.Setenv("CONFIGURATION_SERVICE", ts.URL)
+
+	err := sendStageRequest(http.MethodDelete, "sockshop", "hardening", nil, *logger)
+
+	assert.Equal(t, err.Error(), "Stage does not exist.", "Expect an error")
+}
+
+func TestUpdateStageRequestStatusNoContent(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, r.Method, "PUT", "Expect PUT request")
+			assert.Equal(t, r.URL.EscapedPath(), "/v1/project/sockshop/stage/dev", "Expect /v1/project/sockshop/stage/dev endpoint")
+			stage := configmodels.Stage{}
+			json.NewDecoder(r.Body).Decode(&stage)
+			assert.Equal(t, stage.StageName, "staging", "Expect new stage name in body")
+			w.WriteHeader(http.StatusNoContent) // 204 - StatusNoContent
+		}),
+	)
+	defer ts.Close()
+
+	logger := keptnutils.NewLogger("4711-a83b-4bc1-9dc0-1f050c7e789b", "4711-a83b-4bc1-9dc0-1f050c7e781b", "shipyard-service")
+	os.Setenv("CONFIGURATION_SERVICE", ts.URL)
+
+	err := sendStageRequest(http.MethodPut, "sockshop", "dev", &configmodels.Stage{StageName: "staging"}, *logger)
+
+	assert.Equal(t, err, nil, "Received unexpected error")
+}
+
+const testShipyard = `stages:
+- name: dev
+  deployment_strategy: direct
+  test_strategy: functional
+- name: production
+  deployment_strategy: blue_green_service
+  remediation_strategy: automated
+`
+
+func TestRenameStageInShipyard(t *testing.T) {
+	shipyard, err := renameStageInShipyard(testShipyard, "dev", "staging")
+
+	assert.Equal(t, err, nil, "Received unexpected error")
+	assert.Equal(t, shipyard, `stages:
+- name: staging
+  deployment_strategy: direct
+  test_strategy: functional
+- name: production
+  deployment_strategy: blue_green_service
+  remediation_strategy: automated
+`, "Stage not renamed")
+
+	_, err = renameStageInShipyard(testShipyard, "dev", "production")
+	assert.Equal(t, err.Error(), "Stage production already exists in shipyard", "Expect an error")
+
+	_, err = renameStageInShipyard(testShipyard, "hardening", "staging")
+	assert.Equal(t, err.Error(), "Stage hardening does not exist in shipyard", "Expect an error")
+}
+
+func TestRemoveStageFromShipyard(t *testing.T) {
+	shipyard, err := removeStageFromShipyard(testShipyard, "dev")
+
+	assert.Equal(t, err, nil, "Received unexpected error")
+	assert.Equal(t, shipyard, `stages:
+- name: production
+  deployment_strategy: blue_green_service
+  remediation_strategy: automated
+`, "Stage not removed")
+
+	_, err = removeStageFromShipyard(testShipyard, "hardening")
+	assert.Equal(t, err.Error(), "Stage hardening does not exist in shipyard", "Expect an error")
+}
+
+func TestChangeStageRestoresShipyardOnFailure(t *testing.T) {
+	var storedShipyards []string
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodPost && r.URL.EscapedPath() == "/v1/project/sockshop/resource":
+				request := struct {
+					Resources []configmodels.Resource `json:"resources"`
+				}{}
+				json.NewDecoder(r.Body).Decode(&request)
+				content, _ := base64.StdEncoding.DecodeString(request.Resources[0].ResourceContent)
+				storedShipyards = append(storedShipyards, string(content))
+				io.WriteString(w, `{"version": "1"}`)
+			case r.Method == http.MethodDelete && r.URL.EscapedPath() == "/v1/project/sockshop/stage/dev":
+				w.WriteHeader(http.StatusInternalServerError)
+				io.WriteString(w, `{"code": 500, "message": "Could not delete stage."}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+	)
+	defer ts.Close()
+
+	logger := keptnutils.NewLogger("4711-a83b-4bc1-9dc0-1f050c7e789b", "4711-a83b-4bc1-9dc0-1f050c7e781b", "shipyard-service")
+	os.Setenv("CONFIGURATION_SERVICE", ts.URL)
+
+	newShipyard, _ := removeStageFromShipyard(testShipyard, "dev")
+	err := changeStage("sockshop", testShipyard, newShipyard, func() error {
+		return sendStageRequest(http.MethodDelete, "sockshop", "dev", nil, *logger)
+	}, *logger)
+
+	assert.Equal(t, err.Error(), "Could not delete stage.", "Expect an error")
+	assert.Equal(t, storedShipyards, []string{newShipyard, testShipyard}, "Expect the shipyard to be restored")
+}
+
+func TestGetStageNamespace(t *testing.T) {
+	config := &namespaceConfig{}
+	assert.Equal(t, config.getStageNamespace("sockshop", "dev"), "sockshop-dev", "Expect the default namespace")
+
+	var noConfig *namespaceConfig
+	assert.Equal(t, noConfig.getStageNamespace("sockshop", "dev"), "sockshop-dev", "Expect the default namespace")
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, r.URL.EscapedPath(), "/v1/project/sockshop/resource/namespaces.yaml", "Expect namespaces.yaml")
+			content := "namespace: team-a-${stage}\nstages:\n- name: production\n  namespace: team-a-prod\n"
+			json.NewEncoder(w).Encode(configmodels.Resource{ResourceContent: base64.StdEncoding.EncodeToString([]byte(content))})
+		}),
+	)
+	defer ts.Close()
+	os.Setenv("CONFIGURATION_SERVICE", ts.URL)
+
+	config, err := loadNamespaceConfig("sockshop")
+	assert.Equal(t, err, nil, "Received unexpected error")
+	assert.Equal(t, config.getStageNamespace("sockshop", "dev"), "team-a-dev", "Expect the namespace template")
+	assert.Equal(t, config.getStageNamespace("sockshop", "production"), "team-a-prod", "Expect the namespace of the stage")
+}
+
 /* cannot mock the request
 func TestStoreResource(t *testing.T) {
 	logger := keptnutils.NewLogger("4711-a83b-4bc1-9dc0-1f050c7e789b", "4711-a83b-4bc1-9dc0-1f050c7e781b", "shipyard-service")
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"gopkg.in/yaml.v2"
+)
+
+// namespaceConfigURI is the project resource defining the namespaces of the stages, see the helm-service
+const namespaceConfigURI = "namespaces.yaml"
+
+// namespaceConfig contains the namespace names defined by the namespaces.yaml of a project.
+// A namespace name may contain the placeholders ${project} and ${stage}.
+type namespaceConfig struct {
+	Namespace string `yaml:"namespace"`
+	Stages    []struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"stages"`
+}
+
+// loadNamespaceConfig reads the namespace config of the project, which is nil if the project does not define one
+func loadNamespaceConfig(project string) (*namespaceConfig, error) {
+	configServiceURL, err := keptn.GetServiceEndpoint(configservice)
+	if err != nil {
+		return nil, err
+	}
+
+	rHandler := configutils.NewResourceHandler(configServiceURL.String())
+	resource, err := rHandler.GetProjectResource(project, namespaceConfigURI)
+	if err == configutils.ResourceNotFoundError {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error when reading %s: %v", namespaceConfigURI, err)
+	}
+
+	config := &namespaceConfig{}
+	if err := yaml.Unmarshal([]byte(resource.ResourceContent), config); err != nil {
+		return nil, fmt.Errorf("error when parsing %s: %v", namespaceConfigURI, err)
+	}
+	return config, nil
+}
+
+// getStageNamespace returns the namespace of the stage. A nil config resolves the namespace ${project}-${stage}.
+func (c *namespaceConfig) getStageNamespace(project string, stage string) string {
+	template := "${project}-${stage}"
+	if c != nil {
+		if c.Namespace != "" {
+			template = c.Namespace
+		}
+		for _, s := range c.Stages {
+			if s.Name == stage && s.Namespace != "" {
+				template = s.Namespace
+			}
+		}
+	}
+	return strings.NewReplacer("${project}", project, "${stage}", stage).Replace(template)
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	"github.com/gorilla/websocket"
+	configmodels "github.com/keptn/go-utils/pkg/api/models"
+	configutils "github.com/keptn/go-utils/pkg/api/utils"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"gopkg.in/yaml.v2"
+)
+
+// internalStageUpdateEventType is a CloudEvent type for renaming a stage
+const internalStageUpdateEventType = "sh.keptn.internal.event.stage.update"
+
+// internalStageDeleteEventType is a CloudEvent type for deleting a stage
+const internalStageDeleteEventType = "sh.keptn.internal.event.stage.delete"
+
+type stageUpdateEventData struct {
+	Project      string `json:"project"`
+	Stage        string `json:"stage"`
+	NewStageName string `json:"newStageName"`
+}
+
+type stageDeleteEventData struct {
+	Project string `json:"project"`
+	Stage   string `json:"stage"`
+}
+
+// updateStage renames a stage in the configuration-service and in the shipyard of the project
+func updateStage(event cloudevents.Event, logger keptn.Logger, ws *websocket.Conn) error {
+	eventData := stageUpdateEventData{}
+	if err := event.DataAs(&eventData); err != nil {
+		return err
+	}
+
+	shipyard, err := getShipyard(eventData.Project, logger)
+	if err != nil {
+		return err
+	}
+	newShipyard, err := renameStageInShipyard(shipyard, eventData.Stage, eventData.NewStageName)
+	if err != nil {
+		return err
+	}
+
+	body := configmodels.Stage{StageName: eventData.NewStageName}
+	if err := changeStage(eventData.Project, shipyard, newShipyard, func() error {
+		return sendStageRequest(http.MethodPut, eventData.Project, eventData.Stage, &body, logger)
+	}, logger); err != nil {
+		return fmt.Errorf("Renaming stage %s failed. %s", eventData.Stage, err.Error())
+	}
+	if err := keptn.WriteWSLog(ws, createEventCopy(event, "sh.keptn.events.log"),
+		fmt.Sprintf("Stage %s renamed to %s", eventData.Stage, eventData.NewStageName), false, "INFO"); err != nil {
+		logger.Error(fmt.Sprintf("Could not write log to websocket. %s", err.Error()))
+	}
+	if err := keptn.WriteWSLog(ws, createEventCopy(event, "sh.keptn.events.log"),
+		getStageNamespaceInfoMessage(eventData.Project, eventData.Stage), false, "INFO"); err != nil {
+		logger.Error(fmt.Sprintf("Could not write log to websocket. %s", err.Error()))
+	}
+	return nil
+}
+
+// deleteStage deletes a stage in the configuration-service and removes it from the shipyard of the project
+func deleteStage(event cloudevents.Event, logger keptn.Logger, ws *websocket.Conn) error {
+	eventData := stageDeleteEventData{}
+	if err := event.DataAs(&eventData); err != nil {
+		return err
+	}
+
+	shipyard, err := getShipyard(eventData.Project, logger)
+	if err != nil {
+		return err
+	}
+	newShipyard, err := removeStageFromShipyard(shipyard, eventData.Stage)
+	if err != nil {
+		return err
+	}
+
+	if err := changeStage(eventData.Project, shipyard, newShipyard, func() error {
+		return sendStageRequest(http.MethodDelete, eventData.Project, eventData.Stage, nil, logger)
+	}, logger); err != nil {
+		return fmt.Errorf("Deleting stage %s failed. %s", eventData.Stage, err.Error())
+	}
+	if err := keptn.WriteWSLog(ws, createEventCopy(event, "sh.keptn.events.log"),
+		fmt.Sprintf("Stage %s deleted", eventData.Stage), false, "INFO"); err != nil {
+		logger.Error(fmt.Sprintf("Could not write log to websocket. %s", err.Error()))
+	}
+	if err := keptn.WriteWSLog(ws, createEventCopy(event, "sh.keptn.events.log"),
+		getStageNamespaceInfoMessage(eventData.Project, eventData.Stage), false, "INFO"); err != nil {
+		logger.Error(fmt.Sprintf("Could not write log to websocket. %s", err.Error()))
+	}
+	return nil
+}
+
+// changeStage stores the new shipyard and then changes the stage in the configuration-service. The shipyard is
+// stored first because a deleted stage cannot be restored, whereas the previous shipyard is restored if changing
+// the stage fails.
+func changeStage(project string, shipyard string, newShipyard string, change func() error, logger keptn.Logger) error {
+	if _, err := storeResourceForProject(project, newShipyard, logger); err != nil {
+		return err
+	}
+	if err := change(); err != nil {
+		if _, restoreErr := storeResourceForProject(project, shipyard, logger); restoreErr != nil {
+			return fmt.Errorf("%s. Restoring the shipyard failed. %s", err.Error(), restoreErr.Error())
+		}
+		return err
+	}
+	return nil
+}
+
+// getStageNamespaceInfoMessage returns the hint that the namespace of the stage is not deleted. The namespace
+// may be configured by the namespaces.yaml of the project, hence it is not named.
+func getStageNamespaceInfoMessage(project string, stage string) string {
+	return fmt.Sprintf("A potentially created namespace of stage %s in project %s is not managed by Keptn anymore and "+
+		"not deleted. If you would like to delete this namespace, please execute 'kubectl delete ns NAMESPACE' "+
+		"with the namespace of the stage (by default %s-%s).", stage, project, project, stage)
+}
+
+// getShipyard returns the shipyard of a project by using the configuration-service
+func getShipyard(project string, logger keptn.Logger) (string, error) {
+	configServiceURL, err := keptn.GetServiceEndpoint(configservice)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Could not get service endpoint for %s: %s", configservice, err.Error()))
+		return "", err
+	}
+	handler := configutils.NewResourceHandler(configServiceURL.String())
+	resource, err := handler.GetProjectResource(project, "shipyard.yaml")
+	if err != nil {
+		return "", fmt.Errorf("Retrieving shipyard of project %s failed. %s", project, err.Error())
+	}
+	return resource.ResourceContent, nil
+}
+
+// sendStageRequest sends a request for the given stage to the configuration-service
+func sendStageRequest(method string, project string, stage string, body interface{}, logger keptn.Logger) error {
+	configServiceURL, err := keptn.GetServiceEndpoint(configservice)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Could not get service endpoint for %s: %s", configservice, err.Error()))
+		return err
+	}
+
+	var payload []byte
+	if body != nil {
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, configServiceURL.String()+"/v1/project/"+project+"/stage/"+stage, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		logger.Info(fmt.Sprintf("Stage %s successfully processed", stage))
+		return nil
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var errObj configmodels.Error
+	if err := json.Unmarshal(respBody, &errObj); err == nil && errObj.Message != nil {
+		return errors.New(*errObj.Message)
+	}
+	return fmt.Errorf("received status code %d", resp.StatusCode)
+}
+
+// renameStageInShipyard renames a stage in the shipyard and keeps all other properties of the shipyard
+func renameStageInShipyard(shipyard string, stage string, newStage string) (string, error) {
+	return updateShipyardStages(shipyard, stage, func(stages []interface{}, index int) ([]interface{}, error) {
+		if findShipyardStage(stages, newStage) >= 0 {
+			return nil, fmt.Errorf("Stage %s already exists in shipyard", newStage)
+		}
+		stageEntry := stages[index].(yaml.MapSlice)
+		for i := range stageEntry {
+			if stageEntry[i].Key == "name" {
+				stageEntry[i].Value = newStage
+			}
+		}
+		return stages, nil
+	})
+}
+
+// removeStageFromShipyard removes a stage from the shipyard and keeps all other properties of the shipyard
+func removeStageFromShipyard(shipyard string, stage string) (string, error) {
+	return updateShipyardStages(shipyard, stage, func(stages []interface{}, index int) ([]interface{}, error) {
+		return append(stages[:index], stages[index+1:]...), nil
+	})
+}
+
+func updateShipyardStages(shipyard string, stage string,
+	update func(stages []interface{}, index int) ([]interface{}, error)) (string, error) {
+
+	content := yaml.MapSlice{}
+	if err := yaml.Unmarshal([]byte(shipyard), &content); err != nil {
+		return "", fmt.Errorf("Could not unmarshal shipyard. %s", err.Error())
+	}
+	for i := range content {
+		if content[i].Key != "stages" {
+			continue
+		}
+		stages, _ := content[i].Value.([]interface{})
+		index := findShipyardStage(stages, stage)
+		if index < 0 {
+			break
+		}
+		newStages, err := update(stages, index)
+		if err != nil {
+			return "", err
+		}
+		content[i].Value = newStages
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("Stage %s does not exist in shipyard", stage)
+}
+
+func findShipyardStage(stages []interface{}, stage string) int {
+	for i, s := range stages {
+		stageEntry, ok := s.(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+		for _, item := range stageEntry {
+			if item.Key == "name" && item.Value == stage {
+				return i
+			}
+		}
+	}
+	return -1
+}